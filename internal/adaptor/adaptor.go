@@ -19,6 +19,16 @@ type Adaptor struct {
 	DashboardAdaptor   DashboardHandler
 	RevenueAdaptor      *RevenueAdaptor
 	ReservationsAdaptor *ReservationsAdaptor
+	TableAdaptor        *TableAdaptor
+	TaxAdaptor          *TaxAdaptor
+	PromotionAdaptor    *PromotionAdaptor
+	KitchenAdaptor      *KitchenAdaptor
+	ModifierAdaptor     *ModifierAdaptor
+	RecipeAdaptor       *RecipeAdaptor
+	SupplierAdaptor     *SupplierAdaptor
+	PurchaseOrderAdaptor *PurchaseOrderAdaptor
+	StocktakeAdaptor     *StocktakeAdaptor
+	WasteAdaptor         *WasteAdaptor
 }
 
 // NewAdaptor creates a new instance of Adaptor with all handlers
@@ -35,5 +45,15 @@ func NewAdaptor(uc *usecase.UseCase, logger *zap.Logger) *Adaptor {
 		DashboardAdaptor:   NewDashboardHandler(uc.DashboardUseCase, logger),
 		RevenueAdaptor:      NewRevenueAdaptor(uc.RevenueUseCase, logger),
 		ReservationsAdaptor: NewReservationsAdaptor(uc.ReservationsUseCase, logger),
+		TableAdaptor:        NewTableAdaptor(uc.TableUseCase, logger),
+		TaxAdaptor:          NewTaxAdaptor(uc.TaxUseCase, logger),
+		PromotionAdaptor:    NewPromotionAdaptor(uc.PromotionUseCase, logger),
+		KitchenAdaptor:      NewKitchenAdaptor(uc.KitchenUseCase, logger),
+		ModifierAdaptor:     NewModifierAdaptor(uc.ModifierUseCase, logger),
+		RecipeAdaptor:       NewRecipeAdaptor(uc.RecipeUseCase, logger),
+		SupplierAdaptor:     NewSupplierAdaptor(uc.SupplierUseCase, logger),
+		PurchaseOrderAdaptor: NewPurchaseOrderAdaptor(uc.PurchaseOrderUseCase, logger),
+		StocktakeAdaptor:     NewStocktakeAdaptor(uc.StocktakeUseCase, logger),
+		WasteAdaptor:         NewWasteAdaptor(uc.WasteUseCase, logger),
 	}
 }
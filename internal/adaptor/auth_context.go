@@ -0,0 +1,35 @@
+package adaptor
+
+import (
+	"net/http"
+
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// authenticatedUserID mengambil user_id dari token JWT yang di-set AuthMiddleware ke context
+func authenticatedUserID(c *gin.Context) (uint, bool) {
+	value, exists := c.Get("user_id")
+	if !exists {
+		return 0, false
+	}
+	userID, ok := value.(uint)
+	return userID, ok && userID != 0
+}
+
+// requireAuthenticatedUser seperti authenticatedUserID, tetapi menulis response 401 jika user
+// tidak terautentikasi. Persetujuan manager (override harga, void, refund, stocktake) dan pelaku
+// yang tercatat di jejak audit order selalu diambil dari sini, tidak pernah dari body request.
+func requireAuthenticatedUser(c *gin.Context, logger *zap.Logger) (uint, bool) {
+	userID, ok := authenticatedUserID(c)
+	if !ok {
+		logger.Warn("User ID not found in context",
+			zap.String("path", c.Request.URL.Path),
+			zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusUnauthorized, "User tidak terautentikasi")
+		return 0, false
+	}
+	return userID, true
+}
@@ -1,6 +1,7 @@
 package adaptor
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -125,7 +126,7 @@ func (h *InventoriesAdaptor) CreateInventory(c *gin.Context) {
 	h.logger.Debug("Request body bound successfully",
 		zap.String("name", req.Name),
 		zap.String("category", req.Category),
-		zap.Int("quantity", req.Quantity),
+		zap.Float64("quantity", req.Quantity),
 		zap.String("status", req.Status),
 	)
 
@@ -137,7 +138,11 @@ func (h *InventoriesAdaptor) CreateInventory(c *gin.Context) {
 			zap.String("name", req.Name),
 			zap.String("client_ip", c.ClientIP()),
 		)
-		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal membuat inventory: "+err.Error())
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidInventoryUnit) {
+			status = http.StatusUnprocessableEntity
+		}
+		utils.ResponseError(c.Writer, status, "Gagal membuat inventory: "+err.Error())
 		return
 	}
 
@@ -194,7 +199,14 @@ func (h *InventoriesAdaptor) UpdateInventory(c *gin.Context) {
 			zap.Int64("id", idInt),
 			zap.String("client_ip", c.ClientIP()),
 		)
-		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal memperbarui inventory: "+err.Error())
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrInventoryNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, usecase.ErrInvalidInventoryUnit):
+			status = http.StatusUnprocessableEntity
+		}
+		utils.ResponseError(c.Writer, status, "Gagal memperbarui inventory: "+err.Error())
 		return
 	}
 
@@ -243,3 +255,131 @@ func (h *InventoriesAdaptor) DeleteInventory(c *gin.Context) {
 	// Return response
 	utils.ResponseSuccess(c.Writer, http.StatusOK, "Inventory berhasil dihapus", nil)
 }
+
+// GetStockMovements menangani request untuk mengambil riwayat pergerakan stok inventory
+// (query param opsional: type, start_date, end_date dengan format YYYY-MM-DD, page, limit)
+func (h *InventoriesAdaptor) GetStockMovements(c *gin.Context) {
+	h.logger.Debug("GetStockMovements handler called", zap.String("client_ip", c.ClientIP()))
+
+	id := c.Param("id")
+	var idInt int64
+	if _, err := fmt.Sscanf(id, "%d", &idInt); err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", id),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var filter dto.StockMovementFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for stock movements",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.inventoriesUsecase.GetStockMovements(c.Request.Context(), idInt, filter)
+	if err != nil {
+		h.logger.Error("Failed to get stock movements",
+			zap.Error(err),
+			zap.Int64("id", idInt),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrInventoryNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, usecase.ErrInvalidStockMovementFilter):
+			status = http.StatusBadRequest
+		}
+		utils.ResponseError(c.Writer, status, "Gagal mengambil pergerakan stok: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Pergerakan stok berhasil diambil", response)
+}
+
+// GetValuationReport menangani request laporan nilai persediaan
+// (query param opsional: method=fifo|weighted_average, category)
+func (h *InventoriesAdaptor) GetValuationReport(c *gin.Context) {
+	h.logger.Debug("GetValuationReport handler called", zap.String("client_ip", c.ClientIP()))
+
+	var filter dto.InventoryValuationFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for inventory valuation",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.inventoriesUsecase.GetValuationReport(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to get inventory valuation",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal mengambil nilai persediaan: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Nilai persediaan berhasil diambil", response)
+}
+
+// GetUnitCatalog menangani request katalog satuan beserta faktor konversinya
+func (h *InventoriesAdaptor) GetUnitCatalog(c *gin.Context) {
+	h.logger.Debug("GetUnitCatalog handler called", zap.String("client_ip", c.ClientIP()))
+
+	response := h.inventoriesUsecase.GetUnitCatalog(c.Request.Context())
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Katalog satuan berhasil diambil", response)
+}
+
+// GetBatches menangani request daftar batch/lot inventory urut FEFO (query param opsional: include_empty)
+func (h *InventoriesAdaptor) GetBatches(c *gin.Context) {
+	h.logger.Debug("GetBatches handler called", zap.String("client_ip", c.ClientIP()))
+
+	id := c.Param("id")
+	var idInt int64
+	if _, err := fmt.Sscanf(id, "%d", &idInt); err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", id),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var filter dto.InventoryBatchFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for inventory batches",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.inventoriesUsecase.GetBatches(c.Request.Context(), idInt, filter)
+	if err != nil {
+		h.logger.Error("Failed to get inventory batches",
+			zap.Error(err),
+			zap.Int64("id", idInt),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInventoryNotFound) {
+			status = http.StatusNotFound
+		}
+		utils.ResponseError(c.Writer, status, "Gagal mengambil batch inventory: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Batch inventory berhasil diambil", response)
+}
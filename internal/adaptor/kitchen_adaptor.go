@@ -0,0 +1,200 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KitchenAdaptor menangani request HTTP untuk station dan tiket dapur (KDS)
+type KitchenAdaptor struct {
+	kitchenUsecase usecase.KitchenUseCase
+	logger         *zap.Logger
+}
+
+// NewKitchenAdaptor membuat instance baru dari KitchenAdaptor
+func NewKitchenAdaptor(kitchenUsecase usecase.KitchenUseCase, logger *zap.Logger) *KitchenAdaptor {
+	return &KitchenAdaptor{
+		kitchenUsecase: kitchenUsecase,
+		logger:         logger,
+	}
+}
+
+// GetAllStations menangani request untuk mengambil semua station dapur
+func (h *KitchenAdaptor) GetAllStations(c *gin.Context) {
+	h.logger.Debug("GetAllStations handler called")
+
+	response, err := h.kitchenUsecase.GetAllStations(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get kitchen stations", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal mengambil station dapur: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data station dapur berhasil diambil", response)
+}
+
+// CreateStation menangani request untuk membuat station dapur beserta kategori yang diarahkan ke station
+func (h *KitchenAdaptor) CreateStation(c *gin.Context) {
+	h.logger.Debug("CreateStation handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.KitchenStationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create kitchen station", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.kitchenUsecase.CreateStation(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create kitchen station", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal membuat station dapur: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Kitchen station created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Station dapur berhasil dibuat", response)
+}
+
+// UpdateStation menangani request untuk update station dapur
+func (h *KitchenAdaptor) UpdateStation(c *gin.Context) {
+	h.logger.Debug("UpdateStation handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.KitchenStationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update kitchen station", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.kitchenUsecase.UpdateStation(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update kitchen station", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal memperbarui station dapur: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Kitchen station updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Station dapur berhasil diperbarui", response)
+}
+
+// DeleteStation menangani request untuk menghapus station dapur
+func (h *KitchenAdaptor) DeleteStation(c *gin.Context) {
+	h.logger.Debug("DeleteStation handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.kitchenUsecase.DeleteStation(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete kitchen station", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal menghapus station dapur: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Kitchen station deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Station dapur berhasil dihapus", nil)
+}
+
+// GetTickets menangani request untuk mengambil tiket dapur
+// (query param opsional: station_id, status=queued,cooking,ready,cancelled; default tiket aktif)
+func (h *KitchenAdaptor) GetTickets(c *gin.Context) {
+	h.logger.Debug("GetTickets handler called")
+
+	stationID, _ := strconv.ParseUint(c.Query("station_id"), 10, 32)
+
+	response, err := h.kitchenUsecase.GetTickets(c.Request.Context(), uint(stationID), c.Query("status"))
+	if err != nil {
+		h.logger.Error("Failed to get kitchen tickets", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal mengambil tiket dapur: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data tiket dapur berhasil diambil", response)
+}
+
+// BumpItem menangani request untuk memajukan status item dapur (queued -> cooking -> ready)
+func (h *KitchenAdaptor) BumpItem(c *gin.Context) {
+	h.logger.Debug("BumpItem handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.kitchenUsecase.BumpItem(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to bump kitchen item", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal bump item dapur: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Kitchen item bumped successfully", zap.Uint("id", id), zap.String("ticket_status", response.Status))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Item dapur berhasil di-bump", response)
+}
+
+// ReadyTicket menangani request untuk menandai semua item tiket dapur ready
+func (h *KitchenAdaptor) ReadyTicket(c *gin.Context) {
+	h.logger.Debug("ReadyTicket handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.kitchenUsecase.ReadyTicket(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to mark kitchen ticket ready", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, kitchenErrorStatus(err), "Gagal menyelesaikan tiket dapur: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Kitchen ticket marked ready", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Tiket dapur siap disajikan", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *KitchenAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// kitchenErrorStatus memetakan error domain dapur ke HTTP status code
+func kitchenErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrKitchenStationNotFound),
+		errors.Is(err, usecase.ErrKitchenCategoryNotFound),
+		errors.Is(err, usecase.ErrKitchenTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidKitchenStatus):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrKitchenItemNotBumpable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
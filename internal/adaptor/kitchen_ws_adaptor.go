@@ -0,0 +1,76 @@
+package adaptor
+
+import (
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type KitchenWebsocketHandler struct {
+	kitchenUC usecase.KitchenUseCase
+	logger    *zap.Logger
+}
+
+func NewKitchenWebsocketHandler(kitchenUC usecase.KitchenUseCase, logger *zap.Logger) *KitchenWebsocketHandler {
+	return &KitchenWebsocketHandler{
+		kitchenUC: kitchenUC,
+		logger:    logger,
+	}
+}
+
+// Websocket endpoint: /api/v1/kitchen/ws?station_id=1 (tanpa station_id = semua station)
+// Saat terhubung dikirim snapshot tiket aktif, selanjutnya setiap perubahan tiket dikirim langsung.
+func (h *KitchenWebsocketHandler) ServeWs(c *gin.Context) {
+	stationID, _ := strconv.ParseUint(c.Query("station_id"), 10, 32)
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("Failed to upgrade websocket", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	// Subscribe sebelum snapshot agar tidak ada perubahan yang terlewat
+	events, unsubscribe := h.kitchenUC.Subscribe(uint(stationID))
+	defer unsubscribe()
+
+	tickets, err := h.kitchenUC.GetTickets(c.Request.Context(), uint(stationID), "")
+	if err != nil {
+		h.logger.Error("Failed to get kitchen tickets", zap.Error(err))
+		return
+	}
+	if err := conn.WriteJSON(dto.KitchenEvent{Type: dto.KitchenEventSnapshot, Tickets: tickets}); err != nil {
+		h.logger.Error("Failed to write websocket message", zap.Error(err))
+		return
+	}
+
+	// Baca pesan dari client hanya untuk mendeteksi koneksi ditutup
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				h.logger.Error("Failed to write websocket message", zap.Error(err))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ModifierAdaptor menangani request HTTP untuk modifier group (add-on, ukuran, tanpa bahan)
+type ModifierAdaptor struct {
+	modifierUsecase usecase.ModifierUseCase
+	logger          *zap.Logger
+}
+
+// NewModifierAdaptor membuat instance baru dari ModifierAdaptor
+func NewModifierAdaptor(modifierUsecase usecase.ModifierUseCase, logger *zap.Logger) *ModifierAdaptor {
+	return &ModifierAdaptor{
+		modifierUsecase: modifierUsecase,
+		logger:          logger,
+	}
+}
+
+// GetAllModifierGroups menangani request untuk mengambil modifier group
+// (query param opsional: product_id untuk group yang berlaku di produk tersebut)
+func (h *ModifierAdaptor) GetAllModifierGroups(c *gin.Context) {
+	h.logger.Debug("GetAllModifierGroups handler called")
+
+	productID, _ := strconv.ParseUint(c.Query("product_id"), 10, 32)
+
+	response, err := h.modifierUsecase.GetAllModifierGroups(c.Request.Context(), uint(productID))
+	if err != nil {
+		h.logger.Error("Failed to get modifier groups", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, modifierErrorStatus(err), "Gagal mengambil data modifier: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data modifier berhasil diambil", response)
+}
+
+// GetModifierGroupByID menangani request untuk mengambil detail modifier group
+func (h *ModifierAdaptor) GetModifierGroupByID(c *gin.Context) {
+	h.logger.Debug("GetModifierGroupByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.modifierUsecase.GetModifierGroupByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get modifier group", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, modifierErrorStatus(err), "Gagal mengambil modifier: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Modifier berhasil diambil", response)
+}
+
+// CreateModifierGroup menangani request untuk membuat modifier group baru
+func (h *ModifierAdaptor) CreateModifierGroup(c *gin.Context) {
+	h.logger.Debug("CreateModifierGroup handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.ModifierGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create modifier group", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.modifierUsecase.CreateModifierGroup(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create modifier group", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, modifierErrorStatus(err), "Gagal membuat modifier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Modifier group created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Modifier berhasil dibuat", response)
+}
+
+// UpdateModifierGroup menangani request untuk update modifier group
+func (h *ModifierAdaptor) UpdateModifierGroup(c *gin.Context) {
+	h.logger.Debug("UpdateModifierGroup handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ModifierGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update modifier group", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.modifierUsecase.UpdateModifierGroup(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update modifier group", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, modifierErrorStatus(err), "Gagal memperbarui modifier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Modifier group updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Modifier berhasil diperbarui", response)
+}
+
+// DeleteModifierGroup menangani request untuk menghapus modifier group
+func (h *ModifierAdaptor) DeleteModifierGroup(c *gin.Context) {
+	h.logger.Debug("DeleteModifierGroup handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.modifierUsecase.DeleteModifierGroup(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete modifier group", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, modifierErrorStatus(err), "Gagal menghapus modifier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Modifier group deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Modifier berhasil dihapus", nil)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *ModifierAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// modifierErrorStatus memetakan error domain modifier ke HTTP status code
+func modifierErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrModifierGroupNotFound),
+		errors.Is(err, usecase.ErrModifierTargetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidModifierGroup):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
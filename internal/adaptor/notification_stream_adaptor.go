@@ -0,0 +1,227 @@
+package adaptor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/middleware"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// notificationStreamHeartbeat adalah interval ping agar koneksi idle tidak diputus proxy
+const notificationStreamHeartbeat = 30 * time.Second
+
+// notificationUpgrader membalas subprotocol token jika client mengirim token lewat
+// Sec-WebSocket-Protocol, karena browser menolak handshake yang subprotocol-nya tidak dijawab
+var notificationUpgrader = websocket.Upgrader{
+	CheckOrigin:  func(r *http.Request) bool { return true },
+	Subprotocols: []string{middleware.WebSocketTokenProtocol},
+}
+
+type NotificationStreamHandler struct {
+	notificationUC usecase.NotificationUseCase
+	logger         *zap.Logger
+}
+
+func NewNotificationStreamHandler(notificationUC usecase.NotificationUseCase, logger *zap.Logger) *NotificationStreamHandler {
+	return &NotificationStreamHandler{
+		notificationUC: notificationUC,
+		logger:         logger,
+	}
+}
+
+// Stream endpoint: /api/v1/notifications/stream. Token dikirim lewat Authorization: Bearer <token>,
+// query access_token (EventSource), atau Sec-WebSocket-Protocol: bearer, <token> (WebSocket browser).
+// Request upgrade websocket dilayani sebagai websocket, selain itu sebagai Server-Sent Events.
+// Last-Event-ID (header, atau query last_event_id untuk websocket) mengirim ulang notifikasi
+// setelah ID tersebut; setelah itu dikirim unread_count dan event baru secara langsung.
+func (h *NotificationStreamHandler) ServeStream(c *gin.Context) {
+	uid, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var afterID uint64
+	if lastEventID != "" {
+		var err error
+		afterID, err = strconv.ParseUint(lastEventID, 10, 32)
+		if err != nil {
+			h.logger.Warn("Invalid Last-Event-ID", zap.String("last_event_id", lastEventID), zap.String("client_ip", c.ClientIP()))
+			utils.ResponseError(c.Writer, http.StatusBadRequest, "Last-Event-ID tidak valid")
+			return
+		}
+	}
+
+	// Subscribe sebelum backlog agar tidak ada notifikasi yang terlewat
+	events, unsubscribe, err := h.notificationUC.Subscribe(uid)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrNotificationStreamClosed) {
+			status = http.StatusServiceUnavailable
+		}
+		utils.ResponseError(c.Writer, status, "Gagal membuka stream notifikasi: "+err.Error())
+		return
+	}
+	defer unsubscribe()
+
+	backlog, err := h.notificationUC.GetStreamBacklog(c.Request.Context(), uid, uint(afterID))
+	if err != nil {
+		h.logger.Error("Failed to get notification stream backlog", zap.Uint("user_id", uid), zap.Error(err))
+		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal mengambil notifikasi: "+err.Error())
+		return
+	}
+
+	// Notifikasi live dengan ID <= ID terakhir backlog sudah terkirim lewat backlog
+	sentID := uint(afterID)
+	for _, event := range backlog {
+		if event.ID > sentID {
+			sentID = event.ID
+		}
+	}
+
+	h.logger.Info("Notification stream opened",
+		zap.Uint("user_id", uid),
+		zap.Bool("websocket", websocket.IsWebSocketUpgrade(c.Request)),
+		zap.Uint64("last_event_id", afterID))
+	defer h.logger.Info("Notification stream closed", zap.Uint("user_id", uid))
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		h.serveWebsocket(c, backlog, events, sentID)
+		return
+	}
+	h.serveSSE(c, backlog, events, sentID)
+}
+
+func (h *NotificationStreamHandler) serveWebsocket(c *gin.Context, backlog []dto.NotificationStreamEvent, events <-chan dto.NotificationStreamEvent, sentID uint) {
+	conn, err := notificationUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("Failed to upgrade websocket", zap.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	for _, event := range backlog {
+		if err := conn.WriteJSON(event); err != nil {
+			h.logger.Error("Failed to write websocket message", zap.Error(err))
+			return
+		}
+	}
+
+	// Baca pesan dari client hanya untuk mendeteksi koneksi ditutup
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(notificationStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				// Hub ditutup karena server shutdown
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(time.Second))
+				return
+			}
+			if event.ID != 0 && event.ID <= sentID {
+				continue
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				h.logger.Error("Failed to write websocket message", zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+func (h *NotificationStreamHandler) serveSSE(c *gin.Context, backlog []dto.NotificationStreamEvent, events <-chan dto.NotificationStreamEvent, sentID uint) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// Client menyambung ulang setelah 3 detik jika koneksi terputus
+	if _, err := fmt.Fprint(c.Writer, "retry: 3000\n\n"); err != nil {
+		return
+	}
+	for _, event := range backlog {
+		if err := writeSSEEvent(c.Writer, event); err != nil {
+			h.logger.Error("Failed to write SSE event", zap.Error(err))
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(notificationStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				// Hub ditutup karena server shutdown
+				return
+			}
+			if event.ID != 0 && event.ID <= sentID {
+				continue
+			}
+			if err := writeSSEEvent(c.Writer, event); err != nil {
+				h.logger.Error("Failed to write SSE event", zap.Error(err))
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeSSEEvent menulis satu event SSE; field id hanya ditulis untuk event notifikasi agar
+// Last-Event-ID client selalu menunjuk notifikasi terakhir yang diterima
+func writeSSEEvent(w gin.ResponseWriter, event dto.NotificationStreamEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+	return err
+}
@@ -1,15 +1,20 @@
 package adaptor
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/internal/dto"
 	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/receipt"
 	"aplikasi-pos-team-boolean/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // OrderAdaptor menangani semua request HTTP untuk orders
@@ -71,6 +76,12 @@ func (h *OrderAdaptor) CreateOrder(c *gin.Context) {
 		zap.Int("items_count", len(req.Items)),
 	)
 
+	userID, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.PriceApprovedBy = userID
+
 	// Call usecase
 	response, err := h.orderUsecase.CreateOrder(c.Request.Context(), req)
 	if err != nil {
@@ -79,7 +90,7 @@ func (h *OrderAdaptor) CreateOrder(c *gin.Context) {
 			zap.String("customer_name", req.CustomerName),
 			zap.String("client_ip", c.ClientIP()),
 		)
-		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal membuat order: "+err.Error())
+		h.responseOrderError(c, err, "Gagal membuat order: ")
 		return
 	}
 
@@ -128,6 +139,12 @@ func (h *OrderAdaptor) UpdateOrder(c *gin.Context) {
 		zap.Int("items_count", len(req.Items)),
 	)
 
+	userID, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.PriceApprovedBy = userID
+
 	// Call usecase
 	err = h.orderUsecase.UpdateOrder(c.Request.Context(), uint(id), req)
 	if err != nil {
@@ -136,7 +153,7 @@ func (h *OrderAdaptor) UpdateOrder(c *gin.Context) {
 			zap.Uint("id", uint(id)),
 			zap.String("client_ip", c.ClientIP()),
 		)
-		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal memperbarui order: "+err.Error())
+		h.responseOrderError(c, err, "Gagal memperbarui order: ")
 		return
 	}
 
@@ -176,7 +193,7 @@ func (h *OrderAdaptor) DeleteOrder(c *gin.Context) {
 			zap.Uint("id", uint(id)),
 			zap.String("client_ip", c.ClientIP()),
 		)
-		utils.ResponseError(c.Writer, http.StatusInternalServerError, "Gagal menghapus order: "+err.Error())
+		h.responseOrderError(c, err, "Gagal menghapus order: ")
 		return
 	}
 
@@ -186,6 +203,565 @@ func (h *OrderAdaptor) DeleteOrder(c *gin.Context) {
 	utils.ResponseSuccess(c.Writer, http.StatusOK, "Order berhasil dihapus", nil)
 }
 
+// ChangeOrderStatus menangani request untuk mengubah status order (PATCH /orders/:id/status)
+func (h *OrderAdaptor) ChangeOrderStatus(c *gin.Context) {
+	h.logger.Debug("ChangeOrderStatus handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderStatusUpdateRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for change order status",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	changedBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.ChangedBy = changedBy
+
+	// Call usecase
+	err = h.orderUsecase.ChangeOrderStatus(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to change order status",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("status", req.Status),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal mengubah status order: ")
+		return
+	}
+
+	h.logger.Info("Order status changed successfully",
+		zap.Uint("id", uint(id)),
+		zap.String("status", req.Status),
+	)
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Status order berhasil diubah", nil)
+}
+
+// GetOrderStatusHistory menangani request untuk mengambil riwayat status order
+func (h *OrderAdaptor) GetOrderStatusHistory(c *gin.Context) {
+	h.logger.Debug("GetOrderStatusHistory handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	// Call usecase
+	response, err := h.orderUsecase.GetOrderStatusHistory(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get order status history",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal mengambil riwayat status order: ")
+		return
+	}
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Riwayat status order berhasil diambil", response)
+}
+
+// AddPayments menangani request pembayaran order dengan satu atau beberapa tender (split tender)
+func (h *OrderAdaptor) AddPayments(c *gin.Context) {
+	h.logger.Debug("AddPayments handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderPaymentRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for order payment",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	receivedBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.ReceivedBy = receivedBy
+
+	// Call usecase
+	response, err := h.orderUsecase.AddPayments(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to add order payments",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal memproses pembayaran: ")
+		return
+	}
+
+	h.logger.Info("Order payments added successfully",
+		zap.Uint("id", uint(id)),
+		zap.String("status", response.Status),
+	)
+
+	message := "Pembayaran berhasil dicatat"
+	if response.RemainingAmount <= 0 {
+		message = "Pembayaran berhasil, order lunas"
+	}
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, message, response)
+}
+
+// GetOrderPayments menangani request untuk mengambil daftar pembayaran order
+func (h *OrderAdaptor) GetOrderPayments(c *gin.Context) {
+	h.logger.Debug("GetOrderPayments handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	// Call usecase
+	response, err := h.orderUsecase.GetOrderPayments(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get order payments",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal mengambil pembayaran order: ")
+		return
+	}
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Pembayaran order berhasil diambil", response)
+}
+
+// SplitOrderItems menangani request untuk memindah sebagian item order ke order baru (split bill per item)
+func (h *OrderAdaptor) SplitOrderItems(c *gin.Context) {
+	h.logger.Debug("SplitOrderItems handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderSplitItemsRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for split order items",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	createdBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.CreatedBy = createdBy
+
+	// Call usecase
+	response, err := h.orderUsecase.SplitOrderItems(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to split order items",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal split order: ")
+		return
+	}
+
+	h.logger.Info("Order items split successfully", zap.Uint("id", uint(id)))
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Order berhasil di-split", response)
+}
+
+// SplitOrderEven menangani request untuk membagi order menjadi beberapa bagian dengan nominal sama
+func (h *OrderAdaptor) SplitOrderEven(c *gin.Context) {
+	h.logger.Debug("SplitOrderEven handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderSplitEvenRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for split order evenly",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	createdBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.CreatedBy = createdBy
+
+	// Call usecase
+	response, err := h.orderUsecase.SplitOrderEven(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to split order evenly",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal membagi order: ")
+		return
+	}
+
+	h.logger.Info("Order split evenly successfully", zap.Uint("id", uint(id)))
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Order berhasil dibagi rata", response)
+}
+
+// MergeOrders menangani request untuk menggabungkan order lain (misal dari meja lain) ke order ini
+func (h *OrderAdaptor) MergeOrders(c *gin.Context) {
+	h.logger.Debug("MergeOrders handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderMergeRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for merge orders",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	createdBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.CreatedBy = createdBy
+
+	// Call usecase
+	response, err := h.orderUsecase.MergeOrders(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to merge orders",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal menggabungkan order: ")
+		return
+	}
+
+	h.logger.Info("Orders merged successfully", zap.Uint("id", uint(id)))
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Order berhasil digabung", response)
+}
+
+// GetOrderLinks menangani request untuk mengambil jejak audit split/merge order
+func (h *OrderAdaptor) GetOrderLinks(c *gin.Context) {
+	h.logger.Debug("GetOrderLinks handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	// Call usecase
+	response, err := h.orderUsecase.GetOrderLinks(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get order links",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal mengambil relasi order: ")
+		return
+	}
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Relasi order berhasil diambil", response)
+}
+
+// VoidOrder menangani request void order yang belum dibayar (butuh kode alasan dan persetujuan manager)
+func (h *OrderAdaptor) VoidOrder(c *gin.Context) {
+	h.logger.Debug("VoidOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderVoidRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for void order",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	userID, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.ApprovedBy = userID
+	req.ProcessedBy = userID
+
+	// Call usecase
+	response, err := h.orderUsecase.VoidOrder(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to void order",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal void order: ")
+		return
+	}
+
+	h.logger.Info("Order voided successfully",
+		zap.Uint("id", uint(id)),
+		zap.Uint("refund_id", response.ID),
+		zap.Float64("amount", response.Amount),
+	)
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Order berhasil di-void", response)
+}
+
+// RefundOrder menangani request refund order yang sudah dibayar, penuh atau per item
+func (h *OrderAdaptor) RefundOrder(c *gin.Context) {
+	h.logger.Debug("RefundOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	var req dto.OrderRefundRequest
+
+	// Bind JSON request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for refund order",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	userID, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req.ApprovedBy = userID
+	req.ProcessedBy = userID
+
+	// Call usecase
+	response, err := h.orderUsecase.RefundOrder(c.Request.Context(), uint(id), req)
+	if err != nil {
+		h.logger.Error("Failed to refund order",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal refund order: ")
+		return
+	}
+
+	h.logger.Info("Order refunded successfully",
+		zap.Uint("id", uint(id)),
+		zap.Uint("refund_id", response.ID),
+		zap.Float64("amount", response.Amount),
+	)
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Refund berhasil dicatat", response)
+}
+
+// GetOrderRefunds menangani request untuk mengambil riwayat void/refund order
+func (h *OrderAdaptor) GetOrderRefunds(c *gin.Context) {
+	h.logger.Debug("GetOrderRefunds handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	// Call usecase
+	response, err := h.orderUsecase.GetOrderRefunds(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("Failed to get order refunds",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal mengambil refund order: ")
+		return
+	}
+
+	// Return response
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Riwayat void/refund order berhasil diambil", response)
+}
+
+// GetOrderReceipt menangani request cetak receipt order
+// (query param: format=text|html|escpos, default text; copy=true untuk cetak ulang)
+func (h *OrderAdaptor) GetOrderReceipt(c *gin.Context) {
+	h.logger.Debug("GetOrderReceipt handler called", zap.String("client_ip", c.ClientIP()))
+
+	// Get ID from path parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return
+	}
+
+	format := c.DefaultQuery("format", receipt.FormatText)
+	isCopy := utils.StringToBool(c.Query("copy"))
+
+	// Call usecase
+	body, contentType, err := h.orderUsecase.GetOrderReceipt(c.Request.Context(), uint(id), format, isCopy)
+	if err != nil {
+		h.logger.Error("Failed to render order receipt",
+			zap.Error(err),
+			zap.Uint("id", uint(id)),
+			zap.String("format", format),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		h.responseOrderError(c, err, "Gagal membuat receipt: ")
+		return
+	}
+
+	if format == receipt.FormatESCPOS {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receipt-%d.bin", id))
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
 // GetAllTables menangani request untuk mengambil semua meja
 func (h *OrderAdaptor) GetAllTables(c *gin.Context) {
 	h.logger.Debug("GetAllTables handler called")
@@ -254,3 +830,63 @@ func (h *OrderAdaptor) GetAvailableChairs(c *gin.Context) {
 	// Return response
 	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data kursi tersedia berhasil diambil", response)
 }
+
+// responseOrderError menulis response error order; error stok menyertakan daftar product_id dan variant_id
+func (h *OrderAdaptor) responseOrderError(c *gin.Context, err error, prefix string) {
+	var stockErr *repository.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		utils.ResponseBadRequest(c.Writer, http.StatusConflict, prefix+err.Error(), map[string]interface{}{
+			"product_ids": stockErr.ProductIDs,
+			"variant_ids": stockErr.VariantIDs,
+		})
+		return
+	}
+
+	utils.ResponseError(c.Writer, orderErrorStatus(err), prefix+err.Error())
+}
+
+// orderErrorStatus memetakan error domain order ke HTTP status code
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrOrderNotEditable),
+		errors.Is(err, repository.ErrOrderStatusConflict),
+		errors.Is(err, usecase.ErrInvalidStatusTransition),
+		errors.Is(err, usecase.ErrOrderNotDeletable),
+		errors.Is(err, repository.ErrOrderNotRefundable):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrProductNotFound),
+		errors.Is(err, repository.ErrVariantNotFound),
+		errors.Is(err, repository.ErrTableNotFound),
+		errors.Is(err, repository.ErrOutletNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrTableUnavailable):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrProductUnavailable),
+		errors.Is(err, repository.ErrInvalidModifier),
+		errors.Is(err, repository.ErrVariantRequired),
+		errors.Is(err, repository.ErrInvalidBundleChoice):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, repository.ErrPriceOverrideNotApproved),
+		errors.Is(err, usecase.ErrManagerApprovalRequired):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrPromoCodeInvalid),
+		errors.Is(err, repository.ErrPromoNotApplicable):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, repository.ErrPromoUsageExceeded),
+		errors.Is(err, repository.ErrOrderAlreadySettled):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrPaymentMethodNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrPaymentExceedsBalance),
+		errors.Is(err, repository.ErrInvalidSplit),
+		errors.Is(err, repository.ErrInvalidMerge),
+		errors.Is(err, repository.ErrInvalidRefund):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, receipt.ErrInvalidFormat):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
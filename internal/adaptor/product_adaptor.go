@@ -4,6 +4,7 @@ import (
 	"aplikasi-pos-team-boolean/internal/dto"
 	"aplikasi-pos-team-boolean/internal/usecase"
 	"aplikasi-pos-team-boolean/pkg/utils"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -258,6 +259,23 @@ func (h *ProductAdaptor) Create(c *gin.Context) {
 			})
 			return
 		}
+		if err.Error() == "variant sku already exists" {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  false,
+				"message": err.Error(),
+				"data":    nil,
+			})
+			return
+		}
+		if err.Error() == "price is required" || err.Error() == "variant not found" || err.Error() == "duplicate variant sku" ||
+			errors.Is(err, usecase.ErrInvalidBundle) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  false,
+				"message": err.Error(),
+				"data":    nil,
+			})
+			return
+		}
 		h.logger.Error("Failed to create product",
 			zap.Error(err),
 			zap.String("product_name", req.ProductName),
@@ -354,6 +372,23 @@ func (h *ProductAdaptor) Update(c *gin.Context) {
 			})
 			return
 		}
+		if err.Error() == "variant sku already exists" {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  false,
+				"message": err.Error(),
+				"data":    nil,
+			})
+			return
+		}
+		if err.Error() == "price is required" || err.Error() == "variant not found" || err.Error() == "duplicate variant sku" ||
+			errors.Is(err, usecase.ErrInvalidBundle) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  false,
+				"message": err.Error(),
+				"data":    nil,
+			})
+			return
+		}
 		h.logger.Error("Failed to update product",
 			zap.Error(err),
 			zap.Uint64("id", id),
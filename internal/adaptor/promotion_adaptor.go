@@ -0,0 +1,162 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PromotionAdaptor menangani request HTTP untuk promo dan kode promo
+type PromotionAdaptor struct {
+	promotionUsecase usecase.PromotionUseCase
+	logger           *zap.Logger
+}
+
+// NewPromotionAdaptor membuat instance baru dari PromotionAdaptor
+func NewPromotionAdaptor(promotionUsecase usecase.PromotionUseCase, logger *zap.Logger) *PromotionAdaptor {
+	return &PromotionAdaptor{
+		promotionUsecase: promotionUsecase,
+		logger:           logger,
+	}
+}
+
+// GetAllPromotions menangani request untuk mengambil semua promo (query param opsional: active=true)
+func (h *PromotionAdaptor) GetAllPromotions(c *gin.Context) {
+	h.logger.Debug("GetAllPromotions handler called")
+
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	response, err := h.promotionUsecase.GetAllPromotions(c.Request.Context(), activeOnly)
+	if err != nil {
+		h.logger.Error("Failed to get all promotions", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, promotionErrorStatus(err), "Gagal mengambil data promo: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data promo berhasil diambil", response)
+}
+
+// GetPromotionByID menangani request untuk mengambil detail promo
+func (h *PromotionAdaptor) GetPromotionByID(c *gin.Context) {
+	h.logger.Debug("GetPromotionByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.promotionUsecase.GetPromotionByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get promotion", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, promotionErrorStatus(err), "Gagal mengambil promo: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Promo berhasil diambil", response)
+}
+
+// CreatePromotion menangani request untuk membuat promo baru
+func (h *PromotionAdaptor) CreatePromotion(c *gin.Context) {
+	h.logger.Debug("CreatePromotion handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create promotion", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.promotionUsecase.CreatePromotion(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create promotion", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, promotionErrorStatus(err), "Gagal membuat promo: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Promotion created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Promo berhasil dibuat", response)
+}
+
+// UpdatePromotion menangani request untuk update promo
+func (h *PromotionAdaptor) UpdatePromotion(c *gin.Context) {
+	h.logger.Debug("UpdatePromotion handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update promotion", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.promotionUsecase.UpdatePromotion(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update promotion", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, promotionErrorStatus(err), "Gagal memperbarui promo: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Promotion updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Promo berhasil diperbarui", response)
+}
+
+// DeletePromotion menangani request untuk menghapus promo
+func (h *PromotionAdaptor) DeletePromotion(c *gin.Context) {
+	h.logger.Debug("DeletePromotion handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.promotionUsecase.DeletePromotion(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete promotion", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, promotionErrorStatus(err), "Gagal menghapus promo: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Promotion deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Promo berhasil dihapus", nil)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *PromotionAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// promotionErrorStatus memetakan error domain promo ke HTTP status code
+func promotionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrPromotionNotFound),
+		errors.Is(err, usecase.ErrPromotionTargetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrPromoCodeExists):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidPromotion):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,253 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PurchaseOrderAdaptor menangani request HTTP untuk purchase order dan penerimaan barang
+type PurchaseOrderAdaptor struct {
+	purchaseOrderUsecase usecase.PurchaseOrderUseCase
+	logger               *zap.Logger
+}
+
+// NewPurchaseOrderAdaptor membuat instance baru dari PurchaseOrderAdaptor
+func NewPurchaseOrderAdaptor(purchaseOrderUsecase usecase.PurchaseOrderUseCase, logger *zap.Logger) *PurchaseOrderAdaptor {
+	return &PurchaseOrderAdaptor{
+		purchaseOrderUsecase: purchaseOrderUsecase,
+		logger:               logger,
+	}
+}
+
+// GetAllPurchaseOrders menangani request untuk mengambil purchase order
+// (query param opsional: status, supplier_id)
+func (h *PurchaseOrderAdaptor) GetAllPurchaseOrders(c *gin.Context) {
+	h.logger.Debug("GetAllPurchaseOrders handler called")
+
+	var filter dto.PurchaseOrderFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for purchase orders", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.GetAllPurchaseOrders(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to get purchase orders", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal mengambil data purchase order: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data purchase order berhasil diambil", response)
+}
+
+// GetPurchaseOrderByID menangani request untuk mengambil detail purchase order beserta penerimaannya
+func (h *PurchaseOrderAdaptor) GetPurchaseOrderByID(c *gin.Context) {
+	h.logger.Debug("GetPurchaseOrderByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.GetPurchaseOrderByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal mengambil purchase order: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Purchase order berhasil diambil", response)
+}
+
+// CreatePurchaseOrder menangani request untuk membuat purchase order draft
+func (h *PurchaseOrderAdaptor) CreatePurchaseOrder(c *gin.Context) {
+	h.logger.Debug("CreatePurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create purchase order", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.CreatePurchaseOrder(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create purchase order", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal membuat purchase order: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order created successfully", zap.Uint("id", response.ID), zap.String("po_number", response.PONumber))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Purchase order berhasil dibuat", response)
+}
+
+// UpdatePurchaseOrder menangani request untuk mengubah purchase order draft
+func (h *PurchaseOrderAdaptor) UpdatePurchaseOrder(c *gin.Context) {
+	h.logger.Debug("UpdatePurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update purchase order", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.UpdatePurchaseOrder(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal memperbarui purchase order: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Purchase order berhasil diperbarui", response)
+}
+
+// DeletePurchaseOrder menangani request untuk menghapus purchase order draft
+func (h *PurchaseOrderAdaptor) DeletePurchaseOrder(c *gin.Context) {
+	h.logger.Debug("DeletePurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.purchaseOrderUsecase.DeletePurchaseOrder(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal menghapus purchase order: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Purchase order berhasil dihapus", nil)
+}
+
+// SendPurchaseOrder menangani request untuk menandai purchase order sudah dikirim ke supplier
+func (h *PurchaseOrderAdaptor) SendPurchaseOrder(c *gin.Context) {
+	h.logger.Debug("SendPurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.SendPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to send purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal mengirim purchase order: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order sent successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Purchase order berhasil dikirim", response)
+}
+
+// CancelPurchaseOrder menangani request untuk membatalkan purchase order
+func (h *PurchaseOrderAdaptor) CancelPurchaseOrder(c *gin.Context) {
+	h.logger.Debug("CancelPurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.CancelPurchaseOrder(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to cancel purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal membatalkan purchase order: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order cancelled successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Purchase order berhasil dibatalkan", response)
+}
+
+// ReceivePurchaseOrder menangani request untuk mencatat penerimaan barang (goods received note)
+func (h *PurchaseOrderAdaptor) ReceivePurchaseOrder(c *gin.Context) {
+	h.logger.Debug("ReceivePurchaseOrder handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.GoodsReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for receive purchase order", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.purchaseOrderUsecase.ReceivePurchaseOrder(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to receive purchase order", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal mencatat penerimaan barang: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Purchase order received successfully", zap.Uint("id", id), zap.String("status", response.Status))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Penerimaan barang berhasil dicatat", response)
+}
+
+// GetReorderSuggestions menangani request untuk saran pemesanan ulang inventory di bawah MinStock
+func (h *PurchaseOrderAdaptor) GetReorderSuggestions(c *gin.Context) {
+	h.logger.Debug("GetReorderSuggestions handler called")
+
+	response, err := h.purchaseOrderUsecase.GetReorderSuggestions(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get reorder suggestions", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, purchaseOrderErrorStatus(err), "Gagal mengambil saran pemesanan: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Saran pemesanan berhasil diambil", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *PurchaseOrderAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// purchaseOrderErrorStatus memetakan error domain purchase order ke HTTP status code
+func purchaseOrderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrPurchaseOrderNotFound),
+		errors.Is(err, usecase.ErrSupplierNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrPurchaseOrderNotEditable),
+		errors.Is(err, repository.ErrPurchaseOrderStatusConflict):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidPurchaseOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrInvalidGoodsReceipt):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,102 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RecipeAdaptor menangani request HTTP untuk resep (bahan inventory) produk
+type RecipeAdaptor struct {
+	recipeUsecase usecase.RecipeUseCase
+	logger        *zap.Logger
+}
+
+// NewRecipeAdaptor membuat instance baru dari RecipeAdaptor
+func NewRecipeAdaptor(recipeUsecase usecase.RecipeUseCase, logger *zap.Logger) *RecipeAdaptor {
+	return &RecipeAdaptor{
+		recipeUsecase: recipeUsecase,
+		logger:        logger,
+	}
+}
+
+// GetRecipe menangani request untuk mengambil resep produk
+func (h *RecipeAdaptor) GetRecipe(c *gin.Context) {
+	h.logger.Debug("GetRecipe handler called")
+
+	productID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.recipeUsecase.GetRecipe(c.Request.Context(), productID)
+	if err != nil {
+		h.logger.Error("Failed to get recipe", zap.Error(err), zap.Uint("product_id", productID), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, recipeErrorStatus(err), "Gagal mengambil resep: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Resep berhasil diambil", response)
+}
+
+// SaveRecipe menangani request untuk mengganti resep produk
+func (h *RecipeAdaptor) SaveRecipe(c *gin.Context) {
+	h.logger.Debug("SaveRecipe handler called", zap.String("client_ip", c.ClientIP()))
+
+	productID, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for save recipe", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.recipeUsecase.SaveRecipe(c.Request.Context(), productID, req)
+	if err != nil {
+		h.logger.Error("Failed to save recipe", zap.Error(err), zap.Uint("product_id", productID), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, recipeErrorStatus(err), "Gagal menyimpan resep: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Recipe saved successfully", zap.Uint("product_id", productID), zap.Int("items", len(response.Items)))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Resep berhasil disimpan", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *RecipeAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// recipeErrorStatus memetakan error domain resep ke HTTP status code
+func recipeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrRecipeProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidRecipe):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,222 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// StocktakeAdaptor menangani request HTTP untuk stocktake (hitung fisik inventory)
+type StocktakeAdaptor struct {
+	stocktakeUsecase usecase.StocktakeUseCase
+	logger           *zap.Logger
+}
+
+// NewStocktakeAdaptor membuat instance baru dari StocktakeAdaptor
+func NewStocktakeAdaptor(stocktakeUsecase usecase.StocktakeUseCase, logger *zap.Logger) *StocktakeAdaptor {
+	return &StocktakeAdaptor{
+		stocktakeUsecase: stocktakeUsecase,
+		logger:           logger,
+	}
+}
+
+// GetAllStocktakes menangani request untuk mengambil sesi stocktake (query param opsional: status)
+func (h *StocktakeAdaptor) GetAllStocktakes(c *gin.Context) {
+	h.logger.Debug("GetAllStocktakes handler called")
+
+	response, err := h.stocktakeUsecase.GetAllStocktakes(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		h.logger.Error("Failed to get stocktakes", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal mengambil data stocktake: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data stocktake berhasil diambil", response)
+}
+
+// GetStocktakeByID menangani request untuk mengambil detail stocktake beserta hasil hitung
+func (h *StocktakeAdaptor) GetStocktakeByID(c *gin.Context) {
+	h.logger.Debug("GetStocktakeByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.stocktakeUsecase.GetStocktakeByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get stocktake", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal mengambil stocktake: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Stocktake berhasil diambil", response)
+}
+
+// CreateStocktake menangani request untuk membuka sesi stocktake baru
+func (h *StocktakeAdaptor) CreateStocktake(c *gin.Context) {
+	h.logger.Debug("CreateStocktake handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.StocktakeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create stocktake", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.stocktakeUsecase.CreateStocktake(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create stocktake", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal membuat stocktake: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Stocktake created successfully", zap.Uint("id", response.ID), zap.String("number", response.Number))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Stocktake berhasil dibuat", response)
+}
+
+// RecordCounts menangani request untuk mencatat hasil hitung satu counter
+func (h *StocktakeAdaptor) RecordCounts(c *gin.Context) {
+	h.logger.Debug("RecordCounts handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.StocktakeCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for stocktake counts", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.stocktakeUsecase.RecordCounts(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to record stocktake counts", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal mencatat hasil hitung: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Stocktake counts recorded successfully", zap.Uint("id", id), zap.Uint("counted_by", req.CountedBy))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Hasil hitung berhasil dicatat", response)
+}
+
+// ApproveStocktake menangani request persetujuan stocktake oleh manager
+func (h *StocktakeAdaptor) ApproveStocktake(c *gin.Context) {
+	h.logger.Debug("ApproveStocktake handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	approvedBy, ok := requireAuthenticatedUser(c, h.logger)
+	if !ok {
+		return
+	}
+	req := dto.StocktakeApproveRequest{ApprovedBy: approvedBy}
+
+	response, err := h.stocktakeUsecase.ApproveStocktake(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to approve stocktake", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal menyetujui stocktake: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Stocktake approved successfully", zap.Uint("id", id), zap.Uint("approved_by", req.ApprovedBy))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Stocktake berhasil disetujui", response)
+}
+
+// CancelStocktake menangani request untuk membatalkan stocktake
+func (h *StocktakeAdaptor) CancelStocktake(c *gin.Context) {
+	h.logger.Debug("CancelStocktake handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.stocktakeUsecase.CancelStocktake(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to cancel stocktake", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal membatalkan stocktake: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Stocktake cancelled successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Stocktake berhasil dibatalkan", response)
+}
+
+// GetVarianceReport menangani request laporan selisih stocktake (query param opsional: format=csv)
+func (h *StocktakeAdaptor) GetVarianceReport(c *gin.Context) {
+	h.logger.Debug("GetVarianceReport handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		data, filename, err := h.stocktakeUsecase.ExportVarianceCSV(c.Request.Context(), id)
+		if err != nil {
+			h.logger.Error("Failed to export stocktake variance", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+			utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal mengekspor laporan selisih: "+err.Error())
+			return
+		}
+
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+		return
+	}
+
+	response, err := h.stocktakeUsecase.GetVarianceReport(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get stocktake variance", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, stocktakeErrorStatus(err), "Gagal mengambil laporan selisih: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Laporan selisih stocktake berhasil diambil", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *StocktakeAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// stocktakeErrorStatus memetakan error domain stocktake ke HTTP status code
+func stocktakeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrStocktakeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrManagerApprovalRequired):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrStocktakeNotCounting):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrEmptyStocktake),
+		errors.Is(err, repository.ErrInvalidStocktakeCount):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
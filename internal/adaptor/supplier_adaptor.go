@@ -0,0 +1,157 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SupplierAdaptor menangani request HTTP untuk supplier (pemasok barang inventory)
+type SupplierAdaptor struct {
+	supplierUsecase usecase.SupplierUseCase
+	logger          *zap.Logger
+}
+
+// NewSupplierAdaptor membuat instance baru dari SupplierAdaptor
+func NewSupplierAdaptor(supplierUsecase usecase.SupplierUseCase, logger *zap.Logger) *SupplierAdaptor {
+	return &SupplierAdaptor{
+		supplierUsecase: supplierUsecase,
+		logger:          logger,
+	}
+}
+
+// GetAllSuppliers menangani request untuk mengambil semua supplier (query param opsional: active=true)
+func (h *SupplierAdaptor) GetAllSuppliers(c *gin.Context) {
+	h.logger.Debug("GetAllSuppliers handler called")
+
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	response, err := h.supplierUsecase.GetAllSuppliers(c.Request.Context(), activeOnly)
+	if err != nil {
+		h.logger.Error("Failed to get all suppliers", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, supplierErrorStatus(err), "Gagal mengambil data supplier: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data supplier berhasil diambil", response)
+}
+
+// GetSupplierByID menangani request untuk mengambil detail supplier
+func (h *SupplierAdaptor) GetSupplierByID(c *gin.Context) {
+	h.logger.Debug("GetSupplierByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.supplierUsecase.GetSupplierByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get supplier", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, supplierErrorStatus(err), "Gagal mengambil supplier: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Supplier berhasil diambil", response)
+}
+
+// CreateSupplier menangani request untuk membuat supplier baru
+func (h *SupplierAdaptor) CreateSupplier(c *gin.Context) {
+	h.logger.Debug("CreateSupplier handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create supplier", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.supplierUsecase.CreateSupplier(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create supplier", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, supplierErrorStatus(err), "Gagal membuat supplier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Supplier created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Supplier berhasil dibuat", response)
+}
+
+// UpdateSupplier menangani request untuk update supplier
+func (h *SupplierAdaptor) UpdateSupplier(c *gin.Context) {
+	h.logger.Debug("UpdateSupplier handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update supplier", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.supplierUsecase.UpdateSupplier(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update supplier", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, supplierErrorStatus(err), "Gagal memperbarui supplier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Supplier updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Supplier berhasil diperbarui", response)
+}
+
+// DeleteSupplier menangani request untuk menghapus supplier
+func (h *SupplierAdaptor) DeleteSupplier(c *gin.Context) {
+	h.logger.Debug("DeleteSupplier handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.supplierUsecase.DeleteSupplier(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete supplier", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, supplierErrorStatus(err), "Gagal menghapus supplier: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Supplier deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Supplier berhasil dihapus", nil)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *SupplierAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// supplierErrorStatus memetakan error domain supplier ke HTTP status code
+func supplierErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrSupplierNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,230 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TableAdaptor menangani semua request HTTP untuk meja
+type TableAdaptor struct {
+	tableUsecase usecase.TableUseCase
+	logger       *zap.Logger
+}
+
+// NewTableAdaptor membuat instance baru dari TableAdaptor
+func NewTableAdaptor(tableUsecase usecase.TableUseCase, logger *zap.Logger) *TableAdaptor {
+	return &TableAdaptor{
+		tableUsecase: tableUsecase,
+		logger:       logger,
+	}
+}
+
+// GetAllTables menangani request untuk mengambil semua meja (query param opsional: status)
+func (h *TableAdaptor) GetAllTables(c *gin.Context) {
+	h.logger.Debug("GetAllTables handler called")
+
+	response, err := h.tableUsecase.GetAllTables(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		h.logger.Error("Failed to get all tables",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal mengambil data meja: "+err.Error())
+		return
+	}
+
+	h.logger.Info("GetAllTables completed successfully",
+		zap.Int("total_tables", len(response)),
+	)
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data meja berhasil diambil", response)
+}
+
+// GetTableByID menangani request untuk mengambil meja berdasarkan ID
+func (h *TableAdaptor) GetTableByID(c *gin.Context) {
+	h.logger.Debug("GetTableByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.tableUsecase.GetTableByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get table by ID",
+			zap.Error(err),
+			zap.Uint("id", id),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal mengambil data meja: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data meja berhasil diambil", response)
+}
+
+// CreateTable menangani request untuk membuat meja baru
+func (h *TableAdaptor) CreateTable(c *gin.Context) {
+	h.logger.Debug("CreateTable handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.TableCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create table",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.tableUsecase.CreateTable(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create table",
+			zap.Error(err),
+			zap.String("number", req.Number),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal membuat meja: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Table created successfully", zap.Uint("id", response.ID))
+
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Meja berhasil dibuat", response)
+}
+
+// UpdateTable menangani request untuk update nomor dan kapasitas meja
+func (h *TableAdaptor) UpdateTable(c *gin.Context) {
+	h.logger.Debug("UpdateTable handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TableUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update table",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.tableUsecase.UpdateTable(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update table",
+			zap.Error(err),
+			zap.Uint("id", id),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal memperbarui meja: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Table updated successfully", zap.Uint("id", id))
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Meja berhasil diperbarui", response)
+}
+
+// DeleteTable menangani request untuk menghapus meja
+func (h *TableAdaptor) DeleteTable(c *gin.Context) {
+	h.logger.Debug("DeleteTable handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tableUsecase.DeleteTable(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete table",
+			zap.Error(err),
+			zap.Uint("id", id),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal menghapus meja: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Table deleted successfully", zap.Uint("id", id))
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Meja berhasil dihapus", nil)
+}
+
+// UpdateTableStatus menangani override status meja secara manual (PATCH /tables/:id/status)
+func (h *TableAdaptor) UpdateTableStatus(c *gin.Context) {
+	h.logger.Debug("UpdateTableStatus handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TableStatusUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update table status",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.tableUsecase.UpdateTableStatus(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update table status",
+			zap.Error(err),
+			zap.Uint("id", id),
+			zap.String("status", req.Status),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, tableErrorStatus(err), "Gagal mengubah status meja: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Table status overridden",
+		zap.Uint("id", id),
+		zap.String("status", req.Status),
+	)
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Status meja berhasil diubah", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *TableAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// tableErrorStatus memetakan error domain meja ke HTTP status code
+func tableErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrTableNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrTableNumberExists),
+		errors.Is(err, usecase.ErrTableHasOpenOrders):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,205 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TaxAdaptor menangani request HTTP untuk outlet dan aturan pajak
+type TaxAdaptor struct {
+	taxUsecase usecase.TaxUseCase
+	logger     *zap.Logger
+}
+
+// NewTaxAdaptor membuat instance baru dari TaxAdaptor
+func NewTaxAdaptor(taxUsecase usecase.TaxUseCase, logger *zap.Logger) *TaxAdaptor {
+	return &TaxAdaptor{
+		taxUsecase: taxUsecase,
+		logger:     logger,
+	}
+}
+
+// GetAllOutlets menangani request untuk mengambil semua outlet
+func (h *TaxAdaptor) GetAllOutlets(c *gin.Context) {
+	h.logger.Debug("GetAllOutlets handler called")
+
+	response, err := h.taxUsecase.GetAllOutlets(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get all outlets", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal mengambil data outlet: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Data outlet berhasil diambil", response)
+}
+
+// CreateOutlet menangani request untuk membuat outlet baru
+func (h *TaxAdaptor) CreateOutlet(c *gin.Context) {
+	h.logger.Debug("CreateOutlet handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.OutletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create outlet", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.taxUsecase.CreateOutlet(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create outlet", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal membuat outlet: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Outlet created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Outlet berhasil dibuat", response)
+}
+
+// UpdateOutlet menangani request untuk update outlet (termasuk mode harga inclusive/exclusive)
+func (h *TaxAdaptor) UpdateOutlet(c *gin.Context) {
+	h.logger.Debug("UpdateOutlet handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.OutletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update outlet", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.taxUsecase.UpdateOutlet(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update outlet", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal memperbarui outlet: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Outlet updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Outlet berhasil diperbarui", response)
+}
+
+// GetTaxRules menangani request untuk mengambil aturan pajak (query param opsional: outlet_id, category_id)
+func (h *TaxAdaptor) GetTaxRules(c *gin.Context) {
+	h.logger.Debug("GetTaxRules handler called")
+
+	outletID, _ := strconv.ParseUint(c.Query("outlet_id"), 10, 32)
+	categoryID, _ := strconv.ParseUint(c.Query("category_id"), 10, 32)
+
+	response, err := h.taxUsecase.GetTaxRules(c.Request.Context(), uint(outletID), uint(categoryID))
+	if err != nil {
+		h.logger.Error("Failed to get tax rules", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal mengambil aturan pajak: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Aturan pajak berhasil diambil", response)
+}
+
+// CreateTaxRule menangani request untuk membuat aturan pajak baru
+func (h *TaxAdaptor) CreateTaxRule(c *gin.Context) {
+	h.logger.Debug("CreateTaxRule handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.TaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create tax rule", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.taxUsecase.CreateTaxRule(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create tax rule", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal membuat aturan pajak: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Tax rule created successfully", zap.Uint("id", response.ID))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Aturan pajak berhasil dibuat", response)
+}
+
+// UpdateTaxRule menangani request untuk update aturan pajak
+func (h *TaxAdaptor) UpdateTaxRule(c *gin.Context) {
+	h.logger.Debug("UpdateTaxRule handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.TaxRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for update tax rule", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.taxUsecase.UpdateTaxRule(c.Request.Context(), id, req)
+	if err != nil {
+		h.logger.Error("Failed to update tax rule", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal memperbarui aturan pajak: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Tax rule updated successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Aturan pajak berhasil diperbarui", response)
+}
+
+// DeleteTaxRule menangani request untuk menghapus aturan pajak
+func (h *TaxAdaptor) DeleteTaxRule(c *gin.Context) {
+	h.logger.Debug("DeleteTaxRule handler called", zap.String("client_ip", c.ClientIP()))
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.taxUsecase.DeleteTaxRule(c.Request.Context(), id); err != nil {
+		h.logger.Error("Failed to delete tax rule", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, taxErrorStatus(err), "Gagal menghapus aturan pajak: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Tax rule deleted successfully", zap.Uint("id", id))
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Aturan pajak berhasil dihapus", nil)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *TaxAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// taxErrorStatus memetakan error domain pajak ke HTTP status code
+func taxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrOutletNotFound),
+		errors.Is(err, usecase.ErrTaxRuleNotFound),
+		errors.Is(err, usecase.ErrTaxCategoryNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,148 @@
+package adaptor
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/internal/usecase"
+	"aplikasi-pos-team-boolean/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WasteAdaptor menangani request HTTP untuk pencatatan dan laporan waste
+type WasteAdaptor struct {
+	wasteUsecase usecase.WasteUseCase
+	logger       *zap.Logger
+}
+
+// NewWasteAdaptor membuat instance baru dari WasteAdaptor
+func NewWasteAdaptor(wasteUsecase usecase.WasteUseCase, logger *zap.Logger) *WasteAdaptor {
+	return &WasteAdaptor{
+		wasteUsecase: wasteUsecase,
+		logger:       logger,
+	}
+}
+
+// GetAllWaste menangani request daftar waste
+// (query param opsional: reason, inventory_id, product_id, start_date, end_date, page, limit)
+func (h *WasteAdaptor) GetAllWaste(c *gin.Context) {
+	h.logger.Debug("GetAllWaste handler called")
+
+	var filter dto.WasteFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for waste list", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.wasteUsecase.GetAllWaste(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to get waste logs", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, wasteErrorStatus(err), "Gagal mengambil data waste: "+err.Error())
+		return
+	}
+
+	utils.ResponsePagination(c.Writer, http.StatusOK, "Data waste berhasil diambil", response.Data, response.Pagination)
+}
+
+// GetWasteByID menangani request detail satu catatan waste
+func (h *WasteAdaptor) GetWasteByID(c *gin.Context) {
+	h.logger.Debug("GetWasteByID handler called")
+
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.wasteUsecase.GetWasteByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get waste log", zap.Error(err), zap.Uint("id", id), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, wasteErrorStatus(err), "Gagal mengambil waste: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Waste berhasil diambil", response)
+}
+
+// CreateWaste menangani request pencatatan waste inventory atau produk
+func (h *WasteAdaptor) CreateWaste(c *gin.Context) {
+	h.logger.Debug("CreateWaste handler called", zap.String("client_ip", c.ClientIP()))
+
+	var req dto.WasteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request body for create waste", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	response, err := h.wasteUsecase.CreateWaste(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to create waste", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, wasteErrorStatus(err), "Gagal mencatat waste: "+err.Error())
+		return
+	}
+
+	h.logger.Info("Waste recorded successfully",
+		zap.Uint("id", response.ID),
+		zap.String("reason", response.Reason),
+		zap.Float64("cost", response.Cost))
+	utils.ResponseSuccess(c.Writer, http.StatusCreated, "Waste berhasil dicatat", response)
+}
+
+// GetWasteReport menangani request laporan waste
+// (query param opsional: start_date, end_date, period=day|week|month, reason, method=fifo|weighted_average)
+func (h *WasteAdaptor) GetWasteReport(c *gin.Context) {
+	h.logger.Debug("GetWasteReport handler called", zap.String("client_ip", c.ClientIP()))
+
+	var filter dto.WasteReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		h.logger.Warn("Invalid query parameters for waste report", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
+	}
+
+	response, err := h.wasteUsecase.GetWasteReport(c.Request.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to get waste report", zap.Error(err), zap.String("client_ip", c.ClientIP()))
+		utils.ResponseError(c.Writer, wasteErrorStatus(err), "Gagal mengambil laporan waste: "+err.Error())
+		return
+	}
+
+	utils.ResponseSuccess(c.Writer, http.StatusOK, "Laporan waste berhasil diambil", response)
+}
+
+// parseID membaca parameter :id, menulis response 400 jika tidak valid
+func (h *WasteAdaptor) parseID(c *gin.Context) (uint, bool) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		h.logger.Warn("Invalid ID parameter",
+			zap.Error(err),
+			zap.String("id", idStr),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		utils.ResponseError(c.Writer, http.StatusBadRequest, "Invalid ID parameter")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// wasteErrorStatus memetakan error domain waste ke HTTP status code
+func wasteErrorStatus(err error) int {
+	var insufficient *repository.InsufficientStockError
+	switch {
+	case errors.Is(err, usecase.ErrWasteNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidWaste):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, repository.ErrInsufficientWasteStock), errors.As(err, &insufficient):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
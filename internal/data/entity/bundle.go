@@ -0,0 +1,75 @@
+package entity
+
+import "time"
+
+// BundleSlot merepresentasikan tabel bundle_slots di database (contoh: Burger, Fries, Drink pada paket combo).
+// Slot dengan satu opsi adalah komponen tetap; slot dengan beberapa opsi berarti pelanggan memilih salah satu.
+// Quantity adalah jumlah komponen per satu bundle.
+type BundleSlot struct {
+	ID        uint               `gorm:"primaryKey;autoIncrement" json:"id"`
+	BundleID  uint               `gorm:"not null;index" json:"bundle_id"` // Product ID bundle
+	Name      string             `gorm:"type:varchar(50);not null" json:"name"`
+	Quantity  int                `gorm:"not null;default:1" json:"quantity"`
+	CreatedAt time.Time          `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	Options   []BundleSlotOption `gorm:"foreignKey:SlotID;references:ID" json:"options"`
+}
+
+// TableName override nama tabel
+func (BundleSlot) TableName() string {
+	return "bundle_slots"
+}
+
+// BundleSlotOption merepresentasikan tabel bundle_slot_options di database.
+// VariantID wajib diisi jika produk komponen punya varian.
+type BundleSlotOption struct {
+	ID        uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	SlotID    uint            `gorm:"not null;index" json:"slot_id"`
+	ProductID uint            `gorm:"not null;index" json:"product_id"`
+	VariantID *uint           `json:"variant_id,omitempty"`
+	Product   Product         `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID" json:"variant,omitempty"`
+}
+
+// TableName override nama tabel
+func (BundleSlotOption) TableName() string {
+	return "bundle_slot_options"
+}
+
+// AvailableStock mengembalikan stok komponen (stok varian jika opsi memakai varian)
+func (o BundleSlotOption) AvailableStock() int {
+	if o.Variant != nil {
+		return o.Variant.Stock
+	}
+	return o.Product.Stock
+}
+
+// DisplayName mengembalikan nama komponen, contoh "Cola - Large"
+func (o BundleSlotOption) DisplayName() string {
+	if o.Variant != nil && o.Variant.Name != "" {
+		return o.Product.ProductName + " - " + o.Variant.Name
+	}
+	return o.Product.ProductName
+}
+
+// OrderItemComponent merepresentasikan tabel order_item_components di database: komponen bundle
+// yang dipesan pada item order. Quantity adalah jumlah per satu bundle. AllocationRatio adalah porsi
+// pendapatan item (subtotal dan diskon) yang dialokasikan ke komponen, dihitung dari harga katalog
+// komponen saat order dibuat; total ratio satu item = 1.
+type OrderItemComponent struct {
+	ID                 uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderItemID        uint      `gorm:"not null;index" json:"order_item_id"`
+	BundleSlotID       uint      `gorm:"not null" json:"bundle_slot_id"`
+	BundleSlotOptionID uint      `gorm:"not null" json:"bundle_slot_option_id"`
+	SlotName           string    `gorm:"type:varchar(50);not null" json:"slot_name"`
+	ProductID          uint      `gorm:"not null;index" json:"product_id"`
+	VariantID          *uint     `gorm:"index" json:"variant_id,omitempty"`
+	ProductName        string    `gorm:"type:varchar(200);not null" json:"product_name"`
+	Quantity           int       `gorm:"not null" json:"quantity"`
+	AllocationRatio    float64   `gorm:"type:decimal(10,6);not null;default:0" json:"allocation_ratio"`
+	CreatedAt          time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (OrderItemComponent) TableName() string {
+	return "order_item_components"
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// Metode valuasi inventory untuk COGS dan laporan nilai persediaan
+const (
+	ValuationFIFO            = "fifo"             // Barang yang pertama masuk dianggap pertama keluar
+	ValuationWeightedAverage = "weighted_average" // Harga pokok rata-rata tertimbang (perpetual)
+)
+
+// ValuationMethods berisi semua metode valuasi yang valid
+var ValuationMethods = []string{
+	ValuationFIFO,
+	ValuationWeightedAverage,
+}
+
+// IsValidValuationMethod mengecek apakah metode valuasi dikenal
+func IsValidValuationMethod(method string) bool {
+	for _, m := range ValuationMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// CostLayer merepresentasikan tabel cost_layers di database: satu lapisan harga pokok dari barang
+// masuk. RemainingQuantity berkurang saat stok keluar (urut FIFO) sehingga jumlah RemainingQuantity
+// x UnitCost adalah nilai persediaan metode FIFO.
+type CostLayer struct {
+	ID                uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	InventoryID       int64     `gorm:"not null;index:idx_cost_layers_inventory_remaining,priority:1" json:"inventory_id"`
+	StockMovementID   uint      `gorm:"not null;index" json:"stock_movement_id"`
+	UnitCost          float64   `gorm:"type:decimal(15,4);not null" json:"unit_cost"`
+	Quantity          float64   `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	RemainingQuantity float64   `gorm:"type:decimal(15,3);not null;index:idx_cost_layers_inventory_remaining,priority:2" json:"remaining_quantity"`
+	CreatedAt         time.Time `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (CostLayer) TableName() string {
+	return "cost_layers"
+}
+
+// SaleCost merepresentasikan tabel sale_costs di database: harga pokok penjualan (COGS) bahan
+// inventory yang terpakai oleh satu produk pada order yang dibayar. Nilai dicatat untuk kedua metode
+// valuasi agar laporan margin mengikuti metode yang dikonfigurasi.
+type SaleCost struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         uint      `gorm:"not null;index" json:"order_id"`
+	ProductID       uint      `gorm:"not null;index" json:"product_id"`
+	InventoryID     int64     `gorm:"not null" json:"inventory_id"`
+	StockMovementID uint      `gorm:"not null;index" json:"stock_movement_id"`
+	Quantity        float64   `gorm:"type:decimal(15,3);not null" json:"quantity"`     // Dalam satuan inventory
+	FifoCost        float64   `gorm:"type:decimal(15,2);not null" json:"fifo_cost"`    // COGS metode FIFO
+	AverageCost     float64   `gorm:"type:decimal(15,2);not null" json:"average_cost"` // COGS metode weighted average
+	CreatedAt       time.Time `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (SaleCost) TableName() string {
+	return "sale_costs"
+}
@@ -8,18 +8,22 @@ import (
 
 // Inventories merepresentasikan tabel inventories di database
 type Inventories struct {
-	ID          int64          `gorm:"primaryKey;autoIncrement" json:"id"`
-	Image       string         `gorm:"type:varchar(500)" json:"image"`
-	Name        string         `gorm:"type:varchar(255);not null" json:"name"`
-	Category    string         `gorm:"type:varchar(100);default:'uncategorized'" json:"category"`
-	Quantity    int            `gorm:"type:integer;default:0;not null" json:"quantity"`
-	Status      string         `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
-	RetailPrice float64        `gorm:"type:decimal(10,2);not null;default:0" json:"retail_price"`
-	Unit        string         `gorm:"type:varchar(50);not null" json:"unit"`
-	MinStock    int            `gorm:"type:integer;default:5;not null" json:"min_stock"`
-	CreatedAt   time.Time      `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"type:timestamp with time zone;index" json:"deleted_at"`
+	ID                int64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Image             string         `gorm:"type:varchar(500)" json:"image"`
+	Name              string         `gorm:"type:varchar(255);not null" json:"name"`
+	Category          string         `gorm:"type:varchar(100);default:'uncategorized'" json:"category"`
+	Quantity          float64        `gorm:"type:decimal(15,3);default:0;not null" json:"quantity"` // Dalam satuan Unit; bisa pecahan karena dipakai resep
+	Status            string         `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	RetailPrice       float64        `gorm:"type:decimal(10,2);not null;default:0" json:"retail_price"`
+	Unit              string         `gorm:"type:varchar(50);not null" json:"unit"`
+	MinStock          int            `gorm:"type:integer;default:5;not null" json:"min_stock"`
+	AverageCost       float64        `gorm:"type:decimal(15,4);not null;default:0" json:"average_cost"`           // Harga pokok rata-rata tertimbang per Unit
+	LowStockAlertedAt *time.Time     `gorm:"type:timestamp with time zone" json:"low_stock_alerted_at,omitempty"` // Diisi saat notifikasi stok menipis dikirim, dikosongkan saat restock
+	CreatedAt         time.Time      `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"type:timestamp with time zone;index" json:"deleted_at"`
+
+	PackSizes []InventoryPackSize `gorm:"foreignKey:InventoryID" json:"pack_sizes,omitempty"` // Kemasan pembelian (contoh: crate)
 }
 
 // TableName override nama tabel
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// InventoryBatch merepresentasikan tabel inventory_batches di database: satu batch/lot stok dari
+// barang masuk. RemainingQuantity berkurang saat stok keluar dengan urutan FEFO (kedaluwarsa paling
+// awal keluar lebih dulu); batch tanpa ExpiryDate dipakai paling akhir.
+type InventoryBatch struct {
+	ID                uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	InventoryID       int64       `gorm:"not null;index:idx_inventory_batches_inventory_expiry,priority:1" json:"inventory_id"`
+	StockMovementID   *uint       `gorm:"index" json:"stock_movement_id,omitempty"` // Nil untuk stok lama sebelum batch dicatat
+	BatchNumber       string      `gorm:"type:varchar(50)" json:"batch_number"`
+	ExpiryDate        *time.Time  `gorm:"type:date;index:idx_inventory_batches_inventory_expiry,priority:2" json:"expiry_date,omitempty"`
+	Quantity          float64     `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	RemainingQuantity float64     `gorm:"type:decimal(15,3);not null" json:"remaining_quantity"`
+	ExpiryAlertedAt   *time.Time  `gorm:"type:timestamp with time zone" json:"expiry_alerted_at,omitempty"` // Kapan notifikasi kedaluwarsa dikirim
+	CreatedAt         time.Time   `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	Inventory         Inventories `gorm:"foreignKey:InventoryID" json:"inventory,omitempty"`
+}
+
+// TableName override nama tabel
+func (InventoryBatch) TableName() string {
+	return "inventory_batches"
+}
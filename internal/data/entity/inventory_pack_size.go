@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"aplikasi-pos-team-boolean/pkg/unit"
+)
+
+// InventoryPackSize merepresentasikan tabel inventory_pack_sizes di database: kemasan pembelian
+// khusus satu inventory (contoh: 1 crate = 24 litre). Quantity adalah isi satu kemasan dalam
+// satuan Unit inventory, sehingga Name bisa dipakai seperti satuan biasa saat input quantity.
+type InventoryPackSize struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	InventoryID int64     `gorm:"not null;uniqueIndex:idx_inventory_pack_sizes_inventory_name,priority:1" json:"inventory_id"`
+	Name        string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_inventory_pack_sizes_inventory_name,priority:2" json:"name"`
+	Quantity    float64   `gorm:"type:decimal(15,4);not null" json:"quantity"`
+	CreatedAt   time.Time `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName override nama tabel
+func (InventoryPackSize) TableName() string {
+	return "inventory_pack_sizes"
+}
+
+// ToStockUnit mengkonversi quantity dari satuan from ke satuan Unit inventory. from boleh berupa
+// satuan katalog yang sefamily (contoh: ml untuk inventory litre) atau nama kemasan di PackSizes
+// (harus sudah di-preload). from kosong dianggap sudah dalam satuan inventory.
+func (inv Inventories) ToStockUnit(quantity float64, from string) (float64, error) {
+	from = unit.Normalize(from)
+	if from == "" {
+		return quantity, nil
+	}
+	for _, pack := range inv.PackSizes {
+		if unit.Normalize(pack.Name) == from {
+			return quantity * pack.Quantity, nil
+		}
+	}
+	return unit.Convert(quantity, from, inv.Unit)
+}
+
+// AcceptsUnit mengembalikan true jika quantity dalam satuan from bisa dikonversi ke satuan inventory
+func (inv Inventories) AcceptsUnit(from string) bool {
+	_, err := inv.ToStockUnit(1, from)
+	return err == nil
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status tiket dan item dapur. Item di-bump queued -> cooking -> ready,
+// cancelled dipakai jika item dikurangi/dibatalkan sebelum selesai dimasak.
+const (
+	KitchenStatusQueued    = "queued"
+	KitchenStatusCooking   = "cooking"
+	KitchenStatusReady     = "ready"
+	KitchenStatusCancelled = "cancelled"
+)
+
+// KitchenStation merepresentasikan tabel kitchen_stations di database (contoh: grill, bar, bakery).
+// Item order diarahkan ke station berdasarkan kategori produk; kategori yang tidak dipetakan
+// diarahkan ke station default.
+type KitchenStation struct {
+	ID         uint                     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string                   `gorm:"type:varchar(50);not null" json:"name"`
+	IsDefault  bool                     `gorm:"type:boolean;not null;default:false" json:"is_default"`
+	CreatedAt  time.Time                `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time                `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt           `gorm:"index" json:"deleted_at,omitempty"`
+	Categories []KitchenStationCategory `gorm:"foreignKey:StationID;references:ID" json:"categories"`
+}
+
+// TableName override nama tabel
+func (KitchenStation) TableName() string {
+	return "kitchen_stations"
+}
+
+// BeforeUpdate hook untuk update timestamp
+func (s *KitchenStation) BeforeUpdate(tx *gorm.DB) error {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// KitchenStationCategory merepresentasikan tabel kitchen_station_categories di database.
+// Satu kategori hanya diarahkan ke satu station.
+type KitchenStationCategory struct {
+	ID         uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	StationID  uint `gorm:"not null;index" json:"station_id"`
+	CategoryID uint `gorm:"not null;uniqueIndex" json:"category_id"`
+}
+
+// TableName override nama tabel
+func (KitchenStationCategory) TableName() string {
+	return "kitchen_station_categories"
+}
+
+// KitchenTicket merepresentasikan tabel kitchen_tickets di database.
+// Satu tiket berisi item satu order untuk satu station; statusnya diturunkan dari status item.
+type KitchenTicket struct {
+	ID        uint                `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID   uint                `gorm:"not null;index" json:"order_id"`
+	StationID *uint               `gorm:"index" json:"station_id,omitempty"` // Kosong jika tidak ada station yang cocok maupun station default
+	Status    string              `gorm:"type:varchar(20);not null;index" json:"status"`
+	ReadyAt   *time.Time          `gorm:"type:timestamp" json:"ready_at,omitempty"`
+	CreatedAt time.Time           `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time           `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Order     Order               `gorm:"foreignKey:OrderID" json:"order,omitempty"`
+	Station   *KitchenStation     `gorm:"foreignKey:StationID" json:"station,omitempty"`
+	Items     []KitchenTicketItem `gorm:"foreignKey:TicketID;references:ID" json:"items"`
+}
+
+// TableName override nama tabel
+func (KitchenTicket) TableName() string {
+	return "kitchen_tickets"
+}
+
+// KitchenTicketItem merepresentasikan tabel kitchen_ticket_items di database
+type KitchenTicketItem struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TicketID    uint       `gorm:"not null;index" json:"ticket_id"`
+	ProductID   uint       `gorm:"not null" json:"product_id"`
+	VariantID   uint       `gorm:"not null;default:0" json:"variant_id"`           // 0 jika produk tanpa varian
+	ProductName string     `gorm:"type:varchar(100);not null" json:"product_name"` // Nama produk (dan varian) saat tiket dibuat
+	Note        string     `gorm:"type:text;not null;default:''" json:"note"`      // Komponen bundle, modifier, dan catatan item order
+	Quantity    int        `gorm:"not null" json:"quantity"`
+	Status      string     `gorm:"type:varchar(20);not null" json:"status"`
+	StartedAt   *time.Time `gorm:"type:timestamp" json:"started_at,omitempty"`
+	ReadyAt     *time.Time `gorm:"type:timestamp" json:"ready_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName override nama tabel
+func (KitchenTicketItem) TableName() string {
+	return "kitchen_ticket_items"
+}
+
+// NextKitchenStatus mengembalikan status berikutnya saat item di-bump, kosong jika item sudah selesai
+func NextKitchenStatus(status string) string {
+	switch status {
+	case KitchenStatusQueued:
+		return KitchenStatusCooking
+	case KitchenStatusCooking:
+		return KitchenStatusReady
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ModifierGroup merepresentasikan tabel modifier_groups di database (contoh: ukuran, topping, tanpa bahan).
+// Group bisa dipasang ke produk maupun kategori; produk mendapat gabungan group miliknya dan group kategorinya.
+// MinSelect > 0 berarti wajib dipilih, MaxSelect 0 berarti tanpa batas.
+type ModifierGroup struct {
+	ID         uint                    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string                  `gorm:"type:varchar(50);not null" json:"name"`
+	MinSelect  int                     `gorm:"not null;default:0" json:"min_select"`
+	MaxSelect  int                     `gorm:"not null;default:0" json:"max_select"`
+	CreatedAt  time.Time               `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time               `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt          `gorm:"index" json:"deleted_at,omitempty"`
+	Options    []ModifierOption        `gorm:"foreignKey:GroupID;references:ID" json:"options"`
+	Products   []ProductModifierGroup  `gorm:"foreignKey:GroupID;references:ID" json:"products"`
+	Categories []CategoryModifierGroup `gorm:"foreignKey:GroupID;references:ID" json:"categories"`
+}
+
+// TableName override nama tabel
+func (ModifierGroup) TableName() string {
+	return "modifier_groups"
+}
+
+// BeforeUpdate hook untuk update timestamp
+func (g *ModifierGroup) BeforeUpdate(tx *gorm.DB) error {
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+// ModifierOption merepresentasikan tabel modifier_options di database.
+// PriceDelta ditambahkan ke harga satuan item (boleh 0 untuk pilihan seperti "tanpa bawang").
+type ModifierOption struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	GroupID     uint           `gorm:"not null;index" json:"group_id"`
+	Name        string         `gorm:"type:varchar(50);not null" json:"name"`
+	PriceDelta  float64        `gorm:"type:decimal(15,2);not null;default:0" json:"price_delta"`
+	IsAvailable bool           `gorm:"type:boolean;not null" json:"is_available"`
+	CreatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (ModifierOption) TableName() string {
+	return "modifier_options"
+}
+
+// ProductModifierGroup merepresentasikan tabel product_modifier_groups di database
+type ProductModifierGroup struct {
+	ID        uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint `gorm:"not null;uniqueIndex:idx_product_modifier_group" json:"product_id"`
+	GroupID   uint `gorm:"not null;uniqueIndex:idx_product_modifier_group;index" json:"group_id"`
+}
+
+// TableName override nama tabel
+func (ProductModifierGroup) TableName() string {
+	return "product_modifier_groups"
+}
+
+// CategoryModifierGroup merepresentasikan tabel category_modifier_groups di database
+type CategoryModifierGroup struct {
+	ID         uint `gorm:"primaryKey;autoIncrement" json:"id"`
+	CategoryID uint `gorm:"not null;uniqueIndex:idx_category_modifier_group" json:"category_id"`
+	GroupID    uint `gorm:"not null;uniqueIndex:idx_category_modifier_group;index" json:"group_id"`
+}
+
+// TableName override nama tabel
+func (CategoryModifierGroup) TableName() string {
+	return "category_modifier_groups"
+}
+
+// OrderItemModifier merepresentasikan tabel order_item_modifiers di database.
+// Nama dan harga modifier disalin saat order dibuat agar riwayat tidak berubah jika katalog diubah.
+type OrderItemModifier struct {
+	ID               uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderItemID      uint      `gorm:"not null;index" json:"order_item_id"`
+	ModifierGroupID  uint      `gorm:"not null" json:"modifier_group_id"`
+	ModifierOptionID uint      `gorm:"not null;index" json:"modifier_option_id"`
+	GroupName        string    `gorm:"type:varchar(50);not null" json:"group_name"`
+	OptionName       string    `gorm:"type:varchar(50);not null" json:"option_name"`
+	PriceDelta       float64   `gorm:"type:decimal(15,2);not null;default:0" json:"price_delta"`
+	CreatedAt        time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (OrderItemModifier) TableName() string {
+	return "order_item_modifiers"
+}
@@ -6,35 +6,189 @@ import (
 	"gorm.io/gorm"
 )
 
+// Status order. Alur normal: pending -> in_kitchen -> served -> paid,
+// ditambah cancelled (dibatalkan sebelum dibayar) dan refunded (dikembalikan setelah dibayar).
+// split dan merged adalah status akhir order yang dibagi rata atau digabung ke order lain.
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusInKitchen = "in_kitchen"
+	OrderStatusServed    = "served"
+	OrderStatusPaid      = "paid"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+	OrderStatusSplit     = "split"
+	OrderStatusMerged    = "merged"
+)
+
+// Jenis relasi antar order hasil split/merge
+const (
+	OrderLinkSplitItems = "split_items" // Sebagian item dipindah ke order baru
+	OrderLinkSplitEven  = "split_even"  // Order dibagi rata menjadi beberapa bagian
+	OrderLinkMerge      = "merge"       // Order digabung ke order lain
+)
+
+// OpenOrderStatuses adalah status order yang masih berjalan (belum dibayar/dibatalkan)
+var OpenOrderStatuses = []string{OrderStatusPending, OrderStatusInKitchen, OrderStatusServed}
+
+// Tipe order. Hanya order dine_in yang menempati meja
+const (
+	OrderTypeDineIn   = "dine_in"
+	OrderTypeTakeAway = "take_away"
+)
+
 // Order merepresentasikan tabel orders di database
 type Order struct {
-	ID              uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID          uint           `gorm:"not null" json:"user_id"`
-	TableID         uint           `gorm:"not null" json:"table_id"`
-	PaymentMethodID uint           `gorm:"not null" json:"payment_method_id"`
-	CustomerName    string         `gorm:"type:varchar(100);not null" json:"customer_name"`
-	TotalAmount     float64        `gorm:"type:decimal(15,2);not null" json:"total_amount"`
-	Tax             float64        `gorm:"type:decimal(15,2);not null" json:"tax"`
-	Status          string         `gorm:"type:varchar(20);not null" json:"status"`
-	CreatedAt       time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt       time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
-	Table           Table          `gorm:"foreignKey:TableID" json:"table,omitempty"`
-	PaymentMethod   PaymentMethod  `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
-	Items           []OrderItem    `gorm:"foreignKey:OrderID;references:ID" json:"items"`
+	ID               uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           uint            `gorm:"not null" json:"user_id"`
+	TableID          uint            `gorm:"not null" json:"table_id"`
+	OrderType        string          `gorm:"type:varchar(20);not null;default:'dine_in'" json:"order_type"`
+	OutletID         uint            `gorm:"not null;default:1;index" json:"outlet_id"`
+	PaymentMethodID  uint            `gorm:"not null" json:"payment_method_id"`
+	CustomerName     string          `gorm:"type:varchar(100);not null" json:"customer_name"`
+	Subtotal         float64         `gorm:"type:decimal(15,2);not null;default:0" json:"subtotal"`        // Jumlah subtotal item
+	DiscountAmount   float64         `gorm:"type:decimal(15,2);not null;default:0" json:"discount_amount"` // Total diskon promo
+	PromoCode        string          `gorm:"type:varchar(50)" json:"promo_code"`
+	ServiceCharge    float64         `gorm:"type:decimal(15,2);not null;default:0" json:"service_charge"` // Total service charge
+	Tax              float64         `gorm:"type:decimal(15,2);not null" json:"tax"`                      // Total pajak
+	PricesIncludeTax bool            `gorm:"type:boolean;not null;default:false" json:"prices_include_tax"`
+	TotalAmount      float64         `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	PaidAmount       float64         `gorm:"type:decimal(15,2);not null;default:0" json:"paid_amount"`     // Total pembayaran yang sudah diterima
+	RefundedAmount   float64         `gorm:"type:decimal(15,2);not null;default:0" json:"refunded_amount"` // Total yang sudah di-refund
+	ShareIndex       int             `gorm:"not null;default:0" json:"share_index"`                        // Bagian ke-n dari order yang dibagi rata
+	ShareCount       int             `gorm:"not null;default:0" json:"share_count"`                        // Jumlah bagian, 0 jika bukan hasil split rata
+	Status           string          `gorm:"type:varchar(20);not null" json:"status"`
+	CreatedAt        time.Time       `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt        time.Time       `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+	Table            Table           `gorm:"foreignKey:TableID" json:"table,omitempty"`
+	PaymentMethod    PaymentMethod   `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
+	Items            []OrderItem     `gorm:"foreignKey:OrderID;references:ID" json:"items"`
+	Taxes            []OrderTax      `gorm:"foreignKey:OrderID;references:ID" json:"taxes"`
+	Discounts        []OrderDiscount `gorm:"foreignKey:OrderID;references:ID" json:"discounts"`
+	Payments         []OrderPayment  `gorm:"foreignKey:OrderID;references:ID" json:"payments"`
+	Refunds          []OrderRefund   `gorm:"foreignKey:OrderID;references:ID" json:"refunds"`
 }
 
 // OrderItem merepresentasikan tabel order_items di database
 type OrderItem struct {
-	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	OrderID   uint           `gorm:"not null" json:"order_id"`
-	ProductID uint           `gorm:"not null" json:"product_id"`
-	Quantity  int            `gorm:"not null" json:"quantity"`
-	Price     float64        `gorm:"type:decimal(15,2);not null" json:"price"`
-	Subtotal  float64        `gorm:"type:decimal(15,2);not null" json:"subtotal"`
-	CreatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID              uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         uint                 `gorm:"not null" json:"order_id"`
+	ProductID       uint                 `gorm:"not null" json:"product_id"`
+	Quantity        int                  `gorm:"not null" json:"quantity"`
+	Price           float64              `gorm:"type:decimal(15,2);not null" json:"price"`
+	ListPrice       float64              `gorm:"type:decimal(15,2);not null;default:0" json:"list_price"`      // Harga katalog saat order dibuat
+	IsPriceOverride bool                 `gorm:"type:boolean;not null;default:false" json:"is_price_override"` // True jika harga di-override manager
+	PriceApprovedBy *uint                `gorm:"index" json:"price_approved_by,omitempty"`                     // User ID manager yang menyetujui override
+	Subtotal        float64              `gorm:"type:decimal(15,2);not null" json:"subtotal"`
+	DiscountAmount  float64              `gorm:"type:decimal(15,2);not null;default:0" json:"discount_amount"` // Diskon promo yang dialokasikan ke item ini
+	PromotionID     *uint                `gorm:"index" json:"promotion_id,omitempty"`                          // Promo produk/kategori yang diterapkan ke item
+	VariantID       *uint                `gorm:"index" json:"variant_id,omitempty"`                            // Varian produk yang dipesan
+	VariantName     string               `gorm:"type:varchar(100)" json:"variant_name"`                        // Nama varian saat order dibuat
+	ModifierAmount  float64              `gorm:"type:decimal(15,2);not null;default:0" json:"modifier_amount"` // Total price delta modifier per satuan, sudah termasuk di Price
+	Notes           string               `gorm:"type:varchar(255)" json:"notes"`                               // Catatan bebas untuk dapur
+	CreatedAt       time.Time            `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time            `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt       `gorm:"index" json:"deleted_at,omitempty"`
+	Modifiers       []OrderItemModifier  `gorm:"foreignKey:OrderItemID;references:ID" json:"modifiers"`
+	Components      []OrderItemComponent `gorm:"foreignKey:OrderItemID;references:ID" json:"components,omitempty"` // Komponen jika produk adalah bundle
+}
+
+// OrderStatusHistory merepresentasikan tabel order_status_histories di database
+// Setiap perubahan status order dicatat beserta waktunya
+type OrderStatusHistory struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID    uint      `gorm:"not null;index" json:"order_id"`
+	FromStatus string    `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedBy  *uint     `json:"changed_by,omitempty"`
+	Note       string    `gorm:"type:text" json:"note"`
+	CreatedAt  time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// OrderTax merepresentasikan tabel order_taxes di database
+// Rincian pajak dan service charge per aturan pajak untuk satu order
+type OrderTax struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID     uint      `gorm:"not null;index" json:"order_id"`
+	TaxRuleID   *uint     `gorm:"index" json:"tax_rule_id,omitempty"`
+	Name        string    `gorm:"type:varchar(50);not null" json:"name"`
+	Type        string    `gorm:"type:varchar(20);not null" json:"type"`
+	Rate        float64   `gorm:"type:decimal(5,2);not null" json:"rate"`
+	BaseAmount  float64   `gorm:"type:decimal(15,2);not null" json:"base_amount"`
+	Amount      float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	IsInclusive bool      `gorm:"type:boolean;not null;default:false" json:"is_inclusive"`
+	CreatedAt   time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// OrderDiscount merepresentasikan tabel order_discounts di database
+// Setiap promo yang diterapkan ke order dicatat beserta total diskonnya
+type OrderDiscount struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID     uint      `gorm:"not null;index" json:"order_id"`
+	PromotionID uint      `gorm:"not null;index" json:"promotion_id"`
+	Code        string    `gorm:"type:varchar(50)" json:"code"`
+	Name        string    `gorm:"type:varchar(100);not null" json:"name"`
+	Type        string    `gorm:"type:varchar(20);not null" json:"type"`
+	Scope       string    `gorm:"type:varchar(20);not null" json:"scope"`
+	Amount      float64   `gorm:"type:decimal(15,2);not null" json:"amount"`
+	CreatedAt   time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// OrderPayment merepresentasikan tabel order_payments di database
+// Satu order bisa dibayar dengan beberapa tender (split tender), contoh sebagian tunai sebagian QRIS.
+// Amount adalah nilai yang dipakai untuk melunasi order, Tendered adalah uang yang diterima,
+// dan Change adalah kembalian (hanya untuk tender tunai).
+type OrderPayment struct {
+	ID              uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         uint          `gorm:"not null;index" json:"order_id"`
+	PaymentMethodID uint          `gorm:"not null;index" json:"payment_method_id"`
+	Amount          float64       `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Tendered        float64       `gorm:"type:decimal(15,2);not null" json:"tendered"`
+	Change          float64       `gorm:"type:decimal(15,2);not null;default:0" json:"change"`
+	Reference       string        `gorm:"type:varchar(100)" json:"reference"` // Nomor referensi EDC/QRIS
+	ReceivedBy      *uint         `gorm:"index" json:"received_by,omitempty"` // User ID kasir yang menerima pembayaran
+	CreatedAt       time.Time     `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	PaymentMethod   PaymentMethod `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
+}
+
+// OrderLink merepresentasikan tabel order_links di database
+// Jejak audit split/merge: menghubungkan order asal dengan order hasilnya
+type OrderLink struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	SourceOrderID uint      `gorm:"not null;index" json:"source_order_id"`
+	ResultOrderID uint      `gorm:"not null;index" json:"result_order_id"`
+	LinkType      string    `gorm:"type:varchar(20);not null" json:"link_type"`
+	CreatedBy     *uint     `gorm:"index" json:"created_by,omitempty"`
+	CreatedAt     time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel untuk OrderLink
+func (OrderLink) TableName() string {
+	return "order_links"
+}
+
+// TableName override nama tabel untuk OrderPayment
+func (OrderPayment) TableName() string {
+	return "order_payments"
+}
+
+// RemainingAmount mengembalikan sisa tagihan order yang belum dibayar
+func (o *Order) RemainingAmount() float64 {
+	remaining := o.TotalAmount - o.PaidAmount
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// TableName override nama tabel untuk OrderDiscount
+func (OrderDiscount) TableName() string {
+	return "order_discounts"
+}
+
+// TableName override nama tabel untuk OrderTax
+func (OrderTax) TableName() string {
+	return "order_taxes"
 }
 
 // TableName override nama tabel untuk Order
@@ -42,6 +196,21 @@ func (Order) TableName() string {
 	return "orders"
 }
 
+// TableName override nama tabel untuk OrderStatusHistory
+func (OrderStatusHistory) TableName() string {
+	return "order_status_histories"
+}
+
+// IsEditable mengembalikan true jika item order masih boleh diubah.
+// Bagian dari order yang dibagi rata tidak punya item sendiri sehingga tidak bisa diubah.
+func (o *Order) IsEditable() bool {
+	switch o.Status {
+	case OrderStatusPaid, OrderStatusCancelled, OrderStatusRefunded, OrderStatusSplit, OrderStatusMerged:
+		return false
+	}
+	return o.ShareCount == 0
+}
+
 // TableName override nama tabel untuk OrderItem
 func (OrderItem) TableName() string {
 	return "order_items"
@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// Jenis pengembalian: void untuk order yang belum dibayar, refund untuk order yang sudah dibayar
+const (
+	OrderRefundTypeVoid   = "void"
+	OrderRefundTypeRefund = "refund"
+)
+
+// Kode alasan void/refund
+const (
+	RefundReasonCustomerRequest = "customer_request"
+	RefundReasonWrongOrder      = "wrong_order"
+	RefundReasonQualityIssue    = "quality_issue"
+	RefundReasonDuplicate       = "duplicate"
+	RefundReasonPaymentIssue    = "payment_issue"
+	RefundReasonOther           = "other"
+)
+
+// OrderRefund merepresentasikan tabel order_refunds di database.
+// Setiap void dan refund dicatat beserta alasan dan manager yang menyetujui,
+// sehingga nominalnya tetap terlihat di laporan dan rekonsiliasi kas.
+type OrderRefund struct {
+	ID              uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         uint              `gorm:"not null;index" json:"order_id"`
+	Type            string            `gorm:"type:varchar(10);not null;index" json:"type"`
+	ReasonCode      string            `gorm:"type:varchar(30);not null" json:"reason_code"`
+	Note            string            `gorm:"type:text" json:"note"`
+	Amount          float64           `gorm:"type:decimal(15,2);not null" json:"amount"`
+	PaymentMethodID *uint             `gorm:"index" json:"payment_method_id,omitempty"` // Metode pengembalian uang (refund)
+	Restock         bool              `gorm:"type:boolean;not null;default:false" json:"restock"`
+	ApprovedBy      uint              `gorm:"not null;index" json:"approved_by"` // User ID manager yang menyetujui
+	ProcessedBy     *uint             `gorm:"index" json:"processed_by,omitempty"`
+	CreatedAt       time.Time         `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	Items           []OrderRefundItem `gorm:"foreignKey:RefundID;references:ID" json:"items"`
+}
+
+// OrderRefundItem merepresentasikan tabel order_refund_items di database
+// Rincian item yang di-refund sebagian
+type OrderRefundItem struct {
+	ID          uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+	RefundID    uint    `gorm:"not null;index" json:"refund_id"`
+	OrderItemID uint    `gorm:"not null;index" json:"order_item_id"`
+	ProductID   uint    `gorm:"not null" json:"product_id"`
+	Quantity    int     `gorm:"not null" json:"quantity"`
+	Amount      float64 `gorm:"type:decimal(15,2);not null" json:"amount"`
+}
+
+// TableName override nama tabel untuk OrderRefund
+func (OrderRefund) TableName() string {
+	return "order_refunds"
+}
+
+// TableName override nama tabel untuk OrderRefundItem
+func (OrderRefundItem) TableName() string {
+	return "order_refund_items"
+}
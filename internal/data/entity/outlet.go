@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultOutletID adalah outlet yang dipakai jika order tidak menyebutkan outlet
+const DefaultOutletID uint = 1
+
+// Outlet merepresentasikan tabel outlets di database
+type Outlet struct {
+	ID               uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name             string         `gorm:"type:varchar(100);not null" json:"name"`
+	Address          string         `gorm:"type:text" json:"address"`
+	Phone            string         `gorm:"type:varchar(20)" json:"phone"`
+	PricesIncludeTax bool           `gorm:"type:boolean;not null;default:false" json:"prices_include_tax"` // True jika harga katalog sudah termasuk pajak & service charge
+	ReceiptHeader    string         `gorm:"type:text" json:"receipt_header"`                               // Teks di bawah nama outlet pada receipt
+	ReceiptFooter    string         `gorm:"type:text" json:"receipt_footer"`                               // Teks penutup receipt
+	ReceiptWidth     int            `gorm:"not null;default:58" json:"receipt_width"`                      // Lebar kertas printer thermal (58 atau 80 mm)
+	CreatedAt        time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt        time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (Outlet) TableName() string {
+	return "outlets"
+}
+
+// BeforeUpdate hook untuk update timestamp
+func (o *Outlet) BeforeUpdate(tx *gorm.DB) error {
+	o.UpdatedAt = time.Now()
+	return nil
+}
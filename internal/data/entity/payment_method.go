@@ -10,6 +10,7 @@ import (
 type PaymentMethod struct {
 	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
 	Name      string         `gorm:"type:varchar(50);not null;unique" json:"name"`
+	IsCash    bool           `gorm:"type:boolean;not null;default:false" json:"is_cash"` // Tunai: boleh lebih bayar dan menghasilkan kembalian
 	CreatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -6,22 +6,34 @@ import (
 	"gorm.io/gorm"
 )
 
+// Tipe produk
+const (
+	ProductTypeSingle = "single" // Produk biasa dengan stok sendiri
+	ProductTypeBundle = "bundle" // Paket/combo yang komponennya produk lain; stok diambil dari komponen
+)
+
 // Product merepresentasikan tabel product di database
 type Product struct {
-	ID           uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	ProductImage string         `gorm:"type:varchar(255)" json:"product_image"`
-	ProductName  string         `gorm:"type:varchar(100);not null;index" json:"product_name"`
-	ItemID       string         `gorm:"type:varchar(50);unique;not null;index" json:"item_id"`
-	Stock        int            `gorm:"type:int;not null;default:0" json:"stock"`
-	CategoryID   uint           `gorm:"not null;index" json:"category_id"`
-	Price        float64        `gorm:"type:decimal(15,2);not null;default:0" json:"price"`
-	IsAvailable  bool           `gorm:"type:boolean;not null;default:false" json:"is_available"`
-	CreatedAt    time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID                uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductImage      string         `gorm:"type:varchar(255)" json:"product_image"`
+	ProductName       string         `gorm:"type:varchar(100);not null;index" json:"product_name"`
+	ItemID            string         `gorm:"type:varchar(50);unique;not null;index" json:"item_id"`
+	Type              string         `gorm:"type:varchar(20);not null;default:'single'" json:"type"`
+	Stock             int            `gorm:"type:int;not null;default:0" json:"stock"`
+	MinStock          int            `gorm:"type:int;not null;default:5" json:"min_stock"`                        // Batas notifikasi stok menipis
+	LowStockAlertedAt *time.Time     `gorm:"type:timestamp with time zone" json:"low_stock_alerted_at,omitempty"` // Diisi saat notifikasi stok menipis dikirim, dikosongkan saat restock
+	CategoryID        uint           `gorm:"not null;index" json:"category_id"`
+	Price             float64        `gorm:"type:decimal(15,2);not null;default:0" json:"price"`
+	IsAvailable       bool           `gorm:"type:boolean;not null;default:false" json:"is_available"`
+	CreatedAt         time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Relation
-	Category Category `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Category    Category         `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Variants    []ProductVariant `gorm:"foreignKey:ProductID;references:ID" json:"variants,omitempty"`
+	BundleSlots []BundleSlot     `gorm:"foreignKey:BundleID;references:ID" json:"bundle_slots,omitempty"`
+	Recipe      []RecipeItem     `gorm:"foreignKey:ProductID;references:ID" json:"recipe,omitempty"`
 }
 
 // TableName override nama tabel
@@ -42,14 +54,105 @@ func (p *Product) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
-// updateAvailability mengupdate is_available berdasarkan stock
+// updateAvailability mengupdate is_available berdasarkan stock.
+// Bundle tidak punya stok sendiri; ketersediaannya dicek dari stok komponen saat order dibuat.
 func (p *Product) updateAvailability() {
+	if p.IsBundle() {
+		p.IsAvailable = true
+		return
+	}
 	p.IsAvailable = p.Stock > 0
 }
 
-// GetAvailabilityStatus mengembalikan status availability sebagai string untuk response
+// IsBundle mengembalikan true jika produk adalah bundle/combo
+func (p *Product) IsBundle() bool {
+	return p.Type == ProductTypeBundle
+}
+
+// ApplyBundleStock menghitung stok bundle dari komponennya (BundleSlots.Options beserta Product/Variant
+// harus sudah di-preload): jumlah bundle yang masih bisa dibuat dengan opsi terbanyak di setiap slot.
+// Hanya mengubah nilai di memori untuk response, tidak disimpan.
+func (p *Product) ApplyBundleStock() {
+	if !p.IsBundle() {
+		return
+	}
+	stock := -1
+	for _, slot := range p.BundleSlots {
+		quantity := slot.Quantity
+		if quantity < 1 {
+			quantity = 1
+		}
+		best := 0
+		for _, option := range slot.Options {
+			if n := option.AvailableStock() / quantity; n > best {
+				best = n
+			}
+		}
+		if stock < 0 || best < stock {
+			stock = best
+		}
+	}
+	if stock < 0 {
+		stock = 0
+	}
+	p.Stock = stock
+	p.IsAvailable = stock > 0
+}
+
+// ApplyVariantTotals menyamakan harga dan stok produk dengan variannya:
+// harga = harga varian termurah, stok = total stok varian. Tidak berubah jika produk tanpa varian.
+func (p *Product) ApplyVariantTotals() {
+	if len(p.Variants) == 0 {
+		return
+	}
+	p.Price = p.Variants[0].Price
+	p.Stock = 0
+	for _, v := range p.Variants {
+		if v.Price < p.Price {
+			p.Price = v.Price
+		}
+		p.Stock += v.Stock
+	}
+	p.updateAvailability()
+}
+
+// HasRecipe mengembalikan true jika produk punya resep (Recipe harus sudah di-preload).
+// Stok produk beresep tidak dipakai; ketersediaannya ditentukan dari stok bahan inventory.
+func (p *Product) HasRecipe() bool {
+	return len(p.Recipe) > 0
+}
+
+// ApplyRecipeAvailability menentukan ketersediaan produk dan varian beresep dari bahannya: tersedia
+// jika bahan cukup untuk minimal satu porsi, berapa pun kolom stock-nya (Recipe beserta Inventory
+// harus sudah di-preload). Varian tanpa bahan resep yang berlaku tidak diubah. Hanya mengubah nilai
+// di memori.
+func (p *Product) ApplyRecipeAvailability() {
+	if !p.HasRecipe() {
+		return
+	}
+	if len(p.Variants) == 0 {
+		if portions := RecipePortions(p.Recipe, nil); portions >= 0 {
+			p.IsAvailable = portions >= 1
+		}
+		return
+	}
+
+	anyAvailable := false
+	for i := range p.Variants {
+		v := &p.Variants[i]
+		variantID := v.ID
+		if portions := RecipePortions(p.Recipe, &variantID); portions >= 0 {
+			v.IsAvailable = portions >= 1
+		}
+		anyAvailable = anyAvailable || v.IsAvailable
+	}
+	p.IsAvailable = anyAvailable
+}
+
+// GetAvailabilityStatus mengembalikan status availability sebagai string untuk response.
+// Produk beresep tidak mensyaratkan stock > 0 (lihat ApplyRecipeAvailability).
 func (p *Product) GetAvailabilityStatus() string {
-	if p.Stock > 0 {
+	if p.IsAvailable && (p.Stock > 0 || p.HasRecipe()) {
 		return "in_stock"
 	}
 	return "out_of_stock"
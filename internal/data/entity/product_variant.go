@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductVariant merepresentasikan tabel product_variants di database (contoh: Latte Hot Regular, Latte Iced Large).
+// Produk yang punya varian dijual per varian; harga produk menjadi harga varian termurah dan
+// stok produk menjadi total stok semua varian.
+type ProductVariant struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint           `gorm:"not null;index" json:"product_id"`
+	SKU         string         `gorm:"type:varchar(50);unique;not null" json:"sku"`
+	Name        string         `gorm:"type:varchar(100);not null" json:"name"`
+	Price       float64        `gorm:"type:decimal(15,2);not null;default:0" json:"price"`
+	Stock       int            `gorm:"type:int;not null;default:0" json:"stock"`
+	IsAvailable bool           `gorm:"type:boolean;not null;default:false" json:"is_available"`
+	CreatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// BeforeCreate hook untuk set is_available berdasarkan stock
+func (v *ProductVariant) BeforeCreate(tx *gorm.DB) error {
+	v.IsAvailable = v.Stock > 0
+	return nil
+}
+
+// BeforeUpdate hook untuk update timestamp dan is_available
+func (v *ProductVariant) BeforeUpdate(tx *gorm.DB) error {
+	v.UpdatedAt = time.Now()
+	v.IsAvailable = v.Stock > 0
+	return nil
+}
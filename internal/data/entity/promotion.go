@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipe promo
+const (
+	PromotionTypePercentage = "percentage"  // Diskon persen
+	PromotionTypeFixed      = "fixed"       // Potongan nominal (per item untuk scope produk/kategori)
+	PromotionTypeBuyXGetY   = "buy_x_get_y" // Beli X gratis Y (hanya scope produk/kategori)
+)
+
+// Cakupan promo
+const (
+	PromotionScopeOrder    = "order"
+	PromotionScopeCategory = "category"
+	PromotionScopeProduct  = "product"
+)
+
+// Promotion merepresentasikan tabel promotions di database.
+// Promo tanpa Code diterapkan otomatis, promo dengan Code hanya jika kodenya dipakai di order.
+// StartsAt/EndsAt membatasi periode promo, DailyStart/DailyEnd (HH:MM) dan DaysOfWeek
+// membatasi jam berlaku setiap hari (happy hour).
+type Promotion struct {
+	ID             uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name           string         `gorm:"type:varchar(100);not null" json:"name"`
+	Code           *string        `gorm:"type:varchar(50);uniqueIndex" json:"code,omitempty"`
+	Type           string         `gorm:"type:varchar(20);not null" json:"type"`
+	Scope          string         `gorm:"type:varchar(20);not null" json:"scope"`
+	CategoryID     *uint          `gorm:"index" json:"category_id,omitempty"`
+	ProductID      *uint          `gorm:"index" json:"product_id,omitempty"`
+	Value          float64        `gorm:"type:decimal(15,2);not null;default:0" json:"value"`
+	BuyQuantity    int            `gorm:"not null;default:0" json:"buy_quantity"`
+	GetQuantity    int            `gorm:"not null;default:0" json:"get_quantity"`
+	MinOrderAmount float64        `gorm:"type:decimal(15,2);not null;default:0" json:"min_order_amount"`
+	MaxDiscount    float64        `gorm:"type:decimal(15,2);not null;default:0" json:"max_discount"` // 0 = tanpa batas
+	StartsAt       *time.Time     `gorm:"type:timestamp" json:"starts_at,omitempty"`
+	EndsAt         *time.Time     `gorm:"type:timestamp" json:"ends_at,omitempty"`
+	DailyStart     string         `gorm:"type:varchar(5)" json:"daily_start"`    // HH:MM
+	DailyEnd       string         `gorm:"type:varchar(5)" json:"daily_end"`      // HH:MM
+	DaysOfWeek     string         `gorm:"type:varchar(20)" json:"days_of_week"`  // Contoh "1,2,3,4,5" (0 = Minggu)
+	UsageLimit     int            `gorm:"not null;default:0" json:"usage_limit"` // 0 = tanpa batas
+	UsageCount     int            `gorm:"not null;default:0" json:"usage_count"`
+	IsActive       bool           `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt      time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// BeforeUpdate hook untuk update timestamp
+func (p *Promotion) BeforeUpdate(tx *gorm.DB) error {
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsActiveAt mengecek apakah promo berlaku pada waktu t (periode, hari, dan jam happy hour)
+func (p *Promotion) IsActiveAt(t time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.StartsAt != nil && t.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && t.After(*p.EndsAt) {
+		return false
+	}
+
+	if p.DaysOfWeek != "" {
+		matched := false
+		for _, d := range strings.Split(p.DaysOfWeek, ",") {
+			if day, err := strconv.Atoi(strings.TrimSpace(d)); err == nil && time.Weekday(day) == t.Weekday() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if p.DailyStart != "" && p.DailyEnd != "" {
+		now := t.Format("15:04")
+		if p.DailyStart <= p.DailyEnd {
+			return now >= p.DailyStart && now < p.DailyEnd
+		}
+		// Jendela melewati tengah malam, contoh 22:00 - 02:00
+		return now >= p.DailyStart || now < p.DailyEnd
+	}
+
+	return true
+}
+
+// AppliesToItem mengecek apakah promo produk/kategori berlaku untuk item
+func (p *Promotion) AppliesToItem(productID, categoryID uint) bool {
+	switch p.Scope {
+	case PromotionScopeProduct:
+		return p.ProductID != nil && *p.ProductID == productID
+	case PromotionScopeCategory:
+		return p.CategoryID != nil && *p.CategoryID == categoryID
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status purchase order
+const (
+	PurchaseOrderStatusDraft             = "draft"              // Masih bisa diubah
+	PurchaseOrderStatusSent              = "sent"               // Sudah dikirim ke supplier
+	PurchaseOrderStatusPartiallyReceived = "partially_received" // Sebagian barang sudah diterima
+	PurchaseOrderStatusReceived          = "received"           // Semua barang sudah diterima
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+// PurchaseOrder merepresentasikan tabel purchase_orders di database: pesanan pembelian barang
+// inventory ke supplier. Barang masuk dicatat lewat GoodsReceipt.
+type PurchaseOrder struct {
+	ID           uint                `gorm:"primaryKey;autoIncrement" json:"id"`
+	PONumber     string              `gorm:"column:po_number;type:varchar(30);uniqueIndex" json:"po_number"`
+	SupplierID   uint                `gorm:"not null;index" json:"supplier_id"`
+	Status       string              `gorm:"type:varchar(20);not null;index" json:"status"`
+	ExpectedDate *time.Time          `gorm:"type:date" json:"expected_date,omitempty"`
+	Note         string              `gorm:"type:text" json:"note"`
+	TotalAmount  float64             `gorm:"type:decimal(15,2);not null;default:0" json:"total_amount"` // Total quantity x harga satuan pesanan
+	CreatedBy    *uint               `json:"created_by,omitempty"`
+	SentAt       *time.Time          `gorm:"type:timestamp" json:"sent_at,omitempty"`
+	ReceivedAt   *time.Time          `gorm:"type:timestamp" json:"received_at,omitempty"`
+	CreatedAt    time.Time           `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time           `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Supplier     Supplier            `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	Items        []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"items,omitempty"`
+	Receipts     []GoodsReceipt      `gorm:"foreignKey:PurchaseOrderID" json:"receipts,omitempty"`
+}
+
+// TableName override nama tabel
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// CanReceive mengembalikan true jika barang purchase order masih bisa diterima
+func (po PurchaseOrder) CanReceive() bool {
+	return po.Status == PurchaseOrderStatusSent || po.Status == PurchaseOrderStatusPartiallyReceived
+}
+
+// PurchaseOrderNumber membuat nomor purchase order dari ID
+func PurchaseOrderNumber(id uint) string {
+	return fmt.Sprintf("PO-%06d", id)
+}
+
+// PurchaseOrderItem merepresentasikan tabel purchase_order_items di database. Quantity dalam
+// satuan inventory; UnitCost adalah harga satuan yang disepakati saat memesan.
+type PurchaseOrderItem struct {
+	ID               uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	PurchaseOrderID  uint        `gorm:"not null;index" json:"purchase_order_id"`
+	InventoryID      int64       `gorm:"not null;index" json:"inventory_id"`
+	Quantity         float64     `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	ReceivedQuantity float64     `gorm:"type:decimal(15,3);not null;default:0" json:"received_quantity"`
+	UnitCost         float64     `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"`
+	CreatedAt        time.Time   `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	Inventory        Inventories `gorm:"foreignKey:InventoryID" json:"inventory,omitempty"`
+}
+
+// TableName override nama tabel
+func (PurchaseOrderItem) TableName() string {
+	return "purchase_order_items"
+}
+
+// RemainingQuantity mengembalikan quantity yang belum diterima
+func (i PurchaseOrderItem) RemainingQuantity() float64 {
+	if remaining := i.Quantity - i.ReceivedQuantity; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// GoodsReceipt merepresentasikan tabel goods_receipts di database: catatan penerimaan barang
+// (goods received note) untuk satu purchase order
+type GoodsReceipt struct {
+	ID              uint               `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReceiptNumber   string             `gorm:"type:varchar(30);uniqueIndex" json:"receipt_number"`
+	PurchaseOrderID uint               `gorm:"not null;index" json:"purchase_order_id"`
+	ReceivedBy      *uint              `json:"received_by,omitempty"`
+	Note            string             `gorm:"type:text" json:"note"`
+	TotalCost       float64            `gorm:"type:decimal(15,2);not null;default:0" json:"total_cost"`
+	CreatedAt       time.Time          `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	Items           []GoodsReceiptItem `gorm:"foreignKey:GoodsReceiptID" json:"items,omitempty"`
+}
+
+// TableName override nama tabel
+func (GoodsReceipt) TableName() string {
+	return "goods_receipts"
+}
+
+// GoodsReceiptNumber membuat nomor goods received note dari ID
+func GoodsReceiptNumber(id uint) string {
+	return fmt.Sprintf("GRN-%06d", id)
+}
+
+// GoodsReceiptItem merepresentasikan tabel goods_receipt_items di database. UnitCost adalah harga
+// satuan aktual saat barang diterima (bisa berbeda dari harga pesanan).
+type GoodsReceiptItem struct {
+	ID                  uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	GoodsReceiptID      uint       `gorm:"not null;index" json:"goods_receipt_id"`
+	PurchaseOrderItemID uint       `gorm:"not null;index" json:"purchase_order_item_id"`
+	InventoryID         int64      `gorm:"not null;index" json:"inventory_id"`
+	Quantity            float64    `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	UnitCost            float64    `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"`
+	StockMovementID     uint       `gorm:"not null;default:0" json:"stock_movement_id"`
+	BatchNumber         string     `gorm:"type:varchar(50)" json:"batch_number"`
+	ExpiryDate          *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
+	CreatedAt           time.Time  `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (GoodsReceiptItem) TableName() string {
+	return "goods_receipt_items"
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// RecipeItem merepresentasikan tabel recipe_items di database: bahan inventory yang dipakai
+// untuk membuat satu porsi produk. Quantity ditulis dalam Unit resep (contoh: 330 ml) dan dikonversi
+// ke satuan inventory saat stok bahan dihitung. VariantID nil berarti bahan dipakai semua varian.
+type RecipeItem struct {
+	ID          uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint        `gorm:"not null;index" json:"product_id"`
+	VariantID   *uint       `gorm:"index" json:"variant_id,omitempty"`
+	InventoryID int64       `gorm:"not null;index" json:"inventory_id"`
+	Quantity    float64     `gorm:"type:decimal(15,4);not null" json:"quantity"`
+	Unit        string      `gorm:"type:varchar(50);not null" json:"unit"`
+	CreatedAt   time.Time   `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time   `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Inventory   Inventories `gorm:"foreignKey:InventoryID" json:"inventory,omitempty"`
+}
+
+// TableName override nama tabel
+func (RecipeItem) TableName() string {
+	return "recipe_items"
+}
+
+// AppliesTo mengembalikan true jika bahan dipakai untuk varian tersebut (nil = produk tanpa varian)
+func (r RecipeItem) AppliesTo(variantID *uint) bool {
+	return r.VariantID == nil || (variantID != nil && *r.VariantID == *variantID)
+}
+
+// InventoryQuantity mengembalikan quantity bahan per porsi dalam satuan inventory
+// (Inventory dan Inventory.PackSizes harus sudah di-preload)
+func (r RecipeItem) InventoryQuantity() (float64, error) {
+	return r.Inventory.ToStockUnit(r.Quantity, r.Unit)
+}
+
+// RecipePortions menghitung berapa porsi yang bisa dibuat dari stok inventory saat ini untuk
+// varian tertentu. Bahan yang sudah dihapus, nonaktif, atau satuannya tidak cocok dianggap habis.
+// Mengembalikan -1 jika tidak ada bahan resep yang berlaku.
+func RecipePortions(recipe []RecipeItem, variantID *uint) float64 {
+	portions := -1.0
+	for _, item := range recipe {
+		if !item.AppliesTo(variantID) {
+			continue
+		}
+		available := 0.0
+		if perPortion, err := item.InventoryQuantity(); err == nil && perPortion > 0 &&
+			item.Inventory.ID != 0 && item.Inventory.Status == "active" && item.Inventory.Quantity > 0 {
+			available = item.Inventory.Quantity / perPortion
+		}
+		if portions < 0 || available < portions {
+			portions = available
+		}
+	}
+	return portions
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// Tipe pergerakan stok inventory
+const (
+	StockMovementPurchase   = "purchase"   // Barang masuk dari pembelian
+	StockMovementSale       = "sale"       // Bahan terpakai oleh order yang dibayar
+	StockMovementAdjustment = "adjustment" // Koreksi manual / stok awal
+	StockMovementWaste      = "waste"      // Barang rusak, kedaluwarsa, atau terbuang
+	StockMovementTransfer   = "transfer"   // Pindah ke/dari lokasi lain
+)
+
+// StockMovementTypes berisi semua tipe pergerakan stok yang valid
+var StockMovementTypes = []string{
+	StockMovementPurchase,
+	StockMovementSale,
+	StockMovementAdjustment,
+	StockMovementWaste,
+	StockMovementTransfer,
+}
+
+// StockMovement merepresentasikan tabel stock_movements di database: buku besar (append-only)
+// setiap perubahan quantity inventory. Delta positif berarti stok bertambah, Balance adalah
+// quantity inventory setelah pergerakan dicatat.
+type StockMovement struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	InventoryID   int64      `gorm:"not null;index:idx_stock_movements_inventory_created,priority:1" json:"inventory_id"`
+	Type          string     `gorm:"type:varchar(20);not null;index" json:"type"`
+	Delta         float64    `gorm:"type:decimal(15,3);not null" json:"delta"`
+	Balance       float64    `gorm:"type:decimal(15,3);not null" json:"balance"`
+	UserID        *uint      `gorm:"index" json:"user_id,omitempty"`
+	ReferenceType string     `gorm:"type:varchar(50)" json:"reference_type,omitempty"` // Contoh: order, inventory
+	ReferenceID   *uint      `json:"reference_id,omitempty"`
+	Note          string     `gorm:"type:text" json:"note,omitempty"`
+	UnitCost      *float64   `gorm:"type:decimal(15,4)" json:"unit_cost,omitempty"`              // Harga pokok per unit; diisi pemanggil untuk barang masuk (mis. pembelian)
+	FifoValue     float64    `gorm:"type:decimal(15,2);not null;default:0" json:"fifo_value"`    // Perubahan nilai persediaan metode FIFO (bertanda seperti Delta)
+	AverageValue  float64    `gorm:"type:decimal(15,2);not null;default:0" json:"average_value"` // Perubahan nilai persediaan metode weighted average
+	BatchNumber   string     `gorm:"type:varchar(50)" json:"batch_number,omitempty"`             // Nomor batch/lot barang masuk
+	ExpiryDate    *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`                     // Tanggal kedaluwarsa batch barang masuk
+	CreatedAt     time.Time  `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP;index:idx_stock_movements_inventory_created,priority:2" json:"created_at"`
+}
+
+// TableName override nama tabel
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
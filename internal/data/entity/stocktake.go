@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status sesi stocktake
+const (
+	StocktakeStatusCounting  = "counting"  // Masih menerima hasil hitung
+	StocktakeStatusApproved  = "approved"  // Disetujui manager, selisih sudah diposting sebagai adjustment
+	StocktakeStatusCancelled = "cancelled" // Dibatalkan tanpa mengubah stok
+)
+
+// Stocktake merepresentasikan tabel stocktakes di database: sesi hitung fisik (stock opname)
+// inventory. Quantity yang diharapkan di-snapshot saat sesi dibuat.
+type Stocktake struct {
+	ID         uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Number     string          `gorm:"type:varchar(30);uniqueIndex" json:"number"`
+	Status     string          `gorm:"type:varchar(20);not null;index" json:"status"`
+	Category   string          `gorm:"type:varchar(100)" json:"category"` // Kosong = semua kategori inventory
+	Note       string          `gorm:"type:text" json:"note"`
+	CreatedBy  *uint           `json:"created_by,omitempty"`
+	ApprovedBy *uint           `json:"approved_by,omitempty"`
+	ApprovedAt *time.Time      `gorm:"type:timestamp" json:"approved_at,omitempty"`
+	CreatedAt  time.Time       `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	Lines      []StocktakeLine `gorm:"foreignKey:StocktakeID" json:"lines,omitempty"`
+}
+
+// TableName override nama tabel
+func (Stocktake) TableName() string {
+	return "stocktakes"
+}
+
+// StocktakeNumber membuat nomor stocktake dari ID
+func StocktakeNumber(id uint) string {
+	return fmt.Sprintf("ST-%06d", id)
+}
+
+// StocktakeLine merepresentasikan tabel stocktake_lines di database: satu inventory dalam sesi
+// stocktake. ExpectedQuantity, RetailPrice, dan UnitCost adalah snapshot saat sesi dibuat;
+// CountedQuantity adalah jumlah hasil hitung semua counter (nil = belum dihitung).
+type StocktakeLine struct {
+	ID                   uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	StocktakeID          uint             `gorm:"not null;uniqueIndex:idx_stocktake_lines_inventory,priority:1" json:"stocktake_id"`
+	InventoryID          int64            `gorm:"not null;uniqueIndex:idx_stocktake_lines_inventory,priority:2" json:"inventory_id"`
+	ExpectedQuantity     float64          `gorm:"type:decimal(15,3);not null" json:"expected_quantity"`
+	CountedQuantity      *float64         `gorm:"type:decimal(15,3)" json:"counted_quantity,omitempty"`
+	RetailPrice          float64          `gorm:"type:decimal(10,2);not null;default:0" json:"retail_price"`
+	UnitCost             float64          `gorm:"type:decimal(15,2);not null;default:0" json:"unit_cost"`
+	AdjustmentMovementID *uint            `json:"adjustment_movement_id,omitempty"`
+	Inventory            Inventories      `gorm:"foreignKey:InventoryID" json:"inventory,omitempty"`
+	Counts               []StocktakeCount `gorm:"foreignKey:StocktakeLineID" json:"counts,omitempty"`
+}
+
+// TableName override nama tabel
+func (StocktakeLine) TableName() string {
+	return "stocktake_lines"
+}
+
+// Variance mengembalikan selisih hitung fisik terhadap quantity yang diharapkan
+// (positif = lebih, negatif = kurang). Baris yang belum dihitung tidak punya selisih.
+func (l StocktakeLine) Variance() (float64, bool) {
+	if l.CountedQuantity == nil {
+		return 0, false
+	}
+	return *l.CountedQuantity - l.ExpectedQuantity, true
+}
+
+// StocktakeCount merepresentasikan tabel stocktake_counts di database: hasil hitung satu counter
+// untuk satu baris stocktake. Setiap counter punya satu hasil per baris (hitung ulang menimpa).
+type StocktakeCount struct {
+	ID              uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	StocktakeLineID uint      `gorm:"not null;uniqueIndex:idx_stocktake_counts_counter,priority:1" json:"stocktake_line_id"`
+	CountedBy       uint      `gorm:"not null;default:0;uniqueIndex:idx_stocktake_counts_counter,priority:2" json:"counted_by"` // 0 = tidak diketahui
+	Quantity        float64   `gorm:"type:decimal(15,3);not null" json:"quantity"`
+	Note            string    `gorm:"type:text" json:"note"`
+	CreatedAt       time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// TableName override nama tabel
+func (StocktakeCount) TableName() string {
+	return "stocktake_counts"
+}
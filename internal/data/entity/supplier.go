@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Supplier merepresentasikan tabel suppliers di database: pemasok barang inventory
+type Supplier struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string         `gorm:"type:varchar(150);not null" json:"name"`
+	ContactName string         `gorm:"type:varchar(100)" json:"contact_name"`
+	Phone       string         `gorm:"type:varchar(30)" json:"phone"`
+	Email       string         `gorm:"type:varchar(150)" json:"email"`
+	Address     string         `gorm:"type:text" json:"address"`
+	Note        string         `gorm:"type:text" json:"note"`
+	IsActive    bool           `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (Supplier) TableName() string {
+	return "suppliers"
+}
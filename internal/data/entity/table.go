@@ -6,15 +6,25 @@ import (
 	"gorm.io/gorm"
 )
 
+// Status meja. occupied diatur otomatis oleh order dine-in, reserved oleh reservasi,
+// sedangkan out_of_service hanya bisa diatur manual oleh staff
+const (
+	TableStatusAvailable    = "available"
+	TableStatusOccupied     = "occupied"
+	TableStatusReserved     = "reserved"
+	TableStatusOutOfService = "out_of_service"
+)
+
 // Table merepresentasikan tabel tables di database
 type Table struct {
-	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Number    string         `gorm:"type:varchar(10);not null;unique" json:"number"`
-	Capacity  int            `gorm:"not null" json:"capacity"`
-	Status    string         `gorm:"type:varchar(20);not null;default:'available'" json:"status"`
-	CreatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID            uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Number        string         `gorm:"type:varchar(10);not null;unique" json:"number"`
+	Capacity      int            `gorm:"not null" json:"capacity"`
+	Status        string         `gorm:"type:varchar(20);not null;default:'available'" json:"status"`
+	ReservationID *int64         `gorm:"index" json:"reservation_id,omitempty"` // Reservasi yang menahan meja (diisi otomatis oleh sinkronisasi reservasi)
+	CreatedAt     time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName override nama tabel
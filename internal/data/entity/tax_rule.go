@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipe aturan pajak
+const (
+	TaxTypeTax           = "tax"
+	TaxTypeServiceCharge = "service_charge"
+)
+
+// TaxRule merepresentasikan tabel tax_rules di database.
+// Aturan tanpa OutletID berlaku di semua outlet, aturan tanpa CategoryID berlaku untuk semua kategori.
+// Aturan dihitung berurutan berdasarkan Priority; aturan compound dihitung dari subtotal
+// ditambah aturan sebelumnya (misalnya PB1 dihitung setelah service charge).
+type TaxRule struct {
+	ID         uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	OutletID   *uint          `gorm:"index" json:"outlet_id,omitempty"`
+	CategoryID *uint          `gorm:"index" json:"category_id,omitempty"`
+	Name       string         `gorm:"type:varchar(50);not null" json:"name"`
+	Type       string         `gorm:"type:varchar(20);not null;default:'tax'" json:"type"`
+	Rate       float64        `gorm:"type:decimal(5,2);not null" json:"rate"` // Persentase, contoh 10 untuk 10%
+	Priority   int            `gorm:"not null;default:0" json:"priority"`
+	IsCompound bool           `gorm:"type:boolean;not null;default:false" json:"is_compound"`
+	IsActive   bool           `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt  time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"type:timestamp;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// TableName override nama tabel
+func (TaxRule) TableName() string {
+	return "tax_rules"
+}
+
+// BeforeUpdate hook untuk update timestamp
+func (t *TaxRule) BeforeUpdate(tx *gorm.DB) error {
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// AppliesTo mengecek apakah aturan berlaku untuk kategori produk tertentu
+func (t *TaxRule) AppliesTo(categoryID uint) bool {
+	return t.CategoryID == nil || *t.CategoryID == categoryID
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// Alasan pencatatan waste
+const (
+	WasteReasonSpoilage    = "spoilage"          // Basi/rusak di penyimpanan
+	WasteReasonExpired     = "expired"           // Melewati tanggal kedaluwarsa
+	WasteReasonDamaged     = "damaged"           // Jatuh, pecah, tumpah
+	WasteReasonStaffMeal   = "staff_meal"        // Makan/minum staf
+	WasteReasonPreparation = "preparation_error" // Salah buat/dikembalikan dapur
+	WasteReasonOther       = "other"
+)
+
+// WasteReasons berisi semua alasan waste yang valid
+var WasteReasons = []string{
+	WasteReasonSpoilage,
+	WasteReasonExpired,
+	WasteReasonDamaged,
+	WasteReasonStaffMeal,
+	WasteReasonPreparation,
+	WasteReasonOther,
+}
+
+// WasteUnitPortion adalah satuan waste produk (jumlah porsi/item yang terbuang)
+const WasteUnitPortion = "portion"
+
+// WasteLog merepresentasikan tabel waste_logs di database: stok yang terbuang untuk satu inventory
+// atau satu produk (tepat salah satu terisi). Waste inventory mengurangi stok inventory tersebut;
+// waste produk mengurangi stok produk/varian dan bahan resepnya. Semua pengurangan stok dicatat
+// sebagai pergerakan stok waste dengan reference waste, dan harga pokoknya disimpan untuk kedua
+// metode valuasi agar laporan tidak berubah saat metode diganti.
+type WasteLog struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	InventoryID *int64    `gorm:"index" json:"inventory_id,omitempty"`
+	ProductID   *uint     `gorm:"index" json:"product_id,omitempty"`
+	VariantID   *uint     `json:"variant_id,omitempty"`
+	Reason      string    `gorm:"type:varchar(30);not null;index" json:"reason"`
+	Quantity    float64   `gorm:"type:decimal(15,3);not null" json:"quantity"` // Dalam Unit
+	Unit        string    `gorm:"type:varchar(50);not null" json:"unit"`       // Satuan inventory, atau portion untuk produk
+	StaffID     *uint     `gorm:"index" json:"staff_id,omitempty"`             // Staff yang bertanggung jawab
+	PhotoURL    string    `gorm:"type:varchar(500)" json:"photo_url,omitempty"`
+	Note        string    `gorm:"type:text" json:"note,omitempty"`
+	RecordedBy  *uint     `json:"recorded_by,omitempty"` // User ID yang mencatat
+	FifoCost    float64   `gorm:"type:decimal(15,2);not null;default:0" json:"fifo_cost"`
+	AverageCost float64   `gorm:"type:decimal(15,2);not null;default:0" json:"average_cost"`
+	WastedAt    time.Time `gorm:"type:timestamp with time zone;not null;index" json:"wasted_at"`
+	CreatedAt   time.Time `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+
+	Inventory *Inventories    `gorm:"foreignKey:InventoryID" json:"inventory,omitempty"`
+	Product   *Product        `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID" json:"variant,omitempty"`
+	Staff     *Staff          `gorm:"foreignKey:StaffID" json:"staff,omitempty"`
+}
+
+// TableName override nama tabel
+func (WasteLog) TableName() string {
+	return "waste_logs"
+}
+
+// IsValidWasteReason mengembalikan true jika reason adalah alasan waste yang dikenal
+func IsValidWasteReason(reason string) bool {
+	for _, r := range WasteReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
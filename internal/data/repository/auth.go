@@ -21,6 +21,7 @@ type AuthRepository interface {
 	MarkUserAsDeleted(ctx context.Context, id uint) error
 	GetAdminsList(ctx context.Context, offset, limit int, role string) ([]entity.User, int64, error)
 	CountSuperadmins(ctx context.Context) (int64, error)
+	GetActiveUsersByRoles(ctx context.Context, roles []string) ([]entity.User, error)
 
 	// OTP operations
 	CreateOTP(ctx context.Context, otp *entity.OTP) error
@@ -179,6 +180,24 @@ func (r *authRepository) GetAdminsList(ctx context.Context, offset, limit int, r
 	return admins, total, nil
 }
 
+// GetActiveUsersByRoles mengambil user aktif dengan salah satu role (mis. penerima notifikasi sistem)
+func (r *authRepository) GetActiveUsersByRoles(ctx context.Context, roles []string) ([]entity.User, error) {
+	var users []entity.User
+
+	if err := r.db.WithContext(ctx).
+		Where("is_deleted = ? AND status = ? AND role IN ?", false, "active", roles).
+		Order("id ASC").
+		Find(&users).Error; err != nil {
+		r.logger.Error("Failed to get users by roles",
+			zap.Strings("roles", roles),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // CountSuperadmins menghitung jumlah superadmin
 func (r *authRepository) CountSuperadmins(ctx context.Context) (int64, error) {
 	var count int64
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"math"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// movementCost berisi hasil perhitungan harga pokok satu pergerakan stok
+type movementCost struct {
+	averageCost   float64 // Harga pokok rata-rata inventory setelah pergerakan
+	layerQuantity float64 // Quantity cost layer baru untuk barang masuk (0 = tidak ada layer)
+	layerUnitCost float64
+}
+
+// costStockMovement menghitung nilai pergerakan stok untuk kedua metode valuasi. Barang masuk
+// memakai UnitCost dari pemanggil (atau harga rata-rata saat ini jika kosong) dan menggeser harga
+// rata-rata; barang keluar mengambil cost layer urut FIFO dan dinilai dengan harga rata-rata untuk
+// weighted average. Quantity yang tidak tertutup cost layer (stok minus atau stok lama tanpa layer)
+// dinilai dengan harga rata-rata. Harus dipanggil dengan baris inventory yang sudah dikunci.
+func costStockMovement(tx *gorm.DB, inventory *entity.Inventories, movement *entity.StockMovement) (*movementCost, error) {
+	cost := &movementCost{averageCost: inventory.AverageCost}
+
+	if movement.Delta > 0 {
+		unitCost := inventory.AverageCost
+		if movement.UnitCost != nil {
+			unitCost = *movement.UnitCost
+		}
+		movement.UnitCost = &unitCost
+		movement.FifoValue = roundCost(movement.Delta * unitCost)
+		movement.AverageValue = movement.FifoValue
+
+		balance := inventory.Quantity + movement.Delta
+		if inventory.Quantity > 0 {
+			cost.averageCost = (inventory.Quantity*inventory.AverageCost + movement.Delta*unitCost) / balance
+		} else {
+			cost.averageCost = unitCost
+		}
+		cost.averageCost = math.Round(cost.averageCost*10000) / 10000
+
+		// Barang masuk lebih dulu menutup stok minus yang sudah dinilai dengan harga rata-rata
+		cost.layerQuantity = math.Min(movement.Delta, balance)
+		cost.layerUnitCost = unitCost
+		return cost, nil
+	}
+
+	quantity := -movement.Delta
+	movement.AverageValue = roundCost(movement.Delta * inventory.AverageCost)
+
+	var layers []entity.CostLayer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("inventory_id = ? AND remaining_quantity > 0", inventory.ID).
+		Order("id ASC").
+		Find(&layers).Error; err != nil {
+		return nil, err
+	}
+
+	takes, fifoCost := consumeCostLayers(layers, quantity, inventory.AverageCost)
+	for i, take := range takes {
+		if take <= 0 {
+			continue
+		}
+		if err := tx.Model(&entity.CostLayer{}).Where("id = ?", layers[i].ID).
+			UpdateColumn("remaining_quantity", gorm.Expr("remaining_quantity - ?", take)).Error; err != nil {
+			return nil, err
+		}
+	}
+	movement.FifoValue = -roundCost(fifoCost)
+	return cost, nil
+}
+
+// consumeCostLayers mengambil quantity dari cost layer urut FIFO. Mengembalikan quantity yang diambil
+// dari setiap layer dan total nilainya; sisa yang tidak tertutup layer dinilai dengan averageCost.
+func consumeCostLayers(layers []entity.CostLayer, quantity, averageCost float64) ([]float64, float64) {
+	takes := make([]float64, len(layers))
+	var fifoCost float64
+	for i, layer := range layers {
+		if quantity <= 0 {
+			break
+		}
+		take := math.Min(quantity, layer.RemainingQuantity)
+		takes[i] = take
+		fifoCost += take * layer.UnitCost
+		quantity -= take
+	}
+	if quantity > 0 {
+		fifoCost += quantity * averageCost
+	}
+	return takes, fifoCost
+}
+
+// createCostLayer mencatat cost layer untuk barang masuk setelah pergerakan stok tersimpan
+func createCostLayer(tx *gorm.DB, movement *entity.StockMovement, cost *movementCost) error {
+	if cost.layerQuantity <= 0 {
+		return nil
+	}
+	return tx.Create(&entity.CostLayer{
+		InventoryID:       movement.InventoryID,
+		StockMovementID:   movement.ID,
+		UnitCost:          cost.layerUnitCost,
+		Quantity:          movement.Delta,
+		RemainingQuantity: cost.layerQuantity,
+	}).Error
+}
+
+// roundCost membulatkan nilai uang ke 2 desimal
+func roundCost(v float64) float64 {
+	return math.Round(v*100) / 100
+}
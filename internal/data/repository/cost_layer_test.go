@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"math"
+	"testing"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+)
+
+func TestConsumeCostLayers(t *testing.T) {
+	layers := []entity.CostLayer{
+		{ID: 1, UnitCost: 2, RemainingQuantity: 10},
+		{ID: 2, UnitCost: 3, RemainingQuantity: 5},
+	}
+
+	tests := []struct {
+		name        string
+		layers      []entity.CostLayer
+		quantity    float64
+		averageCost float64
+		wantTakes   []float64
+		wantCost    float64
+	}{
+		{
+			name:        "layer terlama habis lebih dulu",
+			layers:      layers,
+			quantity:    12,
+			averageCost: 2.5,
+			wantTakes:   []float64{10, 2},
+			wantCost:    26,
+		},
+		{
+			name:        "tepat menghabiskan layer pertama",
+			layers:      layers,
+			quantity:    10,
+			averageCost: 2.5,
+			wantTakes:   []float64{10, 0},
+			wantCost:    20,
+		},
+		{
+			name:        "sisa di luar layer dinilai harga rata-rata",
+			layers:      layers,
+			quantity:    20,
+			averageCost: 2.5,
+			wantTakes:   []float64{10, 5},
+			wantCost:    47.5,
+		},
+		{
+			name:        "tanpa layer",
+			quantity:    4,
+			averageCost: 1.5,
+			wantTakes:   []float64{},
+			wantCost:    6,
+		},
+		{
+			name:        "quantity pecahan",
+			layers:      []entity.CostLayer{{ID: 1, UnitCost: 12000, RemainingQuantity: 0.25}},
+			quantity:    0.1,
+			averageCost: 10000,
+			wantTakes:   []float64{0.1},
+			wantCost:    1200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			takes, cost := consumeCostLayers(tt.layers, tt.quantity, tt.averageCost)
+			if len(takes) != len(tt.wantTakes) {
+				t.Fatalf("takes = %v, want %v", takes, tt.wantTakes)
+			}
+			for i := range takes {
+				if math.Abs(takes[i]-tt.wantTakes[i]) > 1e-9 {
+					t.Errorf("take[%d] = %v, want %v", i, takes[i], tt.wantTakes[i])
+				}
+			}
+			if math.Abs(cost-tt.wantCost) > 1e-6 {
+				t.Errorf("cost = %v, want %v", cost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestCostStockMovementIncoming(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name          string
+		inventory     entity.Inventories
+		delta         float64
+		unitCost      *float64
+		wantAverage   float64
+		wantValue     float64
+		wantLayerQty  float64
+		wantLayerCost float64
+	}{
+		{
+			name:          "harga rata-rata tertimbang bergeser",
+			inventory:     entity.Inventories{Quantity: 10, AverageCost: 2},
+			delta:         10,
+			unitCost:      float(4),
+			wantAverage:   3,
+			wantValue:     40,
+			wantLayerQty:  10,
+			wantLayerCost: 4,
+		},
+		{
+			name:          "tanpa unit cost memakai harga rata-rata",
+			inventory:     entity.Inventories{Quantity: 5, AverageCost: 2.5},
+			delta:         2,
+			wantAverage:   2.5,
+			wantValue:     5,
+			wantLayerQty:  2,
+			wantLayerCost: 2.5,
+		},
+		{
+			name:          "barang masuk menutup stok minus lebih dulu",
+			inventory:     entity.Inventories{Quantity: -4, AverageCost: 2},
+			delta:         10,
+			unitCost:      float(3),
+			wantAverage:   3,
+			wantValue:     30,
+			wantLayerQty:  6,
+			wantLayerCost: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventory := tt.inventory
+			movement := &entity.StockMovement{Delta: tt.delta, UnitCost: tt.unitCost}
+
+			// Barang masuk tidak membaca cost layer sehingga tidak butuh koneksi database
+			cost, err := costStockMovement(nil, &inventory, movement)
+			if err != nil {
+				t.Fatalf("costStockMovement() unexpected error: %v", err)
+			}
+			if cost.averageCost != tt.wantAverage {
+				t.Errorf("averageCost = %v, want %v", cost.averageCost, tt.wantAverage)
+			}
+			if movement.FifoValue != tt.wantValue || movement.AverageValue != tt.wantValue {
+				t.Errorf("value = fifo %v / average %v, want %v", movement.FifoValue, movement.AverageValue, tt.wantValue)
+			}
+			if cost.layerQuantity != tt.wantLayerQty || cost.layerUnitCost != tt.wantLayerCost {
+				t.Errorf("layer = %v @ %v, want %v @ %v", cost.layerQuantity, cost.layerUnitCost, tt.wantLayerQty, tt.wantLayerCost)
+			}
+		})
+	}
+}
@@ -66,17 +66,25 @@ func (r *dashboardRepository) getSalesSummary(ctx context.Context, startDate, en
 	var summary dto.SalesSummary
 
 	// Query for total orders, revenue, tax
+	// Pendapatan dan pajak hanya dari order yang sudah dibayar (lihat collectedOrderStatuses)
 	type Result struct {
-		TotalOrders  int
-		TotalRevenue float64
-		TotalTax     float64
+		TotalOrders     int
+		CollectedOrders int
+		TotalRevenue    float64
+		TotalTax        float64
 	}
 	var result Result
 
 	err := r.db.WithContext(ctx).Model(&entity.Order{}).
-		Select("COUNT(*) as total_orders, COALESCE(SUM(total_amount), 0) as total_revenue, COALESCE(SUM(tax), 0) as total_tax").
+		Select(`COUNT(*) as total_orders,
+			COUNT(CASE WHEN status IN ? THEN 1 END) as collected_orders,
+			COALESCE(SUM(CASE WHEN status IN ? THEN total_amount END), 0) as total_revenue,
+			COALESCE(SUM(CASE WHEN status IN ? THEN tax END), 0) as total_tax`,
+			collectedOrderStatuses, collectedOrderStatuses, collectedOrderStatuses).
 		Where("created_at >= ? AND created_at < ?", startDate, endDate).
 		Where("deleted_at IS NULL").
+		// Order yang dibagi rata/digabung sudah diwakili order hasilnya
+		Where("status NOT IN ?", []string{entity.OrderStatusSplit, entity.OrderStatusMerged}).
 		Scan(&result).Error
 	if err != nil {
 		r.logger.Error("Failed to get sales summary", zap.Error(err))
@@ -84,11 +92,10 @@ func (r *dashboardRepository) getSalesSummary(ctx context.Context, startDate, en
 	}
 
 	summary.TotalOrders = result.TotalOrders
-	summary.TotalRevenue = result.TotalRevenue
 	summary.TotalTax = result.TotalTax
 
-	if result.TotalOrders > 0 {
-		summary.AverageOrder = result.TotalRevenue / float64(result.TotalOrders)
+	if result.CollectedOrders > 0 {
+		summary.AverageOrder = result.TotalRevenue / float64(result.CollectedOrders)
 	}
 
 	// Count orders by status
@@ -111,15 +118,57 @@ func (r *dashboardRepository) getSalesSummary(ctx context.Context, startDate, en
 
 	for _, sc := range statusCounts {
 		switch sc.Status {
-		case "paid":
+		case entity.OrderStatusPaid:
 			summary.PaidOrders = sc.Count
-		case "pending":
+		case entity.OrderStatusPending:
 			summary.PendingOrders = sc.Count
-		case "cancelled":
+		case entity.OrderStatusInKitchen:
+			summary.InKitchenOrders = sc.Count
+		case entity.OrderStatusServed:
+			summary.ServedOrders = sc.Count
+		case entity.OrderStatusCancelled:
 			summary.CancelledOrders = sc.Count
+		case entity.OrderStatusRefunded:
+			summary.RefundedOrders = sc.Count
 		}
 	}
 
+	// Pembayaran per metode pembayaran (split tender dihitung per tender)
+	err = r.db.WithContext(ctx).Table("order_payments op").
+		Select("pm.id as payment_method_id, pm.name as payment_method, COALESCE(SUM(op.amount), 0) as total_amount, COUNT(op.id) as payment_count, COUNT(DISTINCT op.order_id) as order_count").
+		Joins("JOIN orders o ON o.id = op.order_id AND o.deleted_at IS NULL").
+		Joins("JOIN payment_methods pm ON pm.id = op.payment_method_id").
+		Where("o.created_at >= ? AND o.created_at < ?", startDate, endDate).
+		Group("pm.id, pm.name").
+		Order("total_amount DESC").
+		Scan(&summary.PaymentMethods).Error
+	if err != nil {
+		r.logger.Error("Failed to get payment method summary", zap.Error(err))
+		return nil, err
+	}
+
+	// Void dan refund dihitung pada periode dicatat
+	var refunds dto.RefundTotals
+	err = r.db.WithContext(ctx).Table("order_refunds rf").
+		Select(`COALESCE(SUM(CASE WHEN rf.type = ? THEN rf.amount END), 0) as voided_amount,
+			COUNT(CASE WHEN rf.type = ? THEN 1 END) as void_count,
+			COALESCE(SUM(CASE WHEN rf.type = ? THEN rf.amount END), 0) as refunded_amount,
+			COUNT(CASE WHEN rf.type = ? THEN 1 END) as refund_count`,
+			entity.OrderRefundTypeVoid, entity.OrderRefundTypeVoid, entity.OrderRefundTypeRefund, entity.OrderRefundTypeRefund).
+		Joins("JOIN orders o ON o.id = rf.order_id AND o.deleted_at IS NULL").
+		Where("rf.created_at >= ? AND rf.created_at < ?", startDate, endDate).
+		Scan(&refunds).Error
+	if err != nil {
+		r.logger.Error("Failed to get refund summary", zap.Error(err))
+		return nil, err
+	}
+	// Void tidak pernah masuk pendapatan; refund dikurangkan dari pendapatan periode dicatat
+	summary.TotalRevenue = roundAmount(result.TotalRevenue - refunds.RefundedAmount)
+	summary.VoidedAmount = refunds.VoidedAmount
+	summary.VoidCount = refunds.VoidCount
+	summary.RefundedAmount = refunds.RefundedAmount
+	summary.RefundCount = refunds.RefundCount
+
 	r.logger.Info("Successfully retrieved sales summary",
 		zap.Int("total_orders", summary.TotalOrders),
 		zap.Float64("total_revenue", summary.TotalRevenue))
@@ -159,12 +208,14 @@ func (r *dashboardRepository) GetTableSummary(ctx context.Context) (*dto.TableSu
 
 	for _, sc := range statusCounts {
 		switch sc.Status {
-		case "available":
+		case entity.TableStatusAvailable:
 			summary.AvailableTables = sc.Count
-		case "occupied":
+		case entity.TableStatusOccupied:
 			summary.OccupiedTables = sc.Count
-		case "reserved":
+		case entity.TableStatusReserved:
 			summary.ReservedTables = sc.Count
+		case entity.TableStatusOutOfService:
+			summary.OutOfServiceTables = sc.Count
 		}
 	}
 
@@ -180,9 +231,9 @@ func (r *dashboardRepository) GetPopularProducts(ctx context.Context, limit int)
 
 	var results []PopularProductResult
 
-	// Query popular products based on order_items
+	// Query popular products based on order_items; penjualan bundle dihitung ke produk komponennya
 	err := r.db.WithContext(ctx).
-		Table("order_items oi").
+		Table("(" + productSalesSQL + ") oi").
 		Select(`
 		       p.id as product_id,
 		       p.product_image,
@@ -194,7 +245,6 @@ func (r *dashboardRepository) GetPopularProducts(ctx context.Context, limit int)
 		       CASE WHEN p.stock > 0 THEN 'in_stock' ELSE 'out_of_stock' END as availability
 	       `).
 		Joins("JOIN products p ON oi.product_id = p.id").
-		Where("oi.deleted_at IS NULL").
 		Where("p.deleted_at IS NULL").
 		Group("p.id, p.product_image, p.product_name, p.price, p.stock").
 		Order("total_sold DESC").
@@ -206,6 +256,21 @@ func (r *dashboardRepository) GetPopularProducts(ctx context.Context, limit int)
 		return nil, err
 	}
 
+	productIDs := make([]uint, 0, len(results))
+	for _, result := range results {
+		productIDs = append(productIDs, result.ProductID)
+	}
+	availability, err := r.recipeAvailability(ctx, productIDs)
+	if err != nil {
+		r.logger.Error("Failed to get recipe availability", zap.Error(err))
+		return nil, err
+	}
+	for i := range results {
+		if status, ok := availability[results[i].ProductID]; ok {
+			results[i].Availability = status
+		}
+	}
+
 	r.logger.Info("Successfully retrieved popular products", zap.Int("count", len(results)))
 	return results, nil
 }
@@ -239,7 +304,7 @@ func (r *dashboardRepository) GetNewProducts(ctx context.Context, days int, limi
 		       COALESCE(SUM(oi.quantity), 0) as total_sold,
 		       p.created_at
 	       `).
-		Joins("LEFT JOIN order_items oi ON oi.product_id = p.id AND oi.deleted_at IS NULL").
+		Joins("LEFT JOIN ("+productSalesSQL+") oi ON oi.product_id = p.id").
 		Where("p.created_at >= ?", cutoffDate).
 		Where("p.deleted_at IS NULL").
 		Group("p.id, p.product_image, p.product_name, p.price, p.stock, p.created_at").
@@ -252,10 +317,52 @@ func (r *dashboardRepository) GetNewProducts(ctx context.Context, days int, limi
 		return nil, err
 	}
 
+	productIDs := make([]uint, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	availability, err := r.recipeAvailability(ctx, productIDs)
+	if err != nil {
+		r.logger.Error("Failed to get recipe availability", zap.Error(err))
+		return nil, err
+	}
+	for i := range products {
+		if status, ok := availability[products[i].ProductID]; ok {
+			products[i].Availability = status
+		}
+	}
+
 	r.logger.Info("Successfully retrieved new products", zap.Int("count", len(products)))
 	return products, nil
 }
 
+// recipeAvailability menghitung status availability produk beresep di antara productIDs dari stok
+// bahannya (lihat entity.Product.ApplyRecipeAvailability), karena kolom stock produk beresep tidak
+// dipakai. Produk tanpa resep tidak ada di hasil dan tetap memakai status dari stock.
+func (r *dashboardRepository) recipeAvailability(ctx context.Context, productIDs []uint) (map[uint]string, error) {
+	result := make(map[uint]string)
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	var products []entity.Product
+	err := r.db.WithContext(ctx).
+		Preload("Variants", orderByID).
+		Preload("Recipe.Inventory.PackSizes").
+		Where("id IN ?", productIDs).
+		Where("EXISTS (SELECT 1 FROM recipe_items ri WHERE ri.product_id = products.id)").
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range products {
+		products[i].ApplyRecipeAvailability()
+		result[products[i].ID] = products[i].GetAvailabilityStatus()
+	}
+	return result, nil
+}
+
 // GetDB returns the *gorm.DB instance
 func (r *dashboardRepository) GetDB() *gorm.DB {
 	return r.db
@@ -3,19 +3,42 @@ package repository
 import (
 	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/pkg/unit"
 	"context"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type InventoriesRepository interface {
-	Create(ctx context.Context, inventories *entity.Inventories) error
-	Update(ctx context.Context, inventories *entity.Inventories) error
+	Create(ctx context.Context, inventories *entity.Inventories, userID *uint) error
+	Update(ctx context.Context, inventories *entity.Inventories, userID *uint, note string) error
 	Delete(ctx context.Context, id int64) error
+	FindByID(ctx context.Context, id int64) (*entity.Inventories, error)
 	FindByFilter(ctx context.Context, filter dto.InventoriesFilter) ([]entity.Inventories, int64, error)
 	FindAll(ctx context.Context, filter dto.InventoriesFilter) ([]entity.Inventories, int64, error)
+	GetValuation(ctx context.Context, category string) ([]InventoryValuation, error)
+	FindBatches(ctx context.Context, inventoryID int64, includeEmpty bool) ([]entity.InventoryBatch, error)
+	FindExpiringBatches(ctx context.Context, until time.Time) ([]entity.InventoryBatch, error)
+	MarkBatchesAlerted(ctx context.Context, ids []uint) error
+	ClaimLowStock(ctx context.Context) ([]entity.Inventories, error)
+	ClearRestockedAlerts(ctx context.Context) (int64, error)
+}
+
+// InventoryValuation berisi data nilai persediaan satu inventory untuk kedua metode valuasi
+type InventoryValuation struct {
+	InventoryID   int64
+	Name          string
+	Category      string
+	Unit          string
+	Quantity      float64
+	RetailPrice   float64
+	AverageCost   float64
+	LayerQuantity float64 // Jumlah sisa quantity cost layer
+	LayerValue    float64 // Jumlah sisa quantity x harga pokok cost layer
 }
 
 // inventoriesRepository adalah implementasi dari interface InventoriesRepository
@@ -29,14 +52,38 @@ func NewInventoriesRepository(db *gorm.DB, logger *zap.Logger) InventoriesReposi
 	return &inventoriesRepository{db: db, logger: logger}
 }
 
-// Create menambahkan item Inventories baru ke database
-func (r *inventoriesRepository) Create(ctx context.Context, inventories *entity.Inventories) error {
+// Create menambahkan item Inventories baru ke database. Quantity awal dicatat sebagai
+// pergerakan stok adjustment agar saldo ledger sama dengan quantity inventory.
+func (r *inventoriesRepository) Create(ctx context.Context, inventories *entity.Inventories, userID *uint) error {
 	r.logger.Info("Creating new inventory item",
 		zap.String("name", inventories.Name),
 		zap.String("category", inventories.Category),
-		zap.Int("quantity", inventories.Quantity))
+		zap.Float64("quantity", inventories.Quantity))
 
-	err := r.db.WithContext(ctx).Create(inventories).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		quantity := inventories.Quantity
+		inventories.Quantity = 0
+		if err := tx.Create(inventories).Error; err != nil {
+			return err
+		}
+		if quantity == 0 {
+			return nil
+		}
+
+		movement := &entity.StockMovement{
+			InventoryID:   inventories.ID,
+			Type:          entity.StockMovementAdjustment,
+			Delta:         quantity,
+			UserID:        userID,
+			ReferenceType: "inventory",
+			Note:          "Stok awal",
+		}
+		if err := recordStockMovement(tx, movement); err != nil {
+			return err
+		}
+		// Muat ulang agar quantity dan harga pokok rata-rata hasil pergerakan ikut terbaca
+		return tx.First(inventories, inventories.ID).Error
+	})
 	if err != nil {
 		r.logger.Error("Failed to create inventory item",
 			zap.String("name", inventories.Name),
@@ -50,13 +97,54 @@ func (r *inventoriesRepository) Create(ctx context.Context, inventories *entity.
 	return err
 }
 
-// Update memperbarui item Inventories yang sudah ada
-func (r *inventoriesRepository) Update(ctx context.Context, inventories *entity.Inventories) error {
+// Update memperbarui item Inventories yang sudah ada. Quantity tidak ditimpa langsung: selisih
+// dengan quantity saat ini dicatat sebagai pergerakan stok adjustment. Jika PackSizes tidak nil,
+// seluruh kemasan lama diganti (slice kosong menghapus semua kemasan).
+func (r *inventoriesRepository) Update(ctx context.Context, inventories *entity.Inventories, userID *uint, note string) error {
 	r.logger.Info("Updating inventory item",
 		zap.Int64("id", inventories.ID),
 		zap.String("name", inventories.Name))
 
-	err := r.db.WithContext(ctx).Save(inventories).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.Inventories
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, inventories.ID).Error; err != nil {
+			return err
+		}
+
+		quantity := inventories.Quantity
+		inventories.Quantity = current.Quantity
+		inventories.AverageCost = current.AverageCost
+		inventories.LowStockAlertedAt = current.LowStockAlertedAt
+		if err := tx.Omit("PackSizes").Save(inventories).Error; err != nil {
+			return err
+		}
+		if inventories.PackSizes != nil {
+			if err := replacePackSizes(tx, inventories.ID, inventories.PackSizes); err != nil {
+				return err
+			}
+		}
+
+		delta := quantity - current.Quantity
+		if delta == 0 {
+			return nil
+		}
+		if note == "" {
+			note = "Koreksi quantity manual"
+		}
+		movement := &entity.StockMovement{
+			InventoryID:   inventories.ID,
+			Type:          entity.StockMovementAdjustment,
+			Delta:         delta,
+			UserID:        userID,
+			ReferenceType: "inventory",
+			Note:          note,
+		}
+		if err := recordStockMovement(tx, movement); err != nil {
+			return err
+		}
+		// Muat ulang agar quantity dan harga pokok rata-rata hasil pergerakan ikut terbaca
+		return tx.First(inventories, inventories.ID).Error
+	})
 	if err != nil {
 		r.logger.Error("Failed to update inventory item",
 			zap.Int64("id", inventories.ID),
@@ -69,6 +157,18 @@ func (r *inventoriesRepository) Update(ctx context.Context, inventories *entity.
 	return err
 }
 
+// FindByID mengambil item Inventories berdasarkan ID
+func (r *inventoriesRepository) FindByID(ctx context.Context, id int64) (*entity.Inventories, error) {
+	var inventory entity.Inventories
+	if err := r.db.WithContext(ctx).Preload("PackSizes", orderByID).First(&inventory, id).Error; err != nil {
+		r.logger.Error("Failed to find inventory item",
+			zap.Int64("id", id),
+			zap.Error(err))
+		return nil, err
+	}
+	return &inventory, nil
+}
+
 // Delete menghapus item Inventories berdasarkan ID (soft delete)
 func (r *inventoriesRepository) Delete(ctx context.Context, id int64) error {
 	r.logger.Info("Deleting inventory item",
@@ -117,9 +217,9 @@ func (r *inventoriesRepository) FindByFilter(ctx context.Context, filter dto.Inv
 		query = query.Where("LOWER(category) = ?", strings.ToLower(filter.Category))
 	}
 
-	// Unit filter - filter berdasarkan satuan (Value)
+	// Unit filter - filter berdasarkan family satuan (litre juga mencocokkan l dan ml)
 	if filter.Unit != "" {
-		query = query.Where("LOWER(unit) = ?", strings.ToLower(filter.Unit))
+		query = query.Where("LOWER(unit) IN ?", unit.FamilyUnits(filter.Unit))
 	}
 
 	// Stock status filter - filter berdasarkan status stok
@@ -134,6 +234,15 @@ func (r *inventoriesRepository) FindByFilter(ctx context.Context, filter dto.Inv
 		}
 	}
 
+	// Expiring filter - inventory yang punya batch bersisa dengan kedaluwarsa dalam N hari
+	// (termasuk yang sudah lewat)
+	if filter.ExpiringWithin > 0 {
+		until := time.Now().AddDate(0, 0, filter.ExpiringWithin).Format("2006-01-02")
+		query = query.Where(`EXISTS (SELECT 1 FROM inventory_batches b
+			WHERE b.inventory_id = inventories.id AND b.remaining_quantity > 0
+			AND b.expiry_date IS NOT NULL AND b.expiry_date <= ?)`, until)
+	}
+
 	// Quantity range filter - filter berdasarkan range quantity (Piece/Item/Quantity)
 	if filter.MinQty > 0 {
 		query = query.Where("quantity >= ?", filter.MinQty)
@@ -187,7 +296,7 @@ func (r *inventoriesRepository) FindByFilter(ctx context.Context, filter dto.Inv
 	}
 
 	offset := (filter.Page - 1) * filter.Limit
-	err := query.Limit(filter.Limit).Offset(offset).Find(&inventories).Error
+	err := query.Preload("PackSizes", orderByID).Limit(filter.Limit).Offset(offset).Find(&inventories).Error
 
 	if err != nil {
 		r.logger.Error("Failed to find inventories by filter",
@@ -220,9 +329,9 @@ func (r *inventoriesRepository) FindAll(ctx context.Context, filter dto.Inventor
 		query = query.Where("LOWER(name) LIKE ?", searchPattern)
 	}
 
-	// Unit filter
+	// Unit filter (per family satuan)
 	if filter.Unit != "" {
-		query = query.Where("LOWER(unit) = ?", strings.ToLower(filter.Unit))
+		query = query.Where("LOWER(unit) IN ?", unit.FamilyUnits(filter.Unit))
 	}
 
 	// Stock status filter
@@ -283,7 +392,7 @@ func (r *inventoriesRepository) FindAll(ctx context.Context, filter dto.Inventor
 
 	// Pagination
 	offset := (filter.Page - 1) * filter.Limit
-	err := query.Limit(filter.Limit).Offset(offset).Find(&inventories).Error
+	err := query.Preload("PackSizes", orderByID).Limit(filter.Limit).Offset(offset).Find(&inventories).Error
 
 	if err != nil {
 		r.logger.Error("Failed to find all inventories",
@@ -348,3 +457,26 @@ func (r *inventoriesRepository) GetSummary(ctx context.Context) (*dto.Inventorie
 
 	return &summary, nil
 }
+
+// GetValuation mengambil quantity, harga pokok rata-rata, dan sisa cost layer setiap inventory
+// (opsional satu kategori) untuk laporan nilai persediaan
+func (r *inventoriesRepository) GetValuation(ctx context.Context, category string) ([]InventoryValuation, error) {
+	query := r.db.WithContext(ctx).Table("inventories i").
+		Select(`i.id AS inventory_id, i.name, i.category, i.unit, i.quantity, i.retail_price, i.average_cost,
+			COALESCE(SUM(cl.remaining_quantity), 0) AS layer_quantity,
+			COALESCE(SUM(cl.remaining_quantity * cl.unit_cost), 0) AS layer_value`).
+		Joins("LEFT JOIN cost_layers cl ON cl.inventory_id = i.id AND cl.remaining_quantity > 0").
+		Where("i.deleted_at IS NULL").
+		Group("i.id").
+		Order("i.name ASC, i.id ASC")
+	if category != "" {
+		query = query.Where("LOWER(i.category) = ?", strings.ToLower(category))
+	}
+
+	var rows []InventoryValuation
+	if err := query.Scan(&rows).Error; err != nil {
+		r.logger.Error("Failed to get inventory valuation", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
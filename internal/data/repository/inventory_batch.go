@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// applyBatchMovement memperbarui batch inventory untuk pergerakan stok yang sudah tersimpan. Barang
+// masuk membuka batch baru (setelah menutup stok minus); barang keluar mengurangi batch urut FEFO.
+// Kekurangan batch (stok minus) diabaikan karena quantity inventory tetap dicatat di ledger.
+func applyBatchMovement(tx *gorm.DB, previousQuantity float64, movement *entity.StockMovement) error {
+	if movement.Delta > 0 {
+		remaining := math.Min(movement.Delta, previousQuantity+movement.Delta)
+		if remaining <= 0 {
+			return nil
+		}
+		movementID := movement.ID
+		return tx.Create(&entity.InventoryBatch{
+			InventoryID:       movement.InventoryID,
+			StockMovementID:   &movementID,
+			BatchNumber:       movement.BatchNumber,
+			ExpiryDate:        movement.ExpiryDate,
+			Quantity:          movement.Delta,
+			RemainingQuantity: remaining,
+		}).Error
+	}
+
+	var batches []entity.InventoryBatch
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("inventory_id = ? AND remaining_quantity > 0", movement.InventoryID).
+		Order("expiry_date ASC NULLS LAST, id ASC").
+		Find(&batches).Error; err != nil {
+		return err
+	}
+
+	quantity := -movement.Delta
+	for _, batch := range batches {
+		if quantity <= 0 {
+			break
+		}
+		take := math.Min(quantity, batch.RemainingQuantity)
+		if err := tx.Model(&entity.InventoryBatch{}).Where("id = ?", batch.ID).
+			UpdateColumn("remaining_quantity", gorm.Expr("remaining_quantity - ?", take)).Error; err != nil {
+			return err
+		}
+		quantity -= take
+	}
+	return nil
+}
+
+// FindBatches mengambil batch inventory urut FEFO; batch yang sudah habis hanya disertakan jika includeEmpty
+func (r *inventoriesRepository) FindBatches(ctx context.Context, inventoryID int64, includeEmpty bool) ([]entity.InventoryBatch, error) {
+	query := r.db.WithContext(ctx).
+		Where("inventory_id = ?", inventoryID).
+		Order("expiry_date ASC NULLS LAST, id ASC")
+	if !includeEmpty {
+		query = query.Where("remaining_quantity > 0")
+	}
+
+	var batches []entity.InventoryBatch
+	if err := query.Find(&batches).Error; err != nil {
+		r.logger.Error("Failed to find inventory batches", zap.Int64("inventory_id", inventoryID), zap.Error(err))
+		return nil, err
+	}
+	return batches, nil
+}
+
+// FindExpiringBatches mengambil batch yang masih bersisa, kedaluwarsa paling lambat pada tanggal until,
+// dan belum pernah dikirim notifikasinya
+func (r *inventoriesRepository) FindExpiringBatches(ctx context.Context, until time.Time) ([]entity.InventoryBatch, error) {
+	var batches []entity.InventoryBatch
+	err := r.db.WithContext(ctx).
+		Preload("Inventory").
+		Joins("JOIN inventories i ON i.id = inventory_batches.inventory_id AND i.deleted_at IS NULL").
+		Where("inventory_batches.remaining_quantity > 0").
+		Where("inventory_batches.expiry_date IS NOT NULL AND inventory_batches.expiry_date <= ?", until.Format("2006-01-02")).
+		Where("inventory_batches.expiry_alerted_at IS NULL").
+		Order("inventory_batches.expiry_date ASC, inventory_batches.id ASC").
+		Find(&batches).Error
+	if err != nil {
+		r.logger.Error("Failed to find expiring inventory batches", zap.Error(err))
+		return nil, err
+	}
+	return batches, nil
+}
+
+// MarkBatchesAlerted menandai batch sudah dikirim notifikasi kedaluwarsanya
+func (r *inventoriesRepository) MarkBatchesAlerted(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Model(&entity.InventoryBatch{}).
+		Where("id IN ?", ids).
+		UpdateColumn("expiry_alerted_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to mark inventory batches as alerted", zap.Error(err))
+	}
+	return err
+}
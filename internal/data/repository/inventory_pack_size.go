@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"gorm.io/gorm"
+)
+
+// replacePackSizes mengganti seluruh kemasan pembelian inventory di dalam transaksi tx
+func replacePackSizes(tx *gorm.DB, inventoryID int64, packSizes []entity.InventoryPackSize) error {
+	if err := tx.Where("inventory_id = ?", inventoryID).Delete(&entity.InventoryPackSize{}).Error; err != nil {
+		return err
+	}
+	if len(packSizes) == 0 {
+		return nil
+	}
+	for i := range packSizes {
+		packSizes[i].ID = 0
+		packSizes[i].InventoryID = inventoryID
+	}
+	return tx.Create(&packSizes).Error
+}
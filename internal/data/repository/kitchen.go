@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrKitchenItemNotBumpable dikembalikan jika item/tiket dapur sudah ready atau dibatalkan
+var ErrKitchenItemNotBumpable = errors.New("item dapur sudah selesai atau dibatalkan")
+
+// ActiveKitchenStatuses adalah status tiket yang masih tampil di layar dapur
+var ActiveKitchenStatuses = []string{entity.KitchenStatusQueued, entity.KitchenStatusCooking}
+
+type KitchenRepository interface {
+	FindAllStations(ctx context.Context) ([]entity.KitchenStation, error)
+	FindStationByID(ctx context.Context, id uint) (*entity.KitchenStation, error)
+	CreateStation(ctx context.Context, station *entity.KitchenStation, categoryIDs []uint) error
+	UpdateStation(ctx context.Context, station *entity.KitchenStation, categoryIDs []uint) error
+	DeleteStation(ctx context.Context, id uint) error
+	FindTickets(ctx context.Context, stationID uint, statuses []string) ([]entity.KitchenTicket, error)
+	FindTicketsByOrder(ctx context.Context, orderID uint) ([]entity.KitchenTicket, error)
+	FindTicketByID(ctx context.Context, id uint) (*entity.KitchenTicket, error)
+	BumpItem(ctx context.Context, itemID uint) (*entity.KitchenTicket, error)
+	ReadyTicket(ctx context.Context, ticketID uint) (*entity.KitchenTicket, error)
+}
+
+type kitchenRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+	orders *orderRepository // Dipakai untuk perubahan status order otomatis
+}
+
+func NewKitchenRepository(db *gorm.DB, logger *zap.Logger) KitchenRepository {
+	return &kitchenRepository{db: db, logger: logger, orders: &orderRepository{db, logger}}
+}
+
+func (r *kitchenRepository) FindAllStations(ctx context.Context) ([]entity.KitchenStation, error) {
+	var stations []entity.KitchenStation
+	if err := r.db.WithContext(ctx).Preload("Categories").Order("id ASC").Find(&stations).Error; err != nil {
+		r.logger.Error("Failed to find kitchen stations", zap.Error(err))
+		return nil, err
+	}
+	return stations, nil
+}
+
+func (r *kitchenRepository) FindStationByID(ctx context.Context, id uint) (*entity.KitchenStation, error) {
+	var station entity.KitchenStation
+	if err := r.db.WithContext(ctx).Preload("Categories").First(&station, id).Error; err != nil {
+		return nil, err
+	}
+	return &station, nil
+}
+
+// CreateStation menyimpan station baru beserta kategori yang diarahkan ke station tersebut
+func (r *kitchenRepository) CreateStation(ctx context.Context, station *entity.KitchenStation, categoryIDs []uint) error {
+	r.logger.Info("Creating kitchen station", zap.String("name", station.Name))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(station).Error; err != nil {
+			return err
+		}
+		return r.saveStationRouting(tx, station, categoryIDs)
+	})
+	if err != nil {
+		r.logger.Error("Failed to create kitchen station", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateStation memperbarui station dan mengganti kategori yang diarahkan ke station tersebut
+func (r *kitchenRepository) UpdateStation(ctx context.Context, station *entity.KitchenStation, categoryIDs []uint) error {
+	r.logger.Info("Updating kitchen station", zap.Uint("id", station.ID))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(station).Select("name", "is_default", "updated_at").Updates(station).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("station_id = ?", station.ID).Delete(&entity.KitchenStationCategory{}).Error; err != nil {
+			return err
+		}
+		return r.saveStationRouting(tx, station, categoryIDs)
+	})
+	if err != nil {
+		r.logger.Error("Failed to update kitchen station", zap.Uint("id", station.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeleteStation menghapus station dan pemetaan kategorinya; tiket lama tetap menyimpan station_id
+func (r *kitchenRepository) DeleteStation(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting kitchen station", zap.Uint("id", id))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("station_id = ?", id).Delete(&entity.KitchenStationCategory{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.KitchenStation{}, id).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to delete kitchen station", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// saveStationRouting memindahkan kategori ke station (satu kategori hanya milik satu station)
+// dan memastikan hanya ada satu station default
+func (r *kitchenRepository) saveStationRouting(tx *gorm.DB, station *entity.KitchenStation, categoryIDs []uint) error {
+	if station.IsDefault {
+		if err := tx.Model(&entity.KitchenStation{}).
+			Where("id <> ? AND is_default = ?", station.ID, true).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+	}
+
+	station.Categories = nil
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	if err := tx.Where("category_id IN ?", categoryIDs).Delete(&entity.KitchenStationCategory{}).Error; err != nil {
+		return err
+	}
+	for _, categoryID := range categoryIDs {
+		station.Categories = append(station.Categories, entity.KitchenStationCategory{StationID: station.ID, CategoryID: categoryID})
+	}
+	return tx.Create(&station.Categories).Error
+}
+
+// FindTickets mengambil tiket dapur, urut dari yang terlama. stationID 0 berarti semua station.
+func (r *kitchenRepository) FindTickets(ctx context.Context, stationID uint, statuses []string) ([]entity.KitchenTicket, error) {
+	query := r.ticketQuery(ctx).Where("kitchen_tickets.status IN ?", statuses)
+	if stationID != 0 {
+		query = query.Where("kitchen_tickets.station_id = ?", stationID)
+	}
+
+	var tickets []entity.KitchenTicket
+	if err := query.Order("kitchen_tickets.created_at ASC, kitchen_tickets.id ASC").Find(&tickets).Error; err != nil {
+		r.logger.Error("Failed to find kitchen tickets", zap.Uint("station_id", stationID), zap.Error(err))
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// FindTicketsByOrder mengambil semua tiket dapur sebuah order
+func (r *kitchenRepository) FindTicketsByOrder(ctx context.Context, orderID uint) ([]entity.KitchenTicket, error) {
+	var tickets []entity.KitchenTicket
+	if err := r.ticketQuery(ctx).Where("kitchen_tickets.order_id = ?", orderID).Order("kitchen_tickets.id ASC").Find(&tickets).Error; err != nil {
+		r.logger.Error("Failed to find order kitchen tickets", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *kitchenRepository) FindTicketByID(ctx context.Context, id uint) (*entity.KitchenTicket, error) {
+	var ticket entity.KitchenTicket
+	if err := r.ticketQuery(ctx).First(&ticket, id).Error; err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// BumpItem memajukan status item dapur satu langkah (queued -> cooking -> ready)
+func (r *kitchenRepository) BumpItem(ctx context.Context, itemID uint) (*entity.KitchenTicket, error) {
+	r.logger.Info("Bumping kitchen item", zap.Uint("item_id", itemID))
+
+	var ticketID uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item entity.KitchenTicketItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, itemID).Error; err != nil {
+			return err
+		}
+		ticketID = item.TicketID
+
+		next := entity.NextKitchenStatus(item.Status)
+		if next == "" {
+			return fmt.Errorf("%w: item %d berstatus %s", ErrKitchenItemNotBumpable, item.ID, item.Status)
+		}
+		if err := bumpKitchenItems(tx, []entity.KitchenTicketItem{item}, next); err != nil {
+			return err
+		}
+		return r.advanceOrder(tx, ticketID)
+	})
+	if err != nil {
+		r.logger.Error("Failed to bump kitchen item", zap.Uint("item_id", itemID), zap.Error(err))
+		return nil, err
+	}
+
+	return r.FindTicketByID(ctx, ticketID)
+}
+
+// ReadyTicket menandai semua item tiket yang belum selesai menjadi ready
+func (r *kitchenRepository) ReadyTicket(ctx context.Context, ticketID uint) (*entity.KitchenTicket, error) {
+	r.logger.Info("Marking kitchen ticket ready", zap.Uint("ticket_id", ticketID))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ticket entity.KitchenTicket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticket, ticketID).Error; err != nil {
+			return err
+		}
+
+		var items []entity.KitchenTicketItem
+		if err := tx.Where("ticket_id = ? AND status IN ?", ticketID, ActiveKitchenStatuses).Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("%w: tiket %d berstatus %s", ErrKitchenItemNotBumpable, ticketID, ticket.Status)
+		}
+		if err := bumpKitchenItems(tx, items, entity.KitchenStatusReady); err != nil {
+			return err
+		}
+		return r.advanceOrder(tx, ticketID)
+	})
+	if err != nil {
+		r.logger.Error("Failed to mark kitchen ticket ready", zap.Uint("ticket_id", ticketID), zap.Error(err))
+		return nil, err
+	}
+
+	return r.FindTicketByID(ctx, ticketID)
+}
+
+// advanceOrder menyesuaikan status order pemilik tiket dari progres dapur (lihat advanceFromKitchen)
+func (r *kitchenRepository) advanceOrder(tx *gorm.DB, ticketID uint) error {
+	var ticket entity.KitchenTicket
+	if err := tx.First(&ticket, ticketID).Error; err != nil {
+		return err
+	}
+	return r.orders.advanceFromKitchen(tx, ticket.OrderID)
+}
+
+// ticketQuery menyiapkan query tiket beserta item, station, dan data order untuk layar dapur
+func (r *kitchenRepository) ticketQuery(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Station", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Order", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Order.Table", func(db *gorm.DB) *gorm.DB { return db.Unscoped() })
+}
+
+// bumpKitchenItems mengubah status item dapur ke status tujuan lalu menghitung ulang status tiketnya
+func bumpKitchenItems(tx *gorm.DB, items []entity.KitchenTicketItem, status string) error {
+	now := time.Now()
+	touched := make(map[uint]bool)
+	for _, item := range items {
+		updates := map[string]interface{}{"status": status, "updated_at": now}
+		if item.StartedAt == nil {
+			updates["started_at"] = now
+		}
+		if status == entity.KitchenStatusReady {
+			updates["ready_at"] = now
+		}
+		if err := tx.Model(&entity.KitchenTicketItem{}).Where("id = ?", item.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		touched[item.TicketID] = true
+	}
+	return refreshKitchenTickets(tx, touched)
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// kitchenLine mengelompokkan item order per produk, varian, dan modifier/catatannya
+type kitchenLine struct {
+	productID uint
+	variantID uint
+	note      string
+}
+
+// itemKitchenLine mengembalikan kelompok tiket dapur untuk item order
+func itemKitchenLine(item entity.OrderItem) kitchenLine {
+	return kitchenLine{productID: item.ProductID, variantID: itemStockKey(item).variantID, note: itemKitchenNote(item)}
+}
+
+// queueKitchenItems menyesuaikan tiket dapur order dengan selisih quantity per produk (beserta
+// varian, modifier, dan catatannya) antara oldItems dan newItems. Tambahan quantity masuk antrian station sesuai kategori produk,
+// sedangkan pengurangan hanya membatalkan item yang masih queued (item yang sudah dimasak tetap).
+// Split item dan merge memindah tiket dapur ke order hasil (lihat moveKitchenItems), sedangkan
+// tiket order yang dibagi rata tetap milik order asal.
+func queueKitchenItems(tx *gorm.DB, orderID uint, oldItems, newItems []entity.OrderItem) error {
+	delta := make(map[kitchenLine]int)
+	variantNames := make(map[kitchenLine]string)
+	var lines []kitchenLine
+	var productIDs []uint
+	for _, items := range [][]entity.OrderItem{newItems, oldItems} {
+		for _, item := range items {
+			line := itemKitchenLine(item)
+			if _, ok := delta[line]; !ok {
+				lines = append(lines, line)
+				productIDs = append(productIDs, item.ProductID)
+				variantNames[line] = item.VariantName
+				delta[line] = 0
+			}
+		}
+	}
+	for _, item := range oldItems {
+		delta[itemKitchenLine(item)] -= item.Quantity
+	}
+	for _, item := range newItems {
+		delta[itemKitchenLine(item)] += item.Quantity
+	}
+
+	var products []entity.Product
+	if err := tx.Unscoped().Select("id", "product_name", "category_id").Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return err
+	}
+	productMap := make(map[uint]entity.Product, len(products))
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+
+	routes, defaultStationID, err := kitchenRoutes(tx)
+	if err != nil {
+		return err
+	}
+
+	touched := make(map[uint]bool)
+	tickets := make(map[uint]uint) // station ID (0 = tanpa station) -> ticket ID yang masih queued
+	for _, line := range lines {
+		d := delta[line]
+		switch {
+		case d > 0:
+			product := productMap[line.productID]
+			stationID := defaultStationID
+			if routed, ok := routes[product.CategoryID]; ok {
+				stationID = routed
+			}
+
+			ticketID, err := queuedTicket(tx, orderID, stationID, tickets)
+			if err != nil {
+				return err
+			}
+			item := entity.KitchenTicketItem{
+				TicketID:    ticketID,
+				ProductID:   line.productID,
+				VariantID:   line.variantID,
+				ProductName: itemDisplayName(product.ProductName, variantNames[line]),
+				Note:        line.note,
+				Quantity:    d,
+				Status:      entity.KitchenStatusQueued,
+			}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+			touched[ticketID] = true
+		case d < 0:
+			ticketIDs, err := cancelQueuedKitchenItems(tx, orderID, line, -d)
+			if err != nil {
+				return err
+			}
+			for _, id := range ticketIDs {
+				touched[id] = true
+			}
+		}
+	}
+
+	return refreshKitchenTickets(tx, touched)
+}
+
+// cancelKitchenItems membatalkan semua item dapur order yang belum ready (order dibatalkan/void)
+func cancelKitchenItems(tx *gorm.DB, orderID uint) error {
+	var ticketIDs []uint
+	if err := tx.Model(&entity.KitchenTicket{}).Where("order_id = ?", orderID).Pluck("id", &ticketIDs).Error; err != nil {
+		return err
+	}
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&entity.KitchenTicketItem{}).
+		Where("ticket_id IN ? AND status IN ?", ticketIDs, []string{entity.KitchenStatusQueued, entity.KitchenStatusCooking}).
+		Updates(map[string]interface{}{"status": entity.KitchenStatusCancelled, "updated_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	touched := make(map[uint]bool, len(ticketIDs))
+	for _, id := range ticketIDs {
+		touched[id] = true
+	}
+	return refreshKitchenTickets(tx, touched)
+}
+
+// moveKitchenItems memindah item dapur sebanyak quantity item order yang dipindah (split item)
+// dari order fromOrderID ke order toOrderID. Setiap tiket asal mendapat pasangan tiket baru dengan
+// station yang sama di order tujuan; item dapur yang hanya dipindah sebagian dipecah menjadi dua.
+// Tiket asal yang tidak punya item lagi dihapus.
+func moveKitchenItems(tx *gorm.DB, fromOrderID, toOrderID uint, items []entity.OrderItem) error {
+	remaining := make(map[kitchenLine]int)
+	for _, item := range items {
+		remaining[itemKitchenLine(item)] += item.Quantity
+	}
+
+	var tickets []entity.KitchenTicket
+	if err := tx.Where("order_id = ?", fromOrderID).Find(&tickets).Error; err != nil {
+		return err
+	}
+	if len(tickets) == 0 {
+		return nil
+	}
+	ticketMap := make(map[uint]entity.KitchenTicket, len(tickets))
+	ticketIDs := make([]uint, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticketMap[ticket.ID] = ticket
+		ticketIDs = append(ticketIDs, ticket.ID)
+	}
+
+	var kitchenItems []entity.KitchenTicketItem
+	if err := tx.Where("ticket_id IN ? AND status <> ?", ticketIDs, entity.KitchenStatusCancelled).
+		Order("id ASC").
+		Find(&kitchenItems).Error; err != nil {
+		return err
+	}
+
+	touched := make(map[uint]bool)
+	targets := make(map[uint]uint) // ticket ID asal -> ticket ID di order tujuan
+	for _, item := range kitchenItems {
+		line := kitchenLine{productID: item.ProductID, variantID: item.VariantID, note: item.Note}
+		quantity := remaining[line]
+		if quantity <= 0 {
+			continue
+		}
+		if quantity > item.Quantity {
+			quantity = item.Quantity
+		}
+		remaining[line] -= quantity
+
+		targetID, ok := targets[item.TicketID]
+		if !ok {
+			target := entity.KitchenTicket{
+				OrderID:   toOrderID,
+				StationID: ticketMap[item.TicketID].StationID,
+				Status:    entity.KitchenStatusQueued,
+			}
+			if err := tx.Omit(clause.Associations).Create(&target).Error; err != nil {
+				return err
+			}
+			targetID = target.ID
+			targets[item.TicketID] = targetID
+		}
+
+		if quantity == item.Quantity {
+			if err := tx.Model(&entity.KitchenTicketItem{}).Where("id = ?", item.ID).Update("ticket_id", targetID).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&entity.KitchenTicketItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+				"quantity":   item.Quantity - quantity,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+				return err
+			}
+			moved := item
+			moved.ID = 0
+			moved.TicketID = targetID
+			moved.Quantity = quantity
+			if err := tx.Create(&moved).Error; err != nil {
+				return err
+			}
+		}
+		touched[item.TicketID] = true
+		touched[targetID] = true
+	}
+	if len(touched) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("id IN ? AND NOT EXISTS (SELECT 1 FROM kitchen_ticket_items kti WHERE kti.ticket_id = kitchen_tickets.id)", ticketIDs).
+		Delete(&entity.KitchenTicket{}).Error; err != nil {
+		return err
+	}
+	return refreshKitchenTickets(tx, touched)
+}
+
+// advanceFromKitchen menyesuaikan status order dari progres tiket dapurnya: order pending menjadi
+// in_kitchen saat ada item yang mulai dimasak, dan menjadi served saat semua item di semua tiketnya
+// ready. Tiket order yang dibagi rata tetap milik order asal, sehingga progresnya diteruskan ke setiap
+// bagiannya. Order yang sudah dibayar, dibatalkan, atau digabung tidak diubah.
+func (r *orderRepository) advanceFromKitchen(tx *gorm.DB, orderID uint) error {
+	var statuses []string
+	if err := tx.Model(&entity.KitchenTicket{}).Where("order_id = ?", orderID).Pluck("status", &statuses).Error; err != nil {
+		return err
+	}
+	started, allReady := false, true
+	for _, status := range statuses {
+		switch status {
+		case entity.KitchenStatusReady:
+			started = true
+		case entity.KitchenStatusCooking:
+			started = true
+			allReady = false
+		case entity.KitchenStatusCancelled:
+		default:
+			allReady = false
+		}
+	}
+	if !started {
+		return nil
+	}
+
+	var owner entity.Order
+	if err := tx.Select("id", "status").First(&owner, orderID).Error; err != nil {
+		return err
+	}
+	targetIDs := []uint{orderID}
+	if owner.Status == entity.OrderStatusSplit {
+		targetIDs = nil
+		if err := tx.Model(&entity.OrderLink{}).
+			Where("source_order_id = ? AND link_type = ?", orderID, entity.OrderLinkSplitEven).
+			Order("result_order_id").
+			Pluck("result_order_id", &targetIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, id := range targetIDs {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != entity.OrderStatusPending && order.Status != entity.OrderStatusInKitchen {
+			continue
+		}
+		if order.Status == entity.OrderStatusPending {
+			if err := r.changeStatus(tx, &order, entity.OrderStatusInKitchen, 0, "Otomatis: item mulai dimasak di dapur"); err != nil {
+				return err
+			}
+		}
+		if allReady {
+			if err := r.changeStatus(tx, &order, entity.OrderStatusServed, 0, "Otomatis: semua tiket dapur ready"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// kitchenRoutes memetakan category_id ke station_id, beserta station default (0 jika tidak ada)
+func kitchenRoutes(tx *gorm.DB) (map[uint]uint, uint, error) {
+	var mappings []entity.KitchenStationCategory
+	err := tx.Model(&entity.KitchenStationCategory{}).
+		Joins("JOIN kitchen_stations ks ON ks.id = kitchen_station_categories.station_id AND ks.deleted_at IS NULL").
+		Find(&mappings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	routes := make(map[uint]uint, len(mappings))
+	for _, m := range mappings {
+		routes[m.CategoryID] = m.StationID
+	}
+
+	var defaultStation entity.KitchenStation
+	result := tx.Where("is_default = ?", true).Order("id ASC").Limit(1).Find(&defaultStation)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return routes, defaultStation.ID, nil
+}
+
+// queuedTicket mengambil tiket order untuk station yang masih queued, atau membuat tiket baru
+func queuedTicket(tx *gorm.DB, orderID, stationID uint, cache map[uint]uint) (uint, error) {
+	if id, ok := cache[stationID]; ok {
+		return id, nil
+	}
+
+	query := tx.Where("order_id = ? AND status = ?", orderID, entity.KitchenStatusQueued)
+	if stationID == 0 {
+		query = query.Where("station_id IS NULL")
+	} else {
+		query = query.Where("station_id = ?", stationID)
+	}
+	var ticket entity.KitchenTicket
+	if err := query.Order("id DESC").Limit(1).Find(&ticket).Error; err != nil {
+		return 0, err
+	}
+
+	if ticket.ID == 0 {
+		ticket = entity.KitchenTicket{OrderID: orderID, Status: entity.KitchenStatusQueued}
+		if stationID != 0 {
+			ticket.StationID = &stationID
+		}
+		if err := tx.Omit(clause.Associations).Create(&ticket).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	cache[stationID] = ticket.ID
+	return ticket.ID, nil
+}
+
+// cancelQueuedKitchenItems mengurangi quantity item queued sebuah produk dengan varian dan modifier/catatan
+// yang sama, dimulai dari item terbaru. Mengembalikan ID tiket yang berubah.
+func cancelQueuedKitchenItems(tx *gorm.DB, orderID uint, line kitchenLine, quantity int) ([]uint, error) {
+	var items []entity.KitchenTicketItem
+	err := tx.Joins("JOIN kitchen_tickets kt ON kt.id = kitchen_ticket_items.ticket_id").
+		Where("kt.order_id = ? AND kitchen_ticket_items.product_id = ? AND kitchen_ticket_items.variant_id = ? AND kitchen_ticket_items.note = ? AND kitchen_ticket_items.status = ?",
+			orderID, line.productID, line.variantID, line.note, entity.KitchenStatusQueued).
+		Order("kitchen_ticket_items.id DESC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var ticketIDs []uint
+	for _, item := range items {
+		if quantity <= 0 {
+			break
+		}
+		updates := map[string]interface{}{"updated_at": time.Now()}
+		if item.Quantity > quantity {
+			updates["quantity"] = item.Quantity - quantity
+			quantity = 0
+		} else {
+			updates["status"] = entity.KitchenStatusCancelled
+			quantity -= item.Quantity
+		}
+		if err := tx.Model(&entity.KitchenTicketItem{}).Where("id = ?", item.ID).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+		ticketIDs = append(ticketIDs, item.TicketID)
+	}
+	return ticketIDs, nil
+}
+
+// refreshKitchenTickets menghitung ulang status tiket dari status itemnya:
+// cancelled jika semua item batal, ready jika semua item aktif ready,
+// cooking jika ada item yang sudah mulai dimasak, selain itu queued.
+func refreshKitchenTickets(tx *gorm.DB, ticketIDs map[uint]bool) error {
+	for ticketID := range ticketIDs {
+		var items []entity.KitchenTicketItem
+		if err := tx.Where("ticket_id = ?", ticketID).Find(&items).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		status := kitchenTicketStatus(items)
+		updates := map[string]interface{}{"status": status, "updated_at": now}
+		if status == entity.KitchenStatusReady {
+			updates["ready_at"] = gorm.Expr("COALESCE(ready_at, ?)", now)
+		} else {
+			updates["ready_at"] = nil
+		}
+		if err := tx.Model(&entity.KitchenTicket{}).Where("id = ?", ticketID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kitchenTicketStatus menurunkan status tiket dari status item-itemnya
+func kitchenTicketStatus(items []entity.KitchenTicketItem) string {
+	var active, ready, started int
+	for _, item := range items {
+		switch item.Status {
+		case entity.KitchenStatusCancelled:
+			continue
+		case entity.KitchenStatusReady:
+			ready++
+			started++
+		case entity.KitchenStatusCooking:
+			started++
+		}
+		active++
+	}
+
+	switch {
+	case active == 0:
+		return entity.KitchenStatusCancelled
+	case ready == active:
+		return entity.KitchenStatusReady
+	case started > 0:
+		return entity.KitchenStatusCooking
+	default:
+		return entity.KitchenStatusQueued
+	}
+}
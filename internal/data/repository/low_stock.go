@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+// ClaimLowStock menandai inventory aktif dengan quantity di bawah MinStock yang belum dikirim
+// notifikasinya lalu mengembalikannya, dalam satu UPDATE ... RETURNING. Pemanggil yang berjalan
+// bersamaan tidak akan mendapat inventory yang sama.
+func (r *inventoriesRepository) ClaimLowStock(ctx context.Context) ([]entity.Inventories, error) {
+	var inventories []entity.Inventories
+	err := r.db.WithContext(ctx).Model(&inventories).
+		Clauses(clause.Returning{}).
+		Where("status = ? AND quantity < min_stock AND low_stock_alerted_at IS NULL", "active").
+		UpdateColumn("low_stock_alerted_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to claim low stock inventories", zap.Error(err))
+		return nil, err
+	}
+	sort.Slice(inventories, func(i, j int) bool { return inventories[i].ID < inventories[j].ID })
+	return inventories, nil
+}
+
+// ClearRestockedAlerts mengosongkan tanda notifikasi inventory yang quantity-nya sudah kembali
+// mencapai MinStock, sehingga notifikasi dikirim lagi jika stoknya menipis kembali
+func (r *inventoriesRepository) ClearRestockedAlerts(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entity.Inventories{}).
+		Where("low_stock_alerted_at IS NOT NULL AND quantity >= min_stock").
+		UpdateColumn("low_stock_alerted_at", nil)
+	if result.Error != nil {
+		r.logger.Error("Failed to clear restocked inventory alerts", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ClaimLowStock menandai produk single dengan stok di bawah MinStock yang belum dikirim notifikasinya
+// lalu mengembalikannya, seperti ClaimLowStock inventory. Bundle dilewati karena stoknya dihitung dari
+// komponen.
+func (r *productRepository) ClaimLowStock(ctx context.Context) ([]entity.Product, error) {
+	var products []entity.Product
+	err := r.db.WithContext(ctx).Model(&products).
+		Clauses(clause.Returning{}).
+		Where("type = ? AND stock < min_stock AND low_stock_alerted_at IS NULL", entity.ProductTypeSingle).
+		UpdateColumn("low_stock_alerted_at", time.Now()).Error
+	if err != nil {
+		r.logger.Error("Failed to claim low stock products", zap.Error(err))
+		return nil, err
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products, nil
+}
+
+// ClearRestockedAlerts mengosongkan tanda notifikasi produk yang stoknya sudah kembali mencapai MinStock
+func (r *productRepository) ClearRestockedAlerts(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entity.Product{}).
+		Where("low_stock_alerted_at IS NOT NULL AND stock >= min_stock").
+		UpdateColumn("low_stock_alerted_at", nil)
+	if result.Error != nil {
+		r.logger.Error("Failed to clear restocked product alerts", zap.Error(result.Error))
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModifierRepository interface {
+	FindAll(ctx context.Context) ([]entity.ModifierGroup, error)
+	FindByID(ctx context.Context, id uint) (*entity.ModifierGroup, error)
+	FindForProduct(ctx context.Context, productID uint) ([]entity.ModifierGroup, error)
+	Create(ctx context.Context, group *entity.ModifierGroup) error
+	Update(ctx context.Context, group *entity.ModifierGroup) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type modifierRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewModifierRepository(db *gorm.DB, logger *zap.Logger) ModifierRepository {
+	return &modifierRepository{db, logger}
+}
+
+func (r *modifierRepository) FindAll(ctx context.Context) ([]entity.ModifierGroup, error) {
+	var groups []entity.ModifierGroup
+	if err := r.preloadGroup(r.db.WithContext(ctx)).Order("id ASC").Find(&groups).Error; err != nil {
+		r.logger.Error("Failed to find modifier groups", zap.Error(err))
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (r *modifierRepository) FindByID(ctx context.Context, id uint) (*entity.ModifierGroup, error) {
+	var group entity.ModifierGroup
+	if err := r.preloadGroup(r.db.WithContext(ctx)).First(&group, id).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// FindForProduct mengambil modifier group yang berlaku untuk produk (milik produk dan kategorinya)
+func (r *modifierRepository) FindForProduct(ctx context.Context, productID uint) ([]entity.ModifierGroup, error) {
+	var product entity.Product
+	if err := r.db.WithContext(ctx).First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+
+	catalog, err := loadModifierCatalog(r.db.WithContext(ctx), []entity.Product{product})
+	if err != nil {
+		r.logger.Error("Failed to find modifier groups for product", zap.Uint("product_id", productID), zap.Error(err))
+		return nil, err
+	}
+
+	groups := make([]entity.ModifierGroup, 0, len(catalog.productGroups[productID]))
+	for _, groupID := range catalog.productGroups[productID] {
+		groups = append(groups, catalog.groups[groupID])
+	}
+	return groups, nil
+}
+
+// Create menyimpan modifier group beserta opsi dan produk/kategori tempat group dipasang
+func (r *modifierRepository) Create(ctx context.Context, group *entity.ModifierGroup) error {
+	r.logger.Info("Creating modifier group", zap.String("name", group.Name))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(group).Error; err != nil {
+			return err
+		}
+		if err := r.saveOptions(tx, group); err != nil {
+			return err
+		}
+		return r.saveLinks(tx, group)
+	})
+	if err != nil {
+		r.logger.Error("Failed to create modifier group", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Update memperbarui modifier group. Opsi dengan ID diperbarui, opsi tanpa ID ditambahkan,
+// dan opsi lama yang tidak dikirim dihapus (soft delete, riwayat order tetap menyimpan namanya).
+// Produk/kategori tempat group dipasang diganti seluruhnya.
+func (r *modifierRepository) Update(ctx context.Context, group *entity.ModifierGroup) error {
+	r.logger.Info("Updating modifier group", zap.Uint("id", group.ID))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(group).Select("name", "min_select", "max_select", "updated_at").Updates(group).Error; err != nil {
+			return err
+		}
+		if err := r.saveOptions(tx, group); err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", group.ID).Delete(&entity.ProductModifierGroup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", group.ID).Delete(&entity.CategoryModifierGroup{}).Error; err != nil {
+			return err
+		}
+		return r.saveLinks(tx, group)
+	})
+	if err != nil {
+		r.logger.Error("Failed to update modifier group", zap.Uint("id", group.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Delete menghapus modifier group beserta opsinya dan melepasnya dari produk/kategori
+func (r *modifierRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting modifier group", zap.Uint("id", id))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&entity.ProductModifierGroup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&entity.CategoryModifierGroup{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&entity.ModifierOption{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.ModifierGroup{}, id).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to delete modifier group", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// saveOptions menyimpan opsi group dan menghapus opsi lama yang tidak ada lagi di group.Options
+func (r *modifierRepository) saveOptions(tx *gorm.DB, group *entity.ModifierGroup) error {
+	keep := make([]uint, 0, len(group.Options))
+	for i := range group.Options {
+		option := &group.Options[i]
+		option.GroupID = group.ID
+		if option.ID == 0 {
+			if err := tx.Create(option).Error; err != nil {
+				return err
+			}
+		} else {
+			result := tx.Model(&entity.ModifierOption{}).
+				Where("id = ? AND group_id = ?", option.ID, group.ID).
+				Updates(map[string]interface{}{
+					"name":         option.Name,
+					"price_delta":  option.PriceDelta,
+					"is_available": option.IsAvailable,
+					"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		keep = append(keep, option.ID)
+	}
+
+	query := tx.Where("group_id = ?", group.ID)
+	if len(keep) > 0 {
+		query = query.Where("id NOT IN ?", keep)
+	}
+	return query.Delete(&entity.ModifierOption{}).Error
+}
+
+// saveLinks menyimpan produk dan kategori tempat group dipasang
+func (r *modifierRepository) saveLinks(tx *gorm.DB, group *entity.ModifierGroup) error {
+	for i := range group.Products {
+		group.Products[i].ID = 0
+		group.Products[i].GroupID = group.ID
+	}
+	for i := range group.Categories {
+		group.Categories[i].ID = 0
+		group.Categories[i].GroupID = group.ID
+	}
+	if len(group.Products) > 0 {
+		if err := tx.Create(&group.Products).Error; err != nil {
+			return err
+		}
+	}
+	if len(group.Categories) > 0 {
+		if err := tx.Create(&group.Categories).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *modifierRepository) preloadGroup(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Products").
+		Preload("Categories")
+}
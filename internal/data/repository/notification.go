@@ -18,6 +18,7 @@ type NotificationRepository interface {
 	UpdateNotificationStatus(ctx context.Context, id uint, status string) error
 	DeleteNotification(ctx context.Context, id uint) error
 	GetUnreadCount(ctx context.Context, userID uint) (int64, error)
+	GetNotificationsAfterID(ctx context.Context, userID uint, afterID uint, limit int) ([]entity.Notification, error)
 	DeleteOldNotifications(ctx context.Context, days int) error
 }
 
@@ -216,6 +217,22 @@ func (r *notificationRepository) GetUnreadCount(ctx context.Context, userID uint
 	return count, nil
 }
 
+// GetNotificationsAfterID mengambil notifikasi user dengan ID lebih besar dari afterID, urut ID naik
+func (r *notificationRepository) GetNotificationsAfterID(ctx context.Context, userID uint, afterID uint, limit int) ([]entity.Notification, error) {
+	var notifications []entity.Notification
+
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND id > ?", userID, afterID).Order("id ASC").Limit(limit).Find(&notifications).Error; err != nil {
+		r.logger.Error("Failed to get notifications after ID",
+			zap.Uint("user_id", userID),
+			zap.Uint("after_id", afterID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
 // DeleteOldNotifications menghapus notifikasi yang sudah lama (>days)
 func (r *notificationRepository) DeleteOldNotifications(ctx context.Context, days int) error {
 	cutoffDate := time.Now().AddDate(0, 0, -days)
@@ -2,12 +2,34 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/dto"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrProductNotFound dikembalikan jika produk pada item order tidak ada atau sudah dihapus
+	ErrProductNotFound = errors.New("produk tidak ditemukan")
+	// ErrProductUnavailable dikembalikan jika produk pada item order tidak tersedia (is_available=false)
+	ErrProductUnavailable = errors.New("produk tidak tersedia")
+	// ErrVariantNotFound dikembalikan jika varian pada item order bukan milik produk atau sudah dihapus
+	ErrVariantNotFound = errors.New("varian produk tidak ditemukan")
+	// ErrVariantRequired dikembalikan jika produk yang punya varian dipesan tanpa variant_id
+	ErrVariantRequired = errors.New("varian produk wajib dipilih")
+	// ErrPriceOverrideNotApproved dikembalikan jika harga item berbeda dari katalog tanpa persetujuan manager
+	ErrPriceOverrideNotApproved = errors.New("override harga membutuhkan persetujuan manager")
+	// ErrOrderNotEditable dikembalikan jika order sudah tidak bisa diubah (sudah dibayar/dibatalkan)
+	ErrOrderNotEditable = errors.New("order tidak dapat diubah")
+	// ErrOrderStatusConflict dikembalikan jika status order sudah berubah oleh proses lain
+	ErrOrderStatusConflict = errors.New("status order sudah berubah, silakan muat ulang")
 )
 
 type OrderRepository interface {
@@ -16,6 +38,18 @@ type OrderRepository interface {
 	Create(ctx context.Context, req dto.OrderCreateRequest) (*entity.Order, error)
 	Update(ctx context.Context, id uint, req dto.OrderUpdateRequest) error
 	Delete(ctx context.Context, id uint) error
+	UpdateStatus(ctx context.Context, id uint, fromStatus, toStatus string, changedBy uint, note string) error
+	AddPayments(ctx context.Context, id uint, fromStatus string, tenders []dto.OrderPaymentTenderRequest, receivedBy uint) ([]entity.OrderPayment, error)
+	FindPayments(ctx context.Context, orderID uint) ([]entity.OrderPayment, error)
+	SplitItems(ctx context.Context, id uint, req dto.OrderSplitItemsRequest) (*entity.Order, error)
+	SplitEven(ctx context.Context, id uint, parts int, createdBy uint) ([]entity.Order, error)
+	Merge(ctx context.Context, targetID uint, sourceIDs []uint, createdBy uint) (*entity.Order, error)
+	FindLinks(ctx context.Context, orderID uint) ([]entity.OrderLink, error)
+	Void(ctx context.Context, id uint, fromStatus string, req dto.OrderVoidRequest) (*entity.OrderRefund, error)
+	Refund(ctx context.Context, id uint, req dto.OrderRefundRequest) (*entity.OrderRefund, error)
+	FindRefunds(ctx context.Context, orderID uint) ([]entity.OrderRefund, error)
+	FindProductNames(ctx context.Context, productIDs []uint) (map[uint]string, error)
+	FindStatusHistory(ctx context.Context, orderID uint) ([]entity.OrderStatusHistory, error)
 	FindAllTables(ctx context.Context) ([]entity.Table, error)
 	FindAllPaymentMethods(ctx context.Context) ([]entity.PaymentMethod, error)
 	FindAvailableChairs(ctx context.Context) ([]entity.Table, error)
@@ -34,7 +68,7 @@ func (r *orderRepository) FindAll(ctx context.Context) ([]entity.Order, error) {
 	r.logger.Info("Finding all orders")
 
 	var orders []entity.Order
-	err := r.db.WithContext(ctx).Preload("Items").Preload("Table").Preload("PaymentMethod").Find(&orders).Error
+	err := r.db.WithContext(ctx).Preload("Items.Modifiers").Preload("Items.Components").Preload("Taxes").Preload("Discounts").Preload("Payments.PaymentMethod").Preload("Table").Preload("PaymentMethod").Find(&orders).Error
 	if err != nil {
 		r.logger.Error("Failed to find all orders", zap.Error(err))
 		return nil, err
@@ -48,7 +82,7 @@ func (r *orderRepository) FindByID(ctx context.Context, id uint) (*entity.Order,
 	r.logger.Info("Finding order by ID", zap.Uint("id", id))
 
 	var order entity.Order
-	err := r.db.WithContext(ctx).Preload("Items").Preload("Table").Preload("PaymentMethod").First(&order, id).Error
+	err := r.db.WithContext(ctx).Preload("Items.Modifiers").Preload("Items.Components").Preload("Taxes").Preload("Discounts").Preload("Payments.PaymentMethod").Preload("Table").Preload("PaymentMethod").First(&order, id).Error
 	if err != nil {
 		r.logger.Error("Failed to find order by ID", zap.Uint("id", id), zap.Error(err))
 		return nil, err
@@ -63,37 +97,74 @@ func (r *orderRepository) Create(ctx context.Context, req dto.OrderCreateRequest
 		zap.String("customer_name", req.CustomerName),
 		zap.Uint("table_id", req.TableID))
 
-	// Calculate total amount from items
-	var totalAmount float64
-	var orderItems []entity.OrderItem
-	for _, item := range req.Items {
-		subtotal := item.Price * float64(item.Quantity)
-		totalAmount += subtotal
-		orderItems = append(orderItems, entity.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			Subtotal:  subtotal,
-		})
+	orderType := req.OrderType
+	if orderType == "" {
+		orderType = entity.OrderTypeDineIn
+	}
+	outletID := req.OutletID
+	if outletID == 0 {
+		outletID = entity.DefaultOutletID
 	}
-	totalAmount += req.Tax
 
 	order := entity.Order{
 		UserID:          req.UserID,
 		TableID:         req.TableID,
+		OrderType:       orderType,
+		OutletID:        outletID,
 		PaymentMethodID: req.PaymentMethodID,
 		CustomerName:    req.CustomerName,
-		TotalAmount:     totalAmount,
-		Tax:             req.Tax,
-		Status:          "pending",
-		Items:           orderItems,
+		PromoCode:       strings.ToUpper(strings.TrimSpace(req.PromoCode)),
+		Status:          entity.OrderStatusPending,
 	}
 
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Harga item selalu diambil dari katalog produk
+		orderItems, err := r.priceOrderItems(tx, 0, req.Items, req.PriceApprovedBy, nil)
+		if err != nil {
+			return err
+		}
+
+		// Kurangi stok produk dalam transaksi yang sama dengan insert order_items
+		if err := r.adjustStock(tx, nil, orderItems); err != nil {
+			return err
+		}
+
+		// Diskon promo dihitung sebelum pajak
+		discounts, err := r.applyPromotions(tx, orderItems, order.PromoCode, time.Now())
+		if err != nil {
+			return err
+		}
+		order.Items = orderItems
+		order.Discounts = discounts
+
+		// Pajak dan service charge dihitung dari aturan pajak outlet
+		totals, err := r.calculateOrderTotals(tx, order.OutletID, orderItems)
+		if err != nil {
+			return err
+		}
+		totals.apply(&order)
+		order.Taxes = totals.Taxes
+
+		// Order dine-in menempati meja
+		if order.OrderType == entity.OrderTypeDineIn {
+			if err := r.occupyTable(tx, order.TableID); err != nil {
+				return err
+			}
+		}
+
 		if err := tx.Create(&order).Error; err != nil {
 			return err
 		}
-		return nil
+
+		// Item order langsung masuk antrian dapur sesuai station
+		if err := queueKitchenItems(tx, order.ID, nil, order.Items); err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.OrderStatusHistory{
+			OrderID:  order.ID,
+			ToStatus: order.Status,
+		}).Error
 	})
 
 	if err != nil {
@@ -114,43 +185,42 @@ func (r *orderRepository) Update(ctx context.Context, id uint, req dto.OrderUpda
 		zap.Uint("id", id),
 		zap.String("customer_name", req.CustomerName))
 
-	// Calculate total amount from items
-	var totalAmount float64
-	var orderItems []entity.OrderItem
-	for _, item := range req.Items {
-		subtotal := item.Price * float64(item.Quantity)
-		totalAmount += subtotal
-		orderItems = append(orderItems, entity.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			Subtotal:  subtotal,
-		})
-	}
-
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Update order
-		if err := tx.Model(&entity.Order{}).Where("id = ?", id).Updates(map[string]interface{}{
-			"customer_name":     req.CustomerName,
-			"payment_method_id": req.PaymentMethodID,
-			"total_amount":      totalAmount,
-		}).Error; err != nil {
+		// Baris order dikunci agar edit tidak balapan dengan pembayaran, void, atau edit lain;
+		// status dan pembayaran dicek ulang di bawah kunci (total baru dicek di repriceOrder)
+		var existing entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Modifiers").Preload("Items.Components").First(&existing, id).Error; err != nil {
 			return err
 		}
+		if !existing.IsEditable() {
+			return fmt.Errorf("%w: status %s", ErrOrderNotEditable, existing.Status)
+		}
 
-		// Delete old items and insert new ones
-		if err := tx.Where("order_id = ?", id).Delete(&entity.OrderItem{}).Error; err != nil {
+		// Harga item selalu diambil dari katalog produk
+		orderItems, err := r.priceOrderItems(tx, existing.ID, req.Items, req.PriceApprovedBy, existing.Items)
+		if err != nil {
 			return err
 		}
 
-		for i := range orderItems {
-			orderItems[i].OrderID = id
+		// Terapkan hanya selisih quantity antara item lama dan item baru ke stok
+		if err := r.adjustStock(tx, existing.Items, orderItems); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Order{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"customer_name":     req.CustomerName,
+			"payment_method_id": req.PaymentMethodID,
+		}).Error; err != nil {
+			return err
 		}
-		if err := tx.Create(&orderItems).Error; err != nil {
+
+		// Selisih item dikirim ke dapur; item yang sudah dimasak tidak dibatalkan
+		if err := queueKitchenItems(tx, id, existing.Items, orderItems); err != nil {
 			return err
 		}
 
-		return nil
+		existing.PromoCode = strings.ToUpper(strings.TrimSpace(req.PromoCode))
+		return r.repriceOrder(tx, &existing, orderItems, time.Now())
 	})
 
 	if err != nil {
@@ -165,12 +235,257 @@ func (r *orderRepository) Update(ctx context.Context, id uint, req dto.OrderUpda
 	return nil
 }
 
+// repriceOrder mengganti item order dengan items, lalu mengevaluasi ulang promo (kuota promo lama
+// dikembalikan dulu), pajak, dan total order pada waktu at. Total baru tidak boleh lebih kecil
+// dari pembayaran yang sudah diterima.
+func (r *orderRepository) repriceOrder(tx *gorm.DB, order *entity.Order, items []entity.OrderItem, at time.Time) error {
+	if err := releasePromotionUsage(tx, order.ID, true); err != nil {
+		return err
+	}
+	discounts, err := r.applyPromotions(tx, items, order.PromoCode, at)
+	if err != nil {
+		return err
+	}
+
+	totals, err := r.calculateOrderTotals(tx, order.OutletID, items)
+	if err != nil {
+		return err
+	}
+	if totals.TotalAmount < order.PaidAmount {
+		return fmt.Errorf("%w: total baru %.2f lebih kecil dari pembayaran %.2f", ErrOrderNotEditable, totals.TotalAmount, order.PaidAmount)
+	}
+	totals.apply(order)
+
+	if err := tx.Model(&entity.Order{}).Where("id = ?", order.ID).Updates(map[string]interface{}{
+		"subtotal":           totals.Subtotal,
+		"discount_amount":    totals.DiscountAmount,
+		"promo_code":         order.PromoCode,
+		"service_charge":     totals.ServiceCharge,
+		"tax":                totals.Tax,
+		"prices_include_tax": totals.PricesIncludeTax,
+		"total_amount":       totals.TotalAmount,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := replaceOrderTaxes(tx, order.ID, totals.Taxes); err != nil {
+		return err
+	}
+	for i := range discounts {
+		discounts[i].OrderID = order.ID
+	}
+	if len(discounts) > 0 {
+		if err := tx.Create(&discounts).Error; err != nil {
+			return err
+		}
+	}
+
+	// Hapus item lama dan simpan item baru
+	if err := tx.Where("order_id = ?", order.ID).Delete(&entity.OrderItem{}).Error; err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].ID = 0
+		items[i].OrderID = order.ID
+		items[i].Modifiers = cloneItemModifiers(items[i].Modifiers)
+		items[i].Components = cloneItemComponents(items[i].Components)
+	}
+	order.Items = items
+	order.Taxes = totals.Taxes
+	order.Discounts = discounts
+	return tx.Create(&items).Error
+}
+
+// priceOrderItems membangun order item dengan harga dari katalog produk, atau dari harga varian
+// untuk produk yang punya varian (variant_id wajib diisi).
+// Produk/varian yang sudah dihapus atau tidak tersedia akan ditolak, kecuali sudah
+// ada di currentItems (stoknya sudah dipegang oleh order ini). Harga dari request hanya
+// dianggap override jika berbeda dari harga katalog ditambah price delta modifier yang dipilih (harga
+// satuan yang sama dengan Price di response), dan wajib disertai persetujuan manager.
+// Untuk produk bundle, komponen yang dipilih disimpan bersama item dan stoknya yang dikurangi.
+// Ketersediaan produk yang punya resep hanya dicek dari bahan inventory-nya: ditolak jika tidak cukup
+// untuk order ini ditambah order terbuka lain (orderID adalah order yang sedang diubah, 0 untuk
+// order baru).
+func (r *orderRepository) priceOrderItems(tx *gorm.DB, orderID uint, items []dto.OrderItemRequest, approvedBy uint, currentItems []entity.OrderItem) ([]entity.OrderItem, error) {
+	held := heldStockKeys(currentItems)
+
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	// Varian yang sudah dihapus ikut dimuat agar item lama di order tetap bisa disimpan ulang
+	var products []entity.Product
+	if err := tx.Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	productMap := make(map[uint]entity.Product, len(products))
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+
+	catalog, err := loadModifierCatalog(tx, products)
+	if err != nil {
+		return nil, err
+	}
+	heldOptions := heldModifierOptions(currentItems)
+	bundleSlots, err := loadBundleSlots(tx, products)
+	if err != nil {
+		return nil, err
+	}
+	// Ketersediaan produk beresep tidak diambil dari is_available (stok produk), tetapi dari
+	// bahan inventory-nya lewat checkIngredients di akhir
+	recipeProducts, err := recipeProductIDs(tx, append(productIDs, bundleComponentProductIDs(bundleSlots)...))
+	if err != nil {
+		return nil, err
+	}
+
+	orderItems := make([]entity.OrderItem, 0, len(items))
+	for _, item := range items {
+		product, ok := productMap[item.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("%w: product_id %d", ErrProductNotFound, item.ProductID)
+		}
+
+		orderItem := entity.OrderItem{
+			ProductID: product.ID,
+			Quantity:  item.Quantity,
+			Price:     product.Price,
+			ListPrice: product.Price,
+		}
+		available := product.IsAvailable || recipeProducts[product.ID]
+
+		variant, err := orderItemVariant(product, item.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		if variant != nil {
+			variantID := variant.ID
+			orderItem.VariantID = &variantID
+			orderItem.VariantName = variant.Name
+			orderItem.Price = variant.Price
+			orderItem.ListPrice = variant.Price
+			available = (variant.IsAvailable || recipeProducts[product.ID]) && !variant.DeletedAt.Valid
+		}
+		if !available && !held[itemStockKey(orderItem)] {
+			return nil, fmt.Errorf("%w: %s (product_id %d)", ErrProductUnavailable, itemDisplayName(product.ProductName, orderItem.VariantName), product.ID)
+		}
+
+		modifiers, modifierAmount, err := catalog.resolve(product, item.ModifierOptionIDs, heldOptions)
+		if err != nil {
+			return nil, err
+		}
+		components, err := resolveBundle(product, bundleSlots[product.ID], item.BundleChoices, held, recipeProducts)
+		if err != nil {
+			return nil, err
+		}
+		orderItem.Components = components
+		orderItem.Modifiers = modifiers
+		orderItem.ModifierAmount = modifierAmount
+		orderItem.Notes = strings.TrimSpace(item.Notes)
+		orderItem.Price += modifierAmount
+		orderItem.ListPrice += modifierAmount
+
+		// Price request dibandingkan dengan harga satuan termasuk modifier, sama seperti Price di response
+		if item.Price != nil && roundAmount(*item.Price) != roundAmount(orderItem.ListPrice) {
+			if approvedBy == 0 {
+				return nil, fmt.Errorf("%w: %s (product_id %d)", ErrPriceOverrideNotApproved, product.ProductName, product.ID)
+			}
+			approver := approvedBy
+			orderItem.Price = *item.Price
+			orderItem.IsPriceOverride = true
+			orderItem.PriceApprovedBy = &approver
+
+			r.logger.Warn("Order item price overridden",
+				zap.Uint("product_id", product.ID),
+				zap.Float64("list_price", orderItem.ListPrice),
+				zap.Float64("override_price", *item.Price),
+				zap.Uint("approved_by", approvedBy))
+		}
+
+		orderItem.Subtotal = orderItem.Price * float64(orderItem.Quantity)
+		orderItems = append(orderItems, orderItem)
+	}
+
+	if err := r.checkIngredients(tx, orderItems, held, orderID); err != nil {
+		return nil, err
+	}
+	return orderItems, nil
+}
+
+// orderItemVariant mencari varian yang dipesan. Produk dengan varian aktif wajib dipesan per varian,
+// sedangkan produk tanpa varian tidak boleh diisi variant_id.
+func orderItemVariant(product entity.Product, variantID *uint) (*entity.ProductVariant, error) {
+	hasActive := false
+	for _, v := range product.Variants {
+		if !v.DeletedAt.Valid {
+			hasActive = true
+			break
+		}
+	}
+
+	if variantID == nil {
+		if hasActive {
+			return nil, fmt.Errorf("%w: %s (product_id %d)", ErrVariantRequired, product.ProductName, product.ID)
+		}
+		return nil, nil
+	}
+	for i := range product.Variants {
+		if product.Variants[i].ID == *variantID {
+			return &product.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: variant_id %d bukan milik %s", ErrVariantNotFound, *variantID, product.ProductName)
+}
+
+// itemDisplayName menggabungkan nama produk dan nama varian, contoh "Latte - Iced Large"
+func itemDisplayName(productName, variantName string) string {
+	if variantName == "" {
+		return productName
+	}
+	return productName + " - " + variantName
+}
+
 func (r *orderRepository) Delete(ctx context.Context, id uint) error {
 	r.logger.Info("Deleting order",
 		zap.Uint("id", id))
 
-	// Soft delete
-	err := r.db.WithContext(ctx).Delete(&entity.Order{}, id).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Baris order dikunci dan dicek ulang: hanya order pending tanpa pembayaran yang boleh
+		// dihapus, sehingga pembayaran atau void yang berjalan bersamaan tidak mengembalikan stok dua kali
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != entity.OrderStatusPending || order.PaidAmount > 0 {
+			return fmt.Errorf("%w: status saat ini %s, dibayar %.2f", ErrOrderStatusConflict, order.Status, order.PaidAmount)
+		}
+
+		// Kembalikan stok dan kuota promo, batalkan item dapur
+		if err := r.adjustStock(tx, order.Items, nil); err != nil {
+			return err
+		}
+		if err := releasePromotionUsage(tx, order.ID, false); err != nil {
+			return err
+		}
+		if err := cancelKitchenItems(tx, order.ID); err != nil {
+			return err
+		}
+
+		// Soft delete
+		if err := tx.Where("order_id = ?", id).Delete(&entity.OrderItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entity.Order{}, id).Error; err != nil {
+			return err
+		}
+
+		if order.OrderType == entity.OrderTypeDineIn {
+			return r.releaseTableIfIdle(tx, order.TableID, order.ID)
+		}
+		return nil
+	})
 	if err != nil {
 		r.logger.Error("Failed to delete order",
 			zap.Uint("id", id),
@@ -183,6 +498,129 @@ func (r *orderRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
+// UpdateStatus mengubah status order dan mencatatnya di order_status_histories.
+// Baris order dikunci dan status saat ini harus sama dengan fromStatus, sehingga dua
+// transisi yang berjalan bersamaan tidak bisa saling menimpa. Pembatalan mengembalikan stok,
+// paid (hanya dari AddPayments setelah lunas) memakai bahan resep, dan order dine-in terakhir yang ditutup (paid/cancelled) melepas mejanya.
+func (r *orderRepository) UpdateStatus(ctx context.Context, id uint, fromStatus, toStatus string, changedBy uint, note string) error {
+	r.logger.Info("Updating order status",
+		zap.Uint("id", id),
+		zap.String("from_status", fromStatus),
+		zap.String("to_status", toStatus))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != fromStatus {
+			return fmt.Errorf("%w: status saat ini %s", ErrOrderStatusConflict, order.Status)
+		}
+
+		return r.changeStatus(tx, &order, toStatus, changedBy, note)
+	})
+	if err != nil {
+		r.logger.Error("Failed to update order status",
+			zap.Uint("id", id),
+			zap.String("to_status", toStatus),
+			zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Successfully updated order status",
+		zap.Uint("id", id),
+		zap.String("status", toStatus))
+	return nil
+}
+
+// changeStatus menjalankan efek samping perubahan status order yang sudah dikunci di dalam tx
+// (stok, kuota promo, pelunasan, bahan resep, meja) lalu mencatatnya di order_status_histories
+func (r *orderRepository) changeStatus(tx *gorm.DB, order *entity.Order, toStatus string, changedBy uint, note string) error {
+	fromStatus := order.Status
+
+	switch toStatus {
+	case entity.OrderStatusCancelled:
+		if err := r.adjustStock(tx, order.Items, nil); err != nil {
+			return err
+		}
+		// Order batal tidak menghabiskan kuota promo, catatan diskon tetap disimpan
+		if err := releasePromotionUsage(tx, order.ID, false); err != nil {
+			return err
+		}
+		if err := cancelKitchenItems(tx, order.ID); err != nil {
+			return err
+		}
+		if err := r.deductSplitSource(tx, order, toStatus, changedBy); err != nil {
+			return err
+		}
+	case entity.OrderStatusPaid:
+		// Order hanya menjadi paid lewat AddPayments setelah seluruh tagihan tertutup tender
+		if remaining := roundAmount(order.RemainingAmount()); remaining > 0 {
+			return fmt.Errorf("%w: sisa tagihan %.2f", ErrOrderStatusConflict, remaining)
+		}
+		// Bahan resep dipakai saat order lunas
+		if err := r.deductIngredients(tx, order, changedBy); err != nil {
+			return err
+		}
+		if err := r.deductSplitSource(tx, order, toStatus, changedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(&entity.Order{}).Where("id = ?", order.ID).Update("status", toStatus).Error; err != nil {
+		return err
+	}
+	order.Status = toStatus
+
+	if order.OrderType == entity.OrderTypeDineIn && isOpenOrderStatus(fromStatus) && !isOpenOrderStatus(toStatus) {
+		if err := r.releaseTableIfIdle(tx, order.TableID, order.ID); err != nil {
+			return err
+		}
+	}
+
+	history := entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Note:       note,
+	}
+	if changedBy != 0 {
+		history.ChangedBy = &changedBy
+	}
+	return tx.Create(&history).Error
+}
+
+// FindStatusHistory mengambil riwayat perubahan status order, urut dari yang terlama
+func (r *orderRepository) FindStatusHistory(ctx context.Context, orderID uint) ([]entity.OrderStatusHistory, error) {
+	r.logger.Info("Finding order status history", zap.Uint("order_id", orderID))
+
+	var histories []entity.OrderStatusHistory
+	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&histories).Error
+	if err != nil {
+		r.logger.Error("Failed to find order status history", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully found order status history", zap.Int("count", len(histories)))
+	return histories, nil
+}
+
+// FindProductNames memetakan product_id ke nama produk (termasuk produk yang sudah dihapus)
+func (r *orderRepository) FindProductNames(ctx context.Context, productIDs []uint) (map[uint]string, error) {
+	var products []entity.Product
+	err := r.db.WithContext(ctx).Unscoped().Select("id", "product_name").Where("id IN ?", productIDs).Find(&products).Error
+	if err != nil {
+		r.logger.Error("Failed to find product names", zap.Error(err))
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(products))
+	for _, p := range products {
+		names[p.ID] = p.ProductName
+	}
+	return names, nil
+}
+
 func (r *orderRepository) FindAllTables(ctx context.Context) ([]entity.Table, error) {
 	r.logger.Info("Finding all tables")
 
@@ -215,7 +653,7 @@ func (r *orderRepository) FindAvailableChairs(ctx context.Context) ([]entity.Tab
 	r.logger.Info("Finding available chairs (tables)")
 
 	var tables []entity.Table
-	err := r.db.WithContext(ctx).Where("status = ?", "available").Find(&tables).Error
+	err := r.db.WithContext(ctx).Where("status = ?", entity.TableStatusAvailable).Find(&tables).Error
 	if err != nil {
 		r.logger.Error("Failed to find available chairs", zap.Error(err))
 		return nil, err
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidBundleChoice dikembalikan jika pilihan komponen bundle tidak lengkap atau bukan milik bundle
+var ErrInvalidBundleChoice = errors.New("pilihan komponen bundle tidak valid")
+
+// loadBundleSlots memuat slot dan opsi komponen untuk produk bundle dalam satu order.
+// Produk/varian komponen yang sudah dihapus ikut dimuat agar item lama tetap bisa disimpan ulang.
+func loadBundleSlots(tx *gorm.DB, products []entity.Product) (map[uint][]entity.BundleSlot, error) {
+	bundleIDs := make([]uint, 0, len(products))
+	for _, p := range products {
+		if p.IsBundle() {
+			bundleIDs = append(bundleIDs, p.ID)
+		}
+	}
+	slots := make(map[uint][]entity.BundleSlot, len(bundleIDs))
+	if len(bundleIDs) == 0 {
+		return slots, nil
+	}
+
+	var rows []entity.BundleSlot
+	if err := tx.Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Options.Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Options.Variant", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("bundle_id IN ?", bundleIDs).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, slot := range rows {
+		slots[slot.BundleID] = append(slots[slot.BundleID], slot)
+	}
+	return slots, nil
+}
+
+// bundleComponentProductIDs mengembalikan produk yang menjadi opsi komponen di slots
+func bundleComponentProductIDs(slots map[uint][]entity.BundleSlot) []uint {
+	var ids []uint
+	for _, bundleSlots := range slots {
+		for _, slot := range bundleSlots {
+			for _, option := range slot.Options {
+				ids = append(ids, option.ProductID)
+			}
+		}
+	}
+	return ids
+}
+
+// resolveBundle memilih opsi setiap slot bundle dan mengembalikan snapshot komponennya.
+// Slot dengan satu opsi dipilih otomatis, slot dengan beberapa opsi wajib dipilih lewat choices.
+// Komponen yang tidak tersedia hanya diterima jika stoknya sudah dipegang order ini (held); komponen
+// beresep (recipeProducts) dicek dari bahannya oleh checkIngredients, bukan dari is_available.
+// Pendapatan item dialokasikan ke komponen sebanding dengan harga katalog x quantity komponen.
+func resolveBundle(product entity.Product, slots []entity.BundleSlot, choices []dto.OrderBundleChoiceRequest, held map[stockKey]bool, recipeProducts map[uint]bool) ([]entity.OrderItemComponent, error) {
+	if !product.IsBundle() {
+		if len(choices) > 0 {
+			return nil, fmt.Errorf("%w: %s bukan produk bundle", ErrInvalidBundleChoice, product.ProductName)
+		}
+		return nil, nil
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("%w: %s belum punya komponen", ErrInvalidBundleChoice, product.ProductName)
+	}
+
+	chosen := make(map[uint]uint, len(choices))
+	for _, choice := range choices {
+		if _, ok := chosen[choice.SlotID]; ok {
+			return nil, fmt.Errorf("%w: slot_id %d dipilih lebih dari sekali", ErrInvalidBundleChoice, choice.SlotID)
+		}
+		chosen[choice.SlotID] = choice.OptionID
+	}
+
+	components := make([]entity.OrderItemComponent, 0, len(slots))
+	weights := make([]float64, 0, len(slots))
+	var totalWeight float64
+	for _, slot := range slots {
+		option, err := pickBundleOption(product, slot, chosen)
+		if err != nil {
+			return nil, err
+		}
+		delete(chosen, slot.ID)
+
+		component := entity.OrderItemComponent{
+			BundleSlotID:       slot.ID,
+			BundleSlotOptionID: option.ID,
+			SlotName:           slot.Name,
+			ProductID:          option.ProductID,
+			ProductName:        option.DisplayName(),
+			Quantity:           slot.Quantity,
+		}
+		hasRecipe := recipeProducts[option.ProductID]
+		available := (option.Product.IsAvailable || hasRecipe) && !option.Product.DeletedAt.Valid
+		price := option.Product.Price
+		if option.Variant != nil {
+			variantID := option.Variant.ID
+			component.VariantID = &variantID
+			available = (option.Variant.IsAvailable || hasRecipe) && !option.Variant.DeletedAt.Valid
+			price = option.Variant.Price
+		}
+		key := stockKey{productID: component.ProductID}
+		if component.VariantID != nil {
+			key.variantID = *component.VariantID
+		}
+		if !available && !held[key] {
+			return nil, fmt.Errorf("%w: %s pada %s", ErrProductUnavailable, component.ProductName, product.ProductName)
+		}
+
+		weight := price * float64(slot.Quantity)
+		components = append(components, component)
+		weights = append(weights, weight)
+		totalWeight += weight
+	}
+	for slotID := range chosen {
+		return nil, fmt.Errorf("%w: slot_id %d bukan milik %s", ErrInvalidBundleChoice, slotID, product.ProductName)
+	}
+
+	allocateBundleRevenue(components, weights, totalWeight)
+	return components, nil
+}
+
+// pickBundleOption mengembalikan opsi slot yang dipilih
+func pickBundleOption(product entity.Product, slot entity.BundleSlot, chosen map[uint]uint) (entity.BundleSlotOption, error) {
+	optionID, ok := chosen[slot.ID]
+	if !ok {
+		if len(slot.Options) == 1 {
+			return slot.Options[0], nil
+		}
+		return entity.BundleSlotOption{}, fmt.Errorf("%w: %s wajib memilih %s", ErrInvalidBundleChoice, product.ProductName, slot.Name)
+	}
+	for _, option := range slot.Options {
+		if option.ID == optionID {
+			return option, nil
+		}
+	}
+	return entity.BundleSlotOption{}, fmt.Errorf("%w: option_id %d bukan pilihan %s", ErrInvalidBundleChoice, optionID, slot.Name)
+}
+
+// allocateBundleRevenue mengisi AllocationRatio komponen sebanding dengan bobotnya. Jika semua
+// komponen berharga 0, pendapatan dibagi berdasarkan quantity. Sisa pembulatan masuk ke komponen terakhir.
+func allocateBundleRevenue(components []entity.OrderItemComponent, weights []float64, totalWeight float64) {
+	if totalWeight <= 0 {
+		totalWeight = 0
+		for i, c := range components {
+			weights[i] = float64(c.Quantity)
+			totalWeight += weights[i]
+		}
+	}
+	var allocated float64
+	for i := range components {
+		if i == len(components)-1 {
+			components[i].AllocationRatio = math.Round((1-allocated)*1e6) / 1e6
+			break
+		}
+		ratio := math.Round(weights[i]/totalWeight*1e6) / 1e6
+		components[i].AllocationRatio = ratio
+		allocated += ratio
+	}
+}
+
+// heldStockKeys mengumpulkan stok (produk, varian, dan komponen bundle) yang sudah dipegang item order
+func heldStockKeys(items []entity.OrderItem) map[stockKey]bool {
+	held := make(map[stockKey]bool, len(items))
+	for _, item := range items {
+		held[itemStockKey(item)] = true
+		for key := range itemStockUsage(item) {
+			held[key] = true
+		}
+	}
+	return held
+}
+
+// cloneItemComponents menyalin komponen bundle tanpa ID agar bisa disimpan ulang untuk item baru
+func cloneItemComponents(components []entity.OrderItemComponent) []entity.OrderItemComponent {
+	if len(components) == 0 {
+		return nil
+	}
+	cloned := make([]entity.OrderItemComponent, len(components))
+	for i, c := range components {
+		c.ID = 0
+		c.OrderItemID = 0
+		cloned[i] = c
+	}
+	return cloned
+}
+
+// soldOrderSQL adalah kondisi order (alias o) yang item-nya dihitung sebagai penjualan di laporan
+// produk: order paid, ditambah order asal split rata yang minimal satu bagiannya paid dan tidak ada
+// bagian yang masih terbuka (bagian split rata tidak punya item). Order split/merged lain, batal,
+// refund, dan yang belum dibayar tidak dihitung.
+const soldOrderSQL = `(o.status = 'paid' OR (o.status = 'split'
+		AND EXISTS (SELECT 1 FROM order_links sl JOIN orders s ON s.id = sl.result_order_id
+			WHERE sl.source_order_id = o.id AND sl.link_type = 'split_even' AND s.status = 'paid')
+		AND NOT EXISTS (SELECT 1 FROM order_links sl JOIN orders s ON s.id = sl.result_order_id
+			WHERE sl.source_order_id = o.id AND sl.link_type = 'split_even' AND s.status IN ('pending', 'in_kitchen', 'served'))))`
+
+// productSalesSQL adalah subquery penjualan per produk untuk laporan, hanya dari order yang memenuhi
+// soldOrderSQL. Item biasa dihitung apa adanya, sedangkan item bundle dipecah ke komponennya:
+// quantity = quantity komponen x quantity item, dan subtotal/diskon item dialokasikan sesuai
+// AllocationRatio. Kolom: order_id, product_id, quantity, subtotal, discount_amount, modifier_amount
+// (total modifier, bukan per satuan).
+const productSalesSQL = `
+	SELECT oi.order_id, oi.product_id, oi.quantity, oi.subtotal, oi.discount_amount,
+		oi.modifier_amount * oi.quantity AS modifier_amount
+	FROM order_items oi
+	JOIN orders o ON o.id = oi.order_id AND o.deleted_at IS NULL
+	WHERE oi.deleted_at IS NULL AND ` + soldOrderSQL + `
+		AND NOT EXISTS (SELECT 1 FROM order_item_components oic WHERE oic.order_item_id = oi.id)
+	UNION ALL
+	SELECT oi.order_id, oic.product_id, oic.quantity * oi.quantity, oi.subtotal * oic.allocation_ratio,
+		oi.discount_amount * oic.allocation_ratio, 0
+	FROM order_item_components oic
+	JOIN order_items oi ON oic.order_item_id = oi.id AND oi.deleted_at IS NULL
+	JOIN orders o ON o.id = oi.order_id AND o.deleted_at IS NULL
+	WHERE ` + soldOrderSQL
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"math"
+	"testing"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+)
+
+func TestAllocateBundleRevenue(t *testing.T) {
+	tests := []struct {
+		name        string
+		quantities  []int
+		weights     []float64
+		totalWeight float64
+		want        []float64
+	}{
+		{
+			name:        "sebanding dengan bobot harga",
+			quantities:  []int{1, 1, 1},
+			weights:     []float64{30, 20, 50},
+			totalWeight: 100,
+			want:        []float64{0.3, 0.2, 0.5},
+		},
+		{
+			name:        "sisa pembulatan masuk komponen terakhir",
+			quantities:  []int{1, 1, 1},
+			weights:     []float64{1, 1, 1},
+			totalWeight: 3,
+			want:        []float64{0.333333, 0.333333, 0.333334},
+		},
+		{
+			name:        "semua komponen gratis dibagi berdasarkan quantity",
+			quantities:  []int{1, 3},
+			weights:     []float64{0, 0},
+			totalWeight: 0,
+			want:        []float64{0.25, 0.75},
+		},
+		{
+			name:        "satu komponen mendapat seluruh pendapatan",
+			quantities:  []int{2},
+			weights:     []float64{15},
+			totalWeight: 15,
+			want:        []float64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := make([]entity.OrderItemComponent, len(tt.quantities))
+			for i, qty := range tt.quantities {
+				components[i].Quantity = qty
+			}
+
+			allocateBundleRevenue(components, tt.weights, tt.totalWeight)
+
+			var sum float64
+			for i, c := range components {
+				if math.Abs(c.AllocationRatio-tt.want[i]) > 1e-9 {
+					t.Errorf("component[%d] AllocationRatio = %v, want %v", i, c.AllocationRatio, tt.want[i])
+				}
+				sum += c.AllocationRatio
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Errorf("total AllocationRatio = %v, want 1", sum)
+			}
+		})
+	}
+}
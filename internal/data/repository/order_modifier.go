@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidModifier dikembalikan jika modifier item tidak berlaku untuk produk atau melanggar aturan min/max group
+var ErrInvalidModifier = errors.New("modifier item tidak valid")
+
+// modifierCatalog berisi modifier group yang berlaku untuk produk-produk dalam satu order
+type modifierCatalog struct {
+	groups        map[uint]entity.ModifierGroup // group ID -> group beserta opsinya
+	productGroups map[uint][]uint               // product ID -> group ID (milik produk dan kategorinya)
+}
+
+// loadModifierCatalog memuat modifier group yang dipasang ke produk atau kategori produk
+func loadModifierCatalog(tx *gorm.DB, products []entity.Product) (*modifierCatalog, error) {
+	catalog := &modifierCatalog{
+		groups:        make(map[uint]entity.ModifierGroup),
+		productGroups: make(map[uint][]uint, len(products)),
+	}
+	if len(products) == 0 {
+		return catalog, nil
+	}
+
+	productIDs := make([]uint, 0, len(products))
+	categoryIDs := make([]uint, 0, len(products))
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+		categoryIDs = append(categoryIDs, p.CategoryID)
+	}
+
+	var productLinks []entity.ProductModifierGroup
+	if err := tx.Where("product_id IN ?", productIDs).Find(&productLinks).Error; err != nil {
+		return nil, err
+	}
+	var categoryLinks []entity.CategoryModifierGroup
+	if err := tx.Where("category_id IN ?", categoryIDs).Find(&categoryLinks).Error; err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]uint, 0, len(productLinks)+len(categoryLinks))
+	for _, link := range productLinks {
+		groupIDs = append(groupIDs, link.GroupID)
+	}
+	for _, link := range categoryLinks {
+		groupIDs = append(groupIDs, link.GroupID)
+	}
+	if len(groupIDs) == 0 {
+		return catalog, nil
+	}
+
+	var groups []entity.ModifierGroup
+	if err := tx.Preload("Options", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("id IN ?", groupIDs).Order("id ASC").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		catalog.groups[g.ID] = g
+	}
+
+	byCategory := make(map[uint][]uint)
+	for _, link := range categoryLinks {
+		byCategory[link.CategoryID] = append(byCategory[link.CategoryID], link.GroupID)
+	}
+	byProduct := make(map[uint][]uint)
+	for _, link := range productLinks {
+		byProduct[link.ProductID] = append(byProduct[link.ProductID], link.GroupID)
+	}
+	for _, p := range products {
+		seen := make(map[uint]bool)
+		for _, groupID := range append(byProduct[p.ID], byCategory[p.CategoryID]...) {
+			if _, ok := catalog.groups[groupID]; ok && !seen[groupID] {
+				seen[groupID] = true
+				catalog.productGroups[p.ID] = append(catalog.productGroups[p.ID], groupID)
+			}
+		}
+	}
+	return catalog, nil
+}
+
+// resolve memvalidasi opsi modifier yang dipilih untuk satu item dan mengembalikan snapshot
+// modifier beserta total price delta per satuan. Opsi yang tidak tersedia hanya diterima jika
+// sudah ada di order (held).
+func (c *modifierCatalog) resolve(product entity.Product, optionIDs []uint, held map[uint]bool) ([]entity.OrderItemModifier, float64, error) {
+	type choice struct {
+		group  entity.ModifierGroup
+		option entity.ModifierOption
+	}
+	allowed := make(map[uint]choice)
+	for _, groupID := range c.productGroups[product.ID] {
+		group := c.groups[groupID]
+		for _, option := range group.Options {
+			allowed[option.ID] = choice{group: group, option: option}
+		}
+	}
+
+	var modifiers []entity.OrderItemModifier
+	var amount float64
+	selected := make(map[uint]int)
+	seen := make(map[uint]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if seen[optionID] {
+			return nil, 0, fmt.Errorf("%w: modifier_option_id %d dipilih lebih dari sekali untuk %s", ErrInvalidModifier, optionID, product.ProductName)
+		}
+		seen[optionID] = true
+
+		ch, ok := allowed[optionID]
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: modifier_option_id %d tidak berlaku untuk %s", ErrInvalidModifier, optionID, product.ProductName)
+		}
+		if !ch.option.IsAvailable && !held[optionID] {
+			return nil, 0, fmt.Errorf("%w: %s tidak tersedia", ErrInvalidModifier, ch.option.Name)
+		}
+
+		selected[ch.group.ID]++
+		amount += ch.option.PriceDelta
+		modifiers = append(modifiers, entity.OrderItemModifier{
+			ModifierGroupID:  ch.group.ID,
+			ModifierOptionID: ch.option.ID,
+			GroupName:        ch.group.Name,
+			OptionName:       ch.option.Name,
+			PriceDelta:       ch.option.PriceDelta,
+		})
+	}
+
+	for _, groupID := range c.productGroups[product.ID] {
+		group := c.groups[groupID]
+		count := selected[groupID]
+		if count < group.MinSelect {
+			return nil, 0, fmt.Errorf("%w: %s wajib memilih minimal %d %s", ErrInvalidModifier, product.ProductName, group.MinSelect, group.Name)
+		}
+		if group.MaxSelect > 0 && count > group.MaxSelect {
+			return nil, 0, fmt.Errorf("%w: %s maksimal %d pilihan %s", ErrInvalidModifier, product.ProductName, group.MaxSelect, group.Name)
+		}
+	}
+
+	return modifiers, amount, nil
+}
+
+// heldModifierOptions mengumpulkan opsi modifier yang sudah dipakai item order
+func heldModifierOptions(items []entity.OrderItem) map[uint]bool {
+	held := make(map[uint]bool)
+	for _, item := range items {
+		for _, m := range item.Modifiers {
+			held[m.ModifierOptionID] = true
+		}
+	}
+	return held
+}
+
+// cloneItemModifiers menyalin modifier item tanpa ID agar bisa disimpan ulang untuk item baru.
+// Item hasil split berbagi slice modifier dengan item asal, sehingga harus disalin sebelum disimpan.
+func cloneItemModifiers(modifiers []entity.OrderItemModifier) []entity.OrderItemModifier {
+	if len(modifiers) == 0 {
+		return nil
+	}
+	cloned := make([]entity.OrderItemModifier, len(modifiers))
+	for i, m := range modifiers {
+		m.ID = 0
+		m.OrderItemID = 0
+		cloned[i] = m
+	}
+	return cloned
+}
+
+// itemKitchenNote merangkum komponen bundle, modifier, dan catatan item untuk ditampilkan di tiket dapur
+func itemKitchenNote(item entity.OrderItem) string {
+	parts := make([]string, 0, len(item.Components)+len(item.Modifiers)+1)
+	for _, c := range item.Components {
+		parts = append(parts, fmt.Sprintf("%dx %s", c.Quantity, c.ProductName))
+	}
+	for _, m := range item.Modifiers {
+		parts = append(parts, m.OptionName)
+	}
+	if notes := strings.TrimSpace(item.Notes); notes != "" {
+		parts = append(parts, notes)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrPaymentMethodNotFound dikembalikan jika metode pembayaran pada tender tidak ada
+	ErrPaymentMethodNotFound = errors.New("metode pembayaran tidak ditemukan")
+	// ErrPaymentExceedsBalance dikembalikan jika tender non-tunai melebihi sisa tagihan,
+	// atau tender tunai diberikan padahal tagihan sudah tertutup tender lain
+	ErrPaymentExceedsBalance = errors.New("pembayaran melebihi sisa tagihan")
+	// ErrOrderAlreadySettled dikembalikan jika order sudah lunas
+	ErrOrderAlreadySettled = errors.New("order sudah lunas")
+)
+
+// AddPayments mencatat satu atau beberapa tender pembayaran untuk order. Tender non-tunai
+// dialokasikan lebih dulu dan tidak boleh melebihi sisa tagihan; tender tunai boleh lebih dan
+// selisihnya menjadi kembalian. Jika total pembayaran sudah menutup TotalAmount, status order
+// langsung diubah ke paid dalam transaksi yang sama.
+func (r *orderRepository) AddPayments(ctx context.Context, id uint, fromStatus string, tenders []dto.OrderPaymentTenderRequest, receivedBy uint) ([]entity.OrderPayment, error) {
+	r.logger.Info("Adding order payments",
+		zap.Uint("id", id),
+		zap.Int("tenders", len(tenders)))
+
+	var payments []entity.OrderPayment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != fromStatus {
+			return fmt.Errorf("%w: status saat ini %s", ErrOrderStatusConflict, order.Status)
+		}
+
+		remaining := roundAmount(order.RemainingAmount())
+		if remaining <= 0 {
+			return fmt.Errorf("%w: order_id %d", ErrOrderAlreadySettled, id)
+		}
+
+		methodIDs := make([]uint, 0, len(tenders))
+		for _, tender := range tenders {
+			methodIDs = append(methodIDs, tender.PaymentMethodID)
+		}
+		var methods []entity.PaymentMethod
+		if err := tx.Where("id IN ?", methodIDs).Find(&methods).Error; err != nil {
+			return err
+		}
+		methodMap := make(map[uint]entity.PaymentMethod, len(methods))
+		for _, m := range methods {
+			methodMap[m.ID] = m
+		}
+
+		var receiver *uint
+		if receivedBy != 0 {
+			receiver = &receivedBy
+		}
+
+		// Tender non-tunai dulu, tunai terakhir agar kembalian hanya berasal dari uang tunai
+		var nonCash, cash []entity.OrderPayment
+		for _, tender := range tenders {
+			method, ok := methodMap[tender.PaymentMethodID]
+			if !ok {
+				return fmt.Errorf("%w: payment_method_id %d", ErrPaymentMethodNotFound, tender.PaymentMethodID)
+			}
+			payment := entity.OrderPayment{
+				OrderID:         id,
+				PaymentMethodID: method.ID,
+				Tendered:        roundAmount(tender.Amount),
+				Reference:       tender.Reference,
+				ReceivedBy:      receiver,
+			}
+			if method.IsCash {
+				cash = append(cash, payment)
+			} else {
+				nonCash = append(nonCash, payment)
+			}
+		}
+
+		allocated, remaining, err := allocateTenders(remaining, nonCash, cash)
+		if err != nil {
+			return err
+		}
+		payments = allocated
+
+		if err := tx.Create(&payments).Error; err != nil {
+			return err
+		}
+
+		var paid float64
+		for _, payment := range payments {
+			paid += payment.Amount
+		}
+		order.PaidAmount = roundAmount(order.PaidAmount + paid)
+		if err := tx.Model(&entity.Order{}).Where("id = ?", id).Update("paid_amount", order.PaidAmount).Error; err != nil {
+			return err
+		}
+
+		if remaining > 0 {
+			return nil
+		}
+		return r.changeStatus(tx, &order, entity.OrderStatusPaid, receivedBy, "Lunas melalui pembayaran")
+	})
+	if err != nil {
+		r.logger.Error("Failed to add order payments",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully added order payments",
+		zap.Uint("id", id),
+		zap.Int("count", len(payments)))
+	return payments, nil
+}
+
+// FindPayments mengambil semua pembayaran order, urut dari yang terlama
+func (r *orderRepository) FindPayments(ctx context.Context, orderID uint) ([]entity.OrderPayment, error) {
+	r.logger.Info("Finding order payments", zap.Uint("order_id", orderID))
+
+	var payments []entity.OrderPayment
+	err := r.db.WithContext(ctx).Preload("PaymentMethod").Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&payments).Error
+	if err != nil {
+		r.logger.Error("Failed to find order payments", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+	return payments, nil
+}
+
+// allocateTenders mengisi Amount dan Change tender terhadap sisa tagihan remaining: tender non-tunai
+// dialokasikan lebih dulu dan tidak boleh melebihi sisa, lalu tender tunai menutup sisanya dan
+// kelebihannya menjadi kembalian. Mengembalikan tender yang sudah dialokasikan dan sisa tagihan.
+func allocateTenders(remaining float64, nonCash, cash []entity.OrderPayment) ([]entity.OrderPayment, float64, error) {
+	payments := make([]entity.OrderPayment, 0, len(nonCash)+len(cash))
+	for _, payment := range nonCash {
+		if payment.Tendered > remaining {
+			return nil, remaining, fmt.Errorf("%w: sisa tagihan %.2f", ErrPaymentExceedsBalance, remaining)
+		}
+		payment.Amount = payment.Tendered
+		remaining = roundAmount(remaining - payment.Amount)
+		payments = append(payments, payment)
+	}
+	for _, payment := range cash {
+		if remaining <= 0 {
+			return nil, remaining, fmt.Errorf("%w: tagihan sudah tertutup tender lain", ErrPaymentExceedsBalance)
+		}
+		payment.Amount = payment.Tendered
+		if payment.Amount > remaining {
+			payment.Amount = remaining
+		}
+		payment.Change = roundAmount(payment.Tendered - payment.Amount)
+		remaining = roundAmount(remaining - payment.Amount)
+		payments = append(payments, payment)
+	}
+	return payments, remaining, nil
+}
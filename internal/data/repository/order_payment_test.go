@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+)
+
+func TestAllocateTenders(t *testing.T) {
+	const card, cash = 1, 2
+
+	type allocation struct {
+		method uint
+		amount float64
+		change float64
+	}
+
+	tests := []struct {
+		name          string
+		remaining     float64
+		nonCash       []float64
+		cash          []float64
+		want          []allocation
+		wantRemaining float64
+		wantErr       error
+	}{
+		{
+			name:          "kelebihan tunai menjadi kembalian",
+			remaining:     100,
+			cash:          []float64{150},
+			want:          []allocation{{cash, 100, 50}},
+			wantRemaining: 0,
+		},
+		{
+			name:          "non-tunai dialokasikan dulu, kembalian dari tunai terakhir",
+			remaining:     100,
+			nonCash:       []float64{60},
+			cash:          []float64{50},
+			want:          []allocation{{card, 60, 0}, {cash, 40, 10}},
+			wantRemaining: 0,
+		},
+		{
+			name:          "beberapa tender tunai",
+			remaining:     100,
+			cash:          []float64{70, 50},
+			want:          []allocation{{cash, 70, 0}, {cash, 30, 20}},
+			wantRemaining: 0,
+		},
+		{
+			name:          "pembayaran sebagian menyisakan tagihan",
+			remaining:     100,
+			nonCash:       []float64{25.5},
+			cash:          []float64{30},
+			want:          []allocation{{card, 25.5, 0}, {cash, 30, 0}},
+			wantRemaining: 44.5,
+		},
+		{
+			name:      "non-tunai melebihi sisa tagihan ditolak",
+			remaining: 100,
+			nonCash:   []float64{120},
+			wantErr:   ErrPaymentExceedsBalance,
+		},
+		{
+			name:      "tunai setelah tagihan tertutup non-tunai ditolak",
+			remaining: 100,
+			nonCash:   []float64{100},
+			cash:      []float64{20},
+			wantErr:   ErrPaymentExceedsBalance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nonCash, cashTenders []entity.OrderPayment
+			for _, amount := range tt.nonCash {
+				nonCash = append(nonCash, entity.OrderPayment{PaymentMethodID: card, Tendered: amount})
+			}
+			for _, amount := range tt.cash {
+				cashTenders = append(cashTenders, entity.OrderPayment{PaymentMethodID: cash, Tendered: amount})
+			}
+
+			payments, remaining, err := allocateTenders(tt.remaining, nonCash, cashTenders)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("allocateTenders() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("allocateTenders() unexpected error: %v", err)
+			}
+
+			if remaining != tt.wantRemaining {
+				t.Errorf("remaining = %v, want %v", remaining, tt.wantRemaining)
+			}
+			if len(payments) != len(tt.want) {
+				t.Fatalf("payments = %d, want %d", len(payments), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := payments[i]
+				if got.PaymentMethodID != want.method || got.Amount != want.amount || got.Change != want.change {
+					t.Errorf("payment[%d] = {method %d, amount %v, change %v}, want %+v",
+						i, got.PaymentMethodID, got.Amount, got.Change, want)
+				}
+			}
+		})
+	}
+}
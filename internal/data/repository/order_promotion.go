@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPromoCodeInvalid dikembalikan jika kode promo tidak ada, tidak aktif, atau di luar jam berlaku
+	ErrPromoCodeInvalid = errors.New("kode promo tidak valid atau tidak berlaku")
+	// ErrPromoNotApplicable dikembalikan jika kode promo valid tetapi tidak ada item/order yang memenuhi syarat
+	ErrPromoNotApplicable = errors.New("kode promo tidak berlaku untuk order ini")
+	// ErrPromoUsageExceeded dikembalikan jika kuota pemakaian promo sudah habis
+	ErrPromoUsageExceeded = errors.New("kuota pemakaian promo sudah habis")
+)
+
+// appliedPromotion menampung diskon satu promo per index item
+type appliedPromotion struct {
+	promotion entity.Promotion
+	lines     map[int]float64
+}
+
+func (a *appliedPromotion) total() float64 {
+	var total float64
+	for _, amount := range a.lines {
+		total += amount
+	}
+	return total
+}
+
+// applyPromotions mengevaluasi promo otomatis dan promo dari kode, lalu mengisi DiscountAmount
+// setiap item. Per item hanya satu promo produk/kategori (yang terbesar) dan per order hanya
+// satu promo order; promo dari kode selalu diutamakan di cakupannya. Kuota pemakaian promo
+// yang diterapkan langsung dinaikkan dalam transaksi yang sama.
+func (r *orderRepository) applyPromotions(tx *gorm.DB, items []entity.OrderItem, promoCode string, now time.Time) ([]entity.OrderDiscount, error) {
+	code := strings.ToUpper(strings.TrimSpace(promoCode))
+
+	query := tx.Where("is_active = ?", true)
+	if code == "" {
+		query = query.Where("code IS NULL")
+	} else {
+		query = query.Where("code IS NULL OR UPPER(code) = ?", code)
+	}
+	var promotions []entity.Promotion
+	if err := query.Order("id ASC").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	var gross float64
+	for _, item := range items {
+		gross += item.Subtotal
+	}
+
+	// Saring promo yang berlaku saat ini dan kodenya cocok
+	var codePromo *entity.Promotion
+	var itemPromos, orderPromos []entity.Promotion
+	for _, p := range promotions {
+		if !p.IsActiveAt(now) || gross < p.MinOrderAmount {
+			continue
+		}
+		if p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit {
+			continue
+		}
+		if p.Code != nil {
+			promo := p
+			codePromo = &promo
+		}
+		if p.Scope == entity.PromotionScopeOrder {
+			orderPromos = append(orderPromos, p)
+		} else {
+			itemPromos = append(itemPromos, p)
+		}
+	}
+	if code != "" && codePromo == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPromoCodeInvalid, code)
+	}
+
+	categoryOf, err := productCategories(tx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[uint]*appliedPromotion{}
+	addLine := func(p entity.Promotion, index int, amount float64) {
+		if amount <= 0 {
+			return
+		}
+		if applied[p.ID] == nil {
+			applied[p.ID] = &appliedPromotion{promotion: p, lines: map[int]float64{}}
+		}
+		applied[p.ID].lines[index] += amount
+	}
+
+	// Promo produk/kategori: pilih satu promo per item
+	for i, item := range items {
+		var best *entity.Promotion
+		var bestAmount float64
+		for j := range itemPromos {
+			p := &itemPromos[j]
+			if !p.AppliesToItem(item.ProductID, categoryOf[item.ProductID]) {
+				continue
+			}
+			amount := itemDiscount(p, item)
+			if codePromo != nil && p.ID == codePromo.ID {
+				best, bestAmount = p, amount
+				break
+			}
+			if amount > bestAmount {
+				best, bestAmount = p, amount
+			}
+		}
+		if best != nil {
+			addLine(*best, i, bestAmount)
+		}
+	}
+
+	// Batasi total diskon promo item sesuai MaxDiscount
+	for _, a := range applied {
+		if limit := a.promotion.MaxDiscount; limit > 0 {
+			if total := a.total(); total > limit {
+				for i := range a.lines {
+					a.lines[i] = a.lines[i] * limit / total
+				}
+			}
+		}
+	}
+
+	itemDiscounts := make([]float64, len(items))
+	for _, a := range applied {
+		for i, amount := range a.lines {
+			itemDiscounts[i] += amount
+		}
+	}
+
+	// Promo order: satu promo, dialokasikan proporsional ke item berdasarkan nilai setelah diskon item
+	var net float64
+	for i, item := range items {
+		net += item.Subtotal - itemDiscounts[i]
+	}
+	var orderPromo *entity.Promotion
+	var orderAmount float64
+	for j := range orderPromos {
+		p := &orderPromos[j]
+		amount := orderDiscount(p, net)
+		if codePromo != nil && p.ID == codePromo.ID {
+			orderPromo, orderAmount = p, amount
+			break
+		}
+		if amount > orderAmount {
+			orderPromo, orderAmount = p, amount
+		}
+	}
+	if orderPromo != nil && orderAmount > 0 && net > 0 {
+		for i, item := range items {
+			lineNet := item.Subtotal - itemDiscounts[i]
+			addLine(*orderPromo, i, orderAmount*lineNet/net)
+		}
+	}
+
+	if codePromo != nil && applied[codePromo.ID] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPromoNotApplicable, code)
+	}
+
+	// Tulis diskon ke item dan naikkan kuota pemakaian promo
+	for i := range items {
+		items[i].DiscountAmount = 0
+		items[i].PromotionID = nil
+	}
+	discounts := make([]entity.OrderDiscount, 0, len(applied))
+	for _, p := range promotions {
+		a, ok := applied[p.ID]
+		if !ok {
+			continue
+		}
+
+		var total float64
+		for i, amount := range a.lines {
+			amount = roundAmount(amount)
+			items[i].DiscountAmount = roundAmount(items[i].DiscountAmount + amount)
+			if p.Scope != entity.PromotionScopeOrder {
+				promotionID := p.ID
+				items[i].PromotionID = &promotionID
+			}
+			total += amount
+		}
+
+		result := tx.Model(&entity.Promotion{}).
+			Where("id = ? AND (usage_limit = 0 OR usage_count < usage_limit)", p.ID).
+			UpdateColumn("usage_count", gorm.Expr("usage_count + 1"))
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrPromoUsageExceeded, p.Name)
+		}
+
+		discount := entity.OrderDiscount{
+			PromotionID: p.ID,
+			Name:        p.Name,
+			Type:        p.Type,
+			Scope:       p.Scope,
+			Amount:      roundAmount(total),
+		}
+		if p.Code != nil {
+			discount.Code = *p.Code
+		}
+		discounts = append(discounts, discount)
+
+		r.logger.Info("Promotion applied",
+			zap.Uint("promotion_id", p.ID),
+			zap.String("name", p.Name),
+			zap.Float64("amount", discount.Amount))
+	}
+
+	return discounts, nil
+}
+
+// releasePromotionUsage mengembalikan kuota pemakaian promo yang dipakai order
+// dan menghapus catatan diskonnya jika removeDiscounts bernilai true
+func releasePromotionUsage(tx *gorm.DB, orderID uint, removeDiscounts bool) error {
+	var promotionIDs []uint
+	if err := tx.Model(&entity.OrderDiscount{}).Where("order_id = ?", orderID).Pluck("promotion_id", &promotionIDs).Error; err != nil {
+		return err
+	}
+	for _, id := range promotionIDs {
+		if err := tx.Model(&entity.Promotion{}).
+			Where("id = ? AND usage_count > 0", id).
+			UpdateColumn("usage_count", gorm.Expr("usage_count - 1")).Error; err != nil {
+			return err
+		}
+	}
+	if !removeDiscounts {
+		return nil
+	}
+	return tx.Where("order_id = ?", orderID).Delete(&entity.OrderDiscount{}).Error
+}
+
+// itemDiscount menghitung diskon promo produk/kategori untuk satu item
+func itemDiscount(p *entity.Promotion, item entity.OrderItem) float64 {
+	switch p.Type {
+	case entity.PromotionTypePercentage:
+		return item.Subtotal * p.Value / 100
+	case entity.PromotionTypeFixed:
+		amount := p.Value * float64(item.Quantity)
+		if amount > item.Subtotal {
+			return item.Subtotal
+		}
+		return amount
+	case entity.PromotionTypeBuyXGetY:
+		group := p.BuyQuantity + p.GetQuantity
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 || item.Quantity < group {
+			return 0
+		}
+		free := (item.Quantity / group) * p.GetQuantity
+		return float64(free) * item.Price
+	}
+	return 0
+}
+
+// orderDiscount menghitung diskon promo order dari nilai order setelah diskon item
+func orderDiscount(p *entity.Promotion, net float64) float64 {
+	var amount float64
+	switch p.Type {
+	case entity.PromotionTypePercentage:
+		amount = net * p.Value / 100
+	case entity.PromotionTypeFixed:
+		amount = p.Value
+	}
+	if p.MaxDiscount > 0 && amount > p.MaxDiscount {
+		amount = p.MaxDiscount
+	}
+	if amount > net {
+		amount = net
+	}
+	return amount
+}
+
+// productCategories memetakan product_id ke category_id (termasuk produk yang sudah dihapus)
+func productCategories(tx *gorm.DB, items []entity.OrderItem) (map[uint]uint, error) {
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	var products []entity.Product
+	if err := tx.Unscoped().Select("id", "category_id").Where("id IN ?", productIDs).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	categoryOf := make(map[uint]uint, len(products))
+	for _, p := range products {
+		categoryOf[p.ID] = p.CategoryID
+	}
+	return categoryOf, nil
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ingredientUsage berisi kebutuhan bahan inventory untuk sekumpulan item order
+type ingredientUsage struct {
+	quantities  map[int64]float64            // inventory ID -> quantity dalam satuan inventory
+	inventories map[int64]entity.Inventories // inventory ID -> data inventory saat dihitung
+	users       map[int64][]stockKey         // inventory ID -> produk/varian yang memakai bahan
+	products    map[int64]map[uint]float64   // inventory ID -> produk -> quantity, untuk alokasi COGS
+}
+
+// loadIngredientUsage menghitung kebutuhan bahan dari resep produk/varian yang dipakai item order
+// (bundle dihitung dari resep komponennya). Bahan yang satuannya tidak bisa dikonversi dilewati.
+func (r *orderRepository) loadIngredientUsage(tx *gorm.DB, items []entity.OrderItem) (*ingredientUsage, error) {
+	usage := &ingredientUsage{
+		quantities:  make(map[int64]float64),
+		inventories: make(map[int64]entity.Inventories),
+		users:       make(map[int64][]stockKey),
+		products:    make(map[int64]map[uint]float64),
+	}
+
+	portions := make(map[stockKey]int)
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		for key, qty := range itemStockUsage(item) {
+			if _, ok := portions[key]; !ok {
+				productIDs = append(productIDs, key.productID)
+			}
+			portions[key] += qty
+		}
+	}
+	if len(productIDs) == 0 {
+		return usage, nil
+	}
+
+	var recipe []entity.RecipeItem
+	if err := tx.Preload("Inventory.PackSizes").Where("product_id IN ?", productIDs).Find(&recipe).Error; err != nil {
+		return nil, err
+	}
+
+	for key, qty := range portions {
+		var variantID *uint
+		if key.variantID != 0 {
+			id := key.variantID
+			variantID = &id
+		}
+		for _, line := range recipe {
+			if line.ProductID != key.productID || !line.AppliesTo(variantID) {
+				continue
+			}
+			perPortion, err := line.InventoryQuantity()
+			if err != nil {
+				r.logger.Warn("Skipping recipe line with incompatible unit",
+					zap.Uint("product_id", line.ProductID),
+					zap.Int64("inventory_id", line.InventoryID),
+					zap.Error(err))
+				continue
+			}
+			usage.quantities[line.InventoryID] += perPortion * float64(qty)
+			usage.inventories[line.InventoryID] = line.Inventory
+			usage.users[line.InventoryID] = append(usage.users[line.InventoryID], key)
+			if usage.products[line.InventoryID] == nil {
+				usage.products[line.InventoryID] = make(map[uint]float64)
+			}
+			usage.products[line.InventoryID][key.productID] += perPortion * float64(qty)
+		}
+	}
+	return usage, nil
+}
+
+// recipeProductIDs mengembalikan produk di antara productIDs yang punya resep
+func recipeProductIDs(tx *gorm.DB, productIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+	var ids []uint
+	if err := tx.Model(&entity.RecipeItem{}).Where("product_id IN ?", productIDs).Distinct().Pluck("product_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// checkIngredients memastikan bahan inventory cukup untuk seluruh item order, setelah dikurangi
+// kebutuhan order lain yang belum dibayar (bahan baru dipotong saat order lunas). Baris inventory
+// dikunci dengan urutan ID yang konsisten agar dua order yang berjalan bersamaan tidak bisa memakai
+// sisa bahan yang sama. Kekurangan bahan hanya ditolak jika dipakai produk/varian yang belum dipegang
+// order ini (held), sehingga order lama tetap bisa diubah walaupun bahannya sudah menipis.
+// orderID adalah order yang sedang diubah (0 untuk order baru) dan tidak dihitung sebagai order lain.
+func (r *orderRepository) checkIngredients(tx *gorm.DB, items []entity.OrderItem, held map[stockKey]bool, orderID uint) error {
+	usage, err := r.loadIngredientUsage(tx, items)
+	if err != nil {
+		return err
+	}
+	if len(usage.quantities) == 0 {
+		return nil
+	}
+
+	inventoryIDs := make([]int64, 0, len(usage.quantities))
+	for id := range usage.quantities {
+		inventoryIDs = append(inventoryIDs, id)
+	}
+	sort.Slice(inventoryIDs, func(i, j int) bool { return inventoryIDs[i] < inventoryIDs[j] })
+
+	var inventories []entity.Inventories
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", inventoryIDs).
+		Order("id").
+		Find(&inventories).Error; err != nil {
+		return err
+	}
+	locked := make(map[int64]entity.Inventories, len(inventories))
+	for _, inventory := range inventories {
+		locked[inventory.ID] = inventory
+	}
+
+	// Kebutuhan order lain dibaca setelah lock agar order yang baru saja commit ikut terhitung
+	reserved, err := r.openOrderIngredientDemand(tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	var short []string
+	for _, inventoryID := range inventoryIDs {
+		needed := usage.quantities[inventoryID]
+		inventory, ok := locked[inventoryID]
+		available := inventory.Quantity - reserved[inventoryID]
+		if !ok || inventory.Status != "active" {
+			available = 0
+			inventory.Name = usage.inventories[inventoryID].Name
+		}
+		if needed <= available {
+			continue
+		}
+		for _, key := range usage.users[inventoryID] {
+			if !held[key] {
+				short = append(short, inventory.Name)
+				break
+			}
+		}
+	}
+	if len(short) > 0 {
+		sort.Strings(short)
+		return fmt.Errorf("%w: bahan tidak cukup (%s)", ErrProductUnavailable, strings.Join(short, ", "))
+	}
+	return nil
+}
+
+// openOrderIngredientDemand menghitung kebutuhan bahan inventory dari item order terbuka (belum
+// dibayar, dibatalkan, atau ditutup) selain excludeOrderID, termasuk order asal split rata yang
+// masih punya bagian terbuka
+func (r *orderRepository) openOrderIngredientDemand(tx *gorm.DB, excludeOrderID uint) (map[int64]float64, error) {
+	var items []entity.OrderItem
+	if err := tx.Preload("Components").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where(`(orders.status IN ? OR (orders.status = ? AND EXISTS (
+			SELECT 1 FROM order_links l JOIN orders s ON s.id = l.result_order_id
+			WHERE l.source_order_id = orders.id AND l.link_type = ? AND s.status IN ?)))`,
+			entity.OpenOrderStatuses, entity.OrderStatusSplit, entity.OrderLinkSplitEven, entity.OpenOrderStatuses).
+		Where("orders.id <> ? AND orders.deleted_at IS NULL", excludeOrderID).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	usage, err := r.loadIngredientUsage(tx, items)
+	if err != nil {
+		return nil, err
+	}
+	return usage.quantities, nil
+}
+
+// deductIngredients mengurangi quantity inventory sesuai resep item order yang sudah dibayar dan
+// mencatatnya sebagai pergerakan stok sale, lalu mengalokasikan harga pokoknya (COGS) ke produk yang
+// memakai bahan tersebut. Inventory diproses urut ID agar urutan lock konsisten.
+// Stok bahan boleh menjadi negatif agar pembayaran tidak gagal; selisihnya dicatat di log untuk
+// ditindaklanjuti saat stock opname.
+func (r *orderRepository) deductIngredients(tx *gorm.DB, order *entity.Order, changedBy uint) error {
+	usage, err := r.loadIngredientUsage(tx, order.Items)
+	if err != nil {
+		return err
+	}
+	if len(usage.quantities) == 0 {
+		return nil
+	}
+
+	inventoryIDs := make([]int64, 0, len(usage.quantities))
+	for id, quantity := range usage.quantities {
+		if quantity > 0 {
+			inventoryIDs = append(inventoryIDs, id)
+		}
+	}
+	sort.Slice(inventoryIDs, func(i, j int) bool { return inventoryIDs[i] < inventoryIDs[j] })
+
+	orderID := order.ID
+	for _, inventoryID := range inventoryIDs {
+		movement := &entity.StockMovement{
+			InventoryID:   inventoryID,
+			Type:          entity.StockMovementSale,
+			Delta:         -usage.quantities[inventoryID],
+			ReferenceType: "order",
+			ReferenceID:   &orderID,
+		}
+		if changedBy != 0 {
+			movement.UserID = &changedBy
+		}
+		if err := recordStockMovement(tx, movement); err != nil {
+			return err
+		}
+		if err := r.recordSaleCosts(tx, order.ID, movement, usage.products[inventoryID]); err != nil {
+			return err
+		}
+		if movement.Balance < 0 {
+			r.logger.Warn("Inventory quantity below zero after order payment",
+				zap.Uint("order_id", order.ID),
+				zap.Int64("inventory_id", inventoryID),
+				zap.Float64("quantity", movement.Balance))
+		}
+	}
+
+	r.logger.Debug("Ingredients deducted for paid order",
+		zap.Uint("order_id", order.ID),
+		zap.Int("inventories", len(inventoryIDs)))
+	return nil
+}
+
+// deductSplitSource memakai bahan resep order asal split rata saat share (bagian yang sedang ditutup
+// ke toStatus) adalah bagian terakhir yang masih terbuka. Bagian tidak punya item, sehingga bahan dan
+// COGS-nya dicatat atas order asal; bahan hanya dipakai jika minimal satu bagian lunas.
+func (r *orderRepository) deductSplitSource(tx *gorm.DB, share *entity.Order, toStatus string, changedBy uint) error {
+	if share.ShareCount == 0 {
+		return nil
+	}
+	var link entity.OrderLink
+	if err := tx.Where("result_order_id = ? AND link_type = ?", share.ID, entity.OrderLinkSplitEven).Limit(1).Find(&link).Error; err != nil {
+		return err
+	}
+	if link.ID == 0 {
+		return nil
+	}
+
+	// Order asal dikunci agar dua bagian terakhir yang ditutup bersamaan tidak sama-sama melewatkan
+	// pemotongan bahan
+	var source entity.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&source, link.SourceOrderID).Error; err != nil {
+		return err
+	}
+	var siblings []entity.Order
+	if err := tx.Select("orders.id", "orders.status").
+		Joins("JOIN order_links l ON l.result_order_id = orders.id").
+		Where("l.source_order_id = ? AND l.link_type = ? AND orders.id <> ?", source.ID, entity.OrderLinkSplitEven, share.ID).
+		Find(&siblings).Error; err != nil {
+		return err
+	}
+
+	sold := toStatus == entity.OrderStatusPaid
+	for _, sibling := range siblings {
+		if isOpenOrderStatus(sibling.Status) {
+			return nil
+		}
+		if sibling.Status == entity.OrderStatusPaid || sibling.Status == entity.OrderStatusRefunded {
+			sold = true
+		}
+	}
+	if !sold {
+		return nil
+	}
+	return r.deductIngredients(tx, &source, changedBy)
+}
+
+// recordSaleCosts membagi nilai pergerakan stok sale ke setiap produk sesuai porsi quantity bahan
+// yang dipakainya
+func (r *orderRepository) recordSaleCosts(tx *gorm.DB, orderID uint, movement *entity.StockMovement, products map[uint]float64) error {
+	total := -movement.Delta
+	if total <= 0 || len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uint, 0, len(products))
+	for productID := range products {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	costs := make([]entity.SaleCost, 0, len(productIDs))
+	for _, productID := range productIDs {
+		share := products[productID] / total
+		costs = append(costs, entity.SaleCost{
+			OrderID:         orderID,
+			ProductID:       productID,
+			InventoryID:     movement.InventoryID,
+			StockMovementID: movement.ID,
+			Quantity:        products[productID],
+			FifoCost:        roundCost(-movement.FifoValue * share),
+			AverageCost:     roundCost(-movement.AverageValue * share),
+		})
+	}
+	return tx.Create(&costs).Error
+}
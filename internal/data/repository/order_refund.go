@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrOrderNotRefundable dikembalikan jika order belum dibayar atau sudah di-refund penuh
+	ErrOrderNotRefundable = errors.New("order tidak dapat di-refund")
+	// ErrInvalidRefund dikembalikan jika item atau quantity refund tidak valid
+	ErrInvalidRefund = errors.New("refund tidak valid")
+)
+
+// Void membatalkan order yang belum dibayar dan mencatatnya di order_refunds beserta alasan
+// dan manager yang menyetujui. Nominal order tetap tersimpan sebagai nominal void.
+// Jika restock bernilai false (contoh makanan sudah dimasak), stok item tidak dikembalikan.
+func (r *orderRepository) Void(ctx context.Context, id uint, fromStatus string, req dto.OrderVoidRequest) (*entity.OrderRefund, error) {
+	r.logger.Info("Voiding order",
+		zap.Uint("id", id),
+		zap.String("reason_code", req.ReasonCode),
+		zap.Uint("approved_by", req.ApprovedBy))
+
+	restock := req.Restock == nil || *req.Restock
+	record := entity.OrderRefund{
+		OrderID:    id,
+		Type:       entity.OrderRefundTypeVoid,
+		ReasonCode: req.ReasonCode,
+		Note:       req.Note,
+		Restock:    restock,
+		ApprovedBy: req.ApprovedBy,
+	}
+	if req.ProcessedBy != 0 {
+		record.ProcessedBy = &req.ProcessedBy
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != fromStatus {
+			return fmt.Errorf("%w: status saat ini %s", ErrOrderStatusConflict, order.Status)
+		}
+		if order.PaidAmount > 0 {
+			return fmt.Errorf("%w: order sudah menerima pembayaran, gunakan refund", ErrOrderNotEditable)
+		}
+
+		record.Amount = order.TotalAmount
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+
+		if !restock {
+			// Tanpa item, perubahan status ke cancelled tidak mengembalikan stok
+			order.Items = nil
+		}
+		return r.changeStatus(tx, &order, entity.OrderStatusCancelled, req.ApprovedBy, refundNote("Void", req.ReasonCode, req.Note))
+	})
+	if err != nil {
+		r.logger.Error("Failed to void order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully voided order",
+		zap.Uint("id", id),
+		zap.Float64("amount", record.Amount))
+	return &record, nil
+}
+
+// Refund mengembalikan uang order yang sudah dibayar, penuh (tanpa items) atau sebagian per item.
+// Nominal refund item dihitung proporsional dari nilai item setelah diskon terhadap total order
+// (termasuk pajak), dan refund yang menghabiskan semua item mengambil seluruh sisa nominal agar
+// tidak ada selisih pembulatan. Jika seluruh nominal sudah di-refund, status order menjadi refunded.
+func (r *orderRepository) Refund(ctx context.Context, id uint, req dto.OrderRefundRequest) (*entity.OrderRefund, error) {
+	r.logger.Info("Refunding order",
+		zap.Uint("id", id),
+		zap.String("reason_code", req.ReasonCode),
+		zap.Int("items", len(req.Items)),
+		zap.Uint("approved_by", req.ApprovedBy))
+
+	record := entity.OrderRefund{
+		OrderID:    id,
+		Type:       entity.OrderRefundTypeRefund,
+		ReasonCode: req.ReasonCode,
+		Note:       req.Note,
+		Restock:    req.Restock,
+		ApprovedBy: req.ApprovedBy,
+	}
+	if req.ProcessedBy != 0 {
+		record.ProcessedBy = &req.ProcessedBy
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Components").First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != entity.OrderStatusPaid {
+			return fmt.Errorf("%w: status %s", ErrOrderNotRefundable, order.Status)
+		}
+		remaining := roundAmount(order.TotalAmount - order.RefundedAmount)
+		if remaining <= 0 {
+			return fmt.Errorf("%w: order sudah di-refund penuh", ErrOrderNotRefundable)
+		}
+
+		paymentMethodID := order.PaymentMethodID
+		if req.PaymentMethodID != 0 {
+			paymentMethodID = req.PaymentMethodID
+		}
+		var method entity.PaymentMethod
+		if err := tx.First(&method, paymentMethodID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: payment_method_id %d", ErrPaymentMethodNotFound, paymentMethodID)
+			}
+			return err
+		}
+		record.PaymentMethodID = &method.ID
+
+		refundable, err := refundableQuantities(tx, &order)
+		if err != nil {
+			return err
+		}
+
+		// Tanpa items berarti refund penuh atas semua item yang belum di-refund
+		requested := req.Items
+		if len(requested) == 0 {
+			for _, item := range order.Items {
+				if qty := refundable[item.ID]; qty > 0 {
+					requested = append(requested, dto.OrderRefundItemRequest{OrderItemID: item.ID, Quantity: qty})
+				}
+			}
+		} else if order.ShareCount > 0 {
+			return fmt.Errorf("%w: bagian split rata hanya bisa di-refund penuh", ErrInvalidRefund)
+		}
+
+		itemMap := make(map[uint]entity.OrderItem, len(order.Items))
+		for _, item := range order.Items {
+			itemMap[item.ID] = item
+		}
+		orderNet := order.Subtotal - order.DiscountAmount
+
+		var restockItems []entity.OrderItem
+		var amount float64
+		for _, reqItem := range requested {
+			item, ok := itemMap[reqItem.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: order_item_id %d bukan milik order %d", ErrInvalidRefund, reqItem.OrderItemID, id)
+			}
+			if reqItem.Quantity > refundable[item.ID] {
+				return fmt.Errorf("%w: quantity order_item_id %d melebihi %d", ErrInvalidRefund, item.ID, refundable[item.ID])
+			}
+			refundable[item.ID] -= reqItem.Quantity
+
+			var lineAmount float64
+			if orderNet > 0 && item.Quantity > 0 {
+				unitNet := (item.Subtotal - item.DiscountAmount) / float64(item.Quantity)
+				lineAmount = roundAmount(order.TotalAmount * unitNet * float64(reqItem.Quantity) / orderNet)
+			}
+			amount += lineAmount
+
+			record.Items = append(record.Items, entity.OrderRefundItem{
+				OrderItemID: item.ID,
+				ProductID:   item.ProductID,
+				Quantity:    reqItem.Quantity,
+				Amount:      lineAmount,
+			})
+			restockItems = append(restockItems, entity.OrderItem{ProductID: item.ProductID, VariantID: item.VariantID, Quantity: reqItem.Quantity, Components: item.Components})
+		}
+
+		fullyRefunded := true
+		for _, qty := range refundable {
+			if qty > 0 {
+				fullyRefunded = false
+				break
+			}
+		}
+		if fullyRefunded || amount > remaining {
+			amount = remaining
+		}
+		record.Amount = roundAmount(amount)
+		if record.Amount <= 0 {
+			return fmt.Errorf("%w: nominal refund nol", ErrInvalidRefund)
+		}
+
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+
+		if req.Restock {
+			if err := r.adjustStock(tx, restockItems, nil); err != nil {
+				return err
+			}
+		}
+
+		order.RefundedAmount = roundAmount(order.RefundedAmount + record.Amount)
+		if err := tx.Model(&entity.Order{}).Where("id = ?", id).Update("refunded_amount", order.RefundedAmount).Error; err != nil {
+			return err
+		}
+
+		if order.RefundedAmount < order.TotalAmount {
+			return nil
+		}
+		return r.changeStatus(tx, &order, entity.OrderStatusRefunded, req.ApprovedBy, refundNote("Refund", req.ReasonCode, req.Note))
+	})
+	if err != nil {
+		r.logger.Error("Failed to refund order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully refunded order",
+		zap.Uint("id", id),
+		zap.Float64("amount", record.Amount))
+	return &record, nil
+}
+
+// FindRefunds mengambil semua void/refund order, urut dari yang terlama
+func (r *orderRepository) FindRefunds(ctx context.Context, orderID uint) ([]entity.OrderRefund, error) {
+	r.logger.Info("Finding order refunds", zap.Uint("order_id", orderID))
+
+	var refunds []entity.OrderRefund
+	err := r.db.WithContext(ctx).Preload("Items").Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&refunds).Error
+	if err != nil {
+		r.logger.Error("Failed to find order refunds", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// refundableQuantities menghitung sisa quantity per order item yang belum di-refund
+func refundableQuantities(tx *gorm.DB, order *entity.Order) (map[uint]int, error) {
+	type refundedRow struct {
+		OrderItemID uint
+		Quantity    int
+	}
+	var rows []refundedRow
+	err := tx.Table("order_refund_items ri").
+		Select("ri.order_item_id, SUM(ri.quantity) as quantity").
+		Joins("JOIN order_refunds rf ON rf.id = ri.refund_id").
+		Where("rf.order_id = ?", order.ID).
+		Group("ri.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refundable := make(map[uint]int, len(order.Items))
+	for _, item := range order.Items {
+		refundable[item.ID] = item.Quantity
+	}
+	for _, row := range rows {
+		refundable[row.OrderItemID] -= row.Quantity
+	}
+	return refundable, nil
+}
+
+// refundNote menyusun catatan riwayat status untuk void/refund
+func refundNote(kind, reasonCode, note string) string {
+	if note == "" {
+		return fmt.Sprintf("%s (%s)", kind, reasonCode)
+	}
+	return fmt.Sprintf("%s (%s): %s", kind, reasonCode, note)
+}
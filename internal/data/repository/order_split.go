@@ -0,0 +1,393 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidSplit dikembalikan jika item atau jumlah bagian split tidak valid
+	ErrInvalidSplit = errors.New("pembagian order tidak valid")
+	// ErrInvalidMerge dikembalikan jika order yang digabung tidak valid
+	ErrInvalidMerge = errors.New("penggabungan order tidak valid")
+)
+
+// SplitItems memindahkan item terpilih (boleh sebagian quantity) dari order asal ke order baru.
+// Stok tidak berubah karena item hanya berpindah order, sedangkan tiket dapurnya ikut pindah. Promo, pajak, dan total kedua order
+// dihitung ulang memakai waktu order asal dibuat, sehingga promo happy hour tetap berlaku.
+func (r *orderRepository) SplitItems(ctx context.Context, id uint, req dto.OrderSplitItemsRequest) (*entity.Order, error) {
+	r.logger.Info("Splitting order items",
+		zap.Uint("id", id),
+		zap.Int("items", len(req.Items)))
+
+	var result entity.Order
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, err := r.lockRegroupOrder(tx, id)
+		if err != nil {
+			return err
+		}
+
+		itemMap := make(map[uint]int, len(source.Items))
+		for i, item := range source.Items {
+			itemMap[item.ID] = i
+		}
+
+		remaining := make([]entity.OrderItem, len(source.Items))
+		copy(remaining, source.Items)
+		var moved []entity.OrderItem
+		for _, reqItem := range req.Items {
+			index, ok := itemMap[reqItem.OrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: order_item_id %d bukan milik order %d", ErrInvalidSplit, reqItem.OrderItemID, id)
+			}
+			if reqItem.Quantity > remaining[index].Quantity {
+				return fmt.Errorf("%w: quantity order_item_id %d melebihi %d", ErrInvalidSplit, reqItem.OrderItemID, remaining[index].Quantity)
+			}
+
+			item := remaining[index]
+			item.Quantity = reqItem.Quantity
+			moved = append(moved, item)
+			remaining[index].Quantity -= reqItem.Quantity
+		}
+
+		kept := make([]entity.OrderItem, 0, len(remaining))
+		for _, item := range remaining {
+			if item.Quantity > 0 {
+				kept = append(kept, item)
+			}
+		}
+		if len(kept) == 0 {
+			return fmt.Errorf("%w: minimal satu item harus tetap di order asal", ErrInvalidSplit)
+		}
+
+		tableID := source.TableID
+		if req.TableID != 0 {
+			tableID = req.TableID
+		}
+		customerName := source.CustomerName
+		if req.CustomerName != "" {
+			customerName = req.CustomerName
+		}
+
+		result = entity.Order{
+			UserID:          source.UserID,
+			TableID:         tableID,
+			OrderType:       source.OrderType,
+			OutletID:        source.OutletID,
+			PaymentMethodID: source.PaymentMethodID,
+			CustomerName:    customerName,
+			Status:          source.Status,
+		}
+		if result.OrderType == entity.OrderTypeDineIn && tableID != source.TableID {
+			if err := r.occupyTable(tx, tableID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(clause.Associations).Create(&result).Error; err != nil {
+			return err
+		}
+
+		if err := r.repriceOrder(tx, source, withLineTotals(kept), source.CreatedAt); err != nil {
+			return err
+		}
+		if err := r.repriceOrder(tx, &result, withLineTotals(moved), source.CreatedAt); err != nil {
+			return err
+		}
+
+		// Tiket dapur item yang dipindah ikut pindah agar kedua order tetap maju otomatis ke served
+		if err := moveKitchenItems(tx, source.ID, result.ID, moved); err != nil {
+			return err
+		}
+		if err := r.advanceFromKitchen(tx, source.ID); err != nil {
+			return err
+		}
+		if err := r.advanceFromKitchen(tx, result.ID); err != nil {
+			return err
+		}
+
+		note := fmt.Sprintf("Split item dari order #%d", source.ID)
+		return r.recordRegroup(tx, []uint{source.ID}, []*entity.Order{&result}, entity.OrderLinkSplitItems, req.CreatedBy, note)
+	})
+	if err != nil {
+		r.logger.Error("Failed to split order items", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully split order items",
+		zap.Uint("id", id),
+		zap.Uint("new_order_id", result.ID))
+	return &result, nil
+}
+
+// SplitEven membagi order menjadi beberapa bagian dengan nominal sama. Order asal ditutup
+// dengan status split dan tetap memegang item serta tiket dapurnya (stok dan laporan produk tidak
+// berubah), sedangkan setiap bagian hanya membawa nominal subtotal, diskon, pajak, dan total.
+// Bahan resep order asal dipakai saat bagian terakhir ditutup (lihat deductSplitSource).
+// Sisa pembulatan dibebankan ke bagian terakhir agar jumlah semua bagian sama dengan order asal.
+func (r *orderRepository) SplitEven(ctx context.Context, id uint, parts int, createdBy uint) ([]entity.Order, error) {
+	r.logger.Info("Splitting order evenly",
+		zap.Uint("id", id),
+		zap.Int("parts", parts))
+
+	var shares []entity.Order
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		source, err := r.lockRegroupOrder(tx, id)
+		if err != nil {
+			return err
+		}
+		if parts < 2 {
+			return fmt.Errorf("%w: jumlah bagian minimal 2", ErrInvalidSplit)
+		}
+
+		subtotals := splitAmount(source.Subtotal, parts)
+		discounts := splitAmount(source.DiscountAmount, parts)
+		serviceCharges := splitAmount(source.ServiceCharge, parts)
+		taxes := splitAmount(source.Tax, parts)
+		totalAmounts := splitAmount(source.TotalAmount, parts)
+
+		taxLines := make([][]float64, len(source.Taxes))
+		baseLines := make([][]float64, len(source.Taxes))
+		for i, line := range source.Taxes {
+			taxLines[i] = splitAmount(line.Amount, parts)
+			baseLines[i] = splitAmount(line.BaseAmount, parts)
+		}
+
+		shares = make([]entity.Order, parts)
+		results := make([]*entity.Order, parts)
+		for n := 0; n < parts; n++ {
+			shares[n] = entity.Order{
+				UserID:           source.UserID,
+				TableID:          source.TableID,
+				OrderType:        source.OrderType,
+				OutletID:         source.OutletID,
+				PaymentMethodID:  source.PaymentMethodID,
+				CustomerName:     source.CustomerName,
+				Subtotal:         subtotals[n],
+				DiscountAmount:   discounts[n],
+				PromoCode:        source.PromoCode,
+				ServiceCharge:    serviceCharges[n],
+				Tax:              taxes[n],
+				PricesIncludeTax: source.PricesIncludeTax,
+				TotalAmount:      totalAmounts[n],
+				ShareIndex:       n + 1,
+				ShareCount:       parts,
+				Status:           source.Status,
+			}
+			if err := tx.Omit(clause.Associations).Create(&shares[n]).Error; err != nil {
+				return err
+			}
+
+			lines := make([]entity.OrderTax, 0, len(source.Taxes))
+			for i, line := range source.Taxes {
+				lines = append(lines, entity.OrderTax{
+					TaxRuleID:   line.TaxRuleID,
+					Name:        line.Name,
+					Type:        line.Type,
+					Rate:        line.Rate,
+					BaseAmount:  baseLines[i][n],
+					Amount:      taxLines[i][n],
+					IsInclusive: line.IsInclusive,
+				})
+			}
+			if err := replaceOrderTaxes(tx, shares[n].ID, lines); err != nil {
+				return err
+			}
+			shares[n].Taxes = lines
+			results[n] = &shares[n]
+		}
+
+		note := fmt.Sprintf("Dibagi rata menjadi %d bagian", parts)
+		if err := r.changeStatus(tx, source, entity.OrderStatusSplit, createdBy, note); err != nil {
+			return err
+		}
+
+		note = fmt.Sprintf("Split rata dari order #%d", source.ID)
+		return r.recordRegroup(tx, []uint{source.ID}, results, entity.OrderLinkSplitEven, createdBy, note)
+	})
+	if err != nil {
+		r.logger.Error("Failed to split order evenly", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully split order evenly",
+		zap.Uint("id", id),
+		zap.Int("parts", parts))
+	return shares, nil
+}
+
+// Merge menggabungkan item dari order sourceIDs ke order target (contoh dua meja jadi satu bill).
+// Order asal ditutup dengan status merged, kuota promonya dikembalikan, mejanya dilepas, dan tiket
+// dapurnya dipindah ke order target.
+// Promo, pajak, dan total order target dihitung ulang dengan kode promo order target.
+func (r *orderRepository) Merge(ctx context.Context, targetID uint, sourceIDs []uint, createdBy uint) (*entity.Order, error) {
+	r.logger.Info("Merging orders",
+		zap.Uint("target_id", targetID),
+		zap.Any("source_ids", sourceIDs))
+
+	var target *entity.Order
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Kunci semua order dengan urutan ID yang konsisten agar tidak saling deadlock
+		ids := append([]uint{targetID}, sourceIDs...)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		locked := make(map[uint]*entity.Order, len(ids))
+		for _, id := range ids {
+			if locked[id] != nil {
+				return fmt.Errorf("%w: order_id %d disebut lebih dari sekali", ErrInvalidMerge, id)
+			}
+			order, err := r.lockRegroupOrder(tx, id)
+			if err != nil {
+				return err
+			}
+			locked[id] = order
+		}
+		target = locked[targetID]
+
+		items := append([]entity.OrderItem(nil), target.Items...)
+		for _, id := range sourceIDs {
+			source := locked[id]
+			if source.OutletID != target.OutletID {
+				return fmt.Errorf("%w: order %d berasal dari outlet lain", ErrInvalidMerge, id)
+			}
+			items = append(items, source.Items...)
+
+			// Item pindah ke order target, kuota promo order asal dikembalikan
+			if err := releasePromotionUsage(tx, source.ID, false); err != nil {
+				return err
+			}
+			if err := tx.Where("order_id = ?", source.ID).Delete(&entity.OrderItem{}).Error; err != nil {
+				return err
+			}
+			note := fmt.Sprintf("Digabung ke order #%d", target.ID)
+			if err := r.changeStatus(tx, source, entity.OrderStatusMerged, createdBy, note); err != nil {
+				return err
+			}
+		}
+
+		if err := r.repriceOrder(tx, target, withLineTotals(items), target.CreatedAt); err != nil {
+			return err
+		}
+
+		// Tiket dapur ikut pindah agar order target maju otomatis ke served saat semuanya ready
+		if err := tx.Model(&entity.KitchenTicket{}).Where("order_id IN ?", sourceIDs).Update("order_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := r.advanceFromKitchen(tx, target.ID); err != nil {
+			return err
+		}
+
+		note := fmt.Sprintf("Gabungan dari %d order", len(sourceIDs))
+		return r.recordRegroup(tx, sourceIDs, []*entity.Order{target}, entity.OrderLinkMerge, createdBy, note)
+	})
+	if err != nil {
+		r.logger.Error("Failed to merge orders", zap.Uint("target_id", targetID), zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully merged orders",
+		zap.Uint("target_id", targetID),
+		zap.Int("sources", len(sourceIDs)))
+	return target, nil
+}
+
+// FindLinks mengambil relasi split/merge di mana order menjadi asal atau hasil
+func (r *orderRepository) FindLinks(ctx context.Context, orderID uint) ([]entity.OrderLink, error) {
+	r.logger.Info("Finding order links", zap.Uint("order_id", orderID))
+
+	var links []entity.OrderLink
+	err := r.db.WithContext(ctx).
+		Where("source_order_id = ? OR result_order_id = ?", orderID, orderID).
+		Order("created_at ASC, id ASC").
+		Find(&links).Error
+	if err != nil {
+		r.logger.Error("Failed to find order links", zap.Uint("order_id", orderID), zap.Error(err))
+		return nil, err
+	}
+	return links, nil
+}
+
+// lockRegroupOrder mengunci order yang akan di-split/merge. Order harus masih berjalan,
+// belum menerima pembayaran, dan bukan bagian dari split rata.
+func (r *orderRepository) lockRegroupOrder(tx *gorm.DB, id uint) (*entity.Order, error) {
+	var order entity.Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Items.Modifiers").Preload("Items.Components").Preload("Taxes").First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	if !isOpenOrderStatus(order.Status) || !order.IsEditable() {
+		return nil, fmt.Errorf("%w: order %d berstatus %s", ErrOrderNotEditable, id, order.Status)
+	}
+	if order.PaidAmount > 0 {
+		return nil, fmt.Errorf("%w: order %d sudah menerima pembayaran", ErrOrderNotEditable, id)
+	}
+	return &order, nil
+}
+
+// recordRegroup mencatat relasi order asal -> order hasil dan riwayat status awal order baru
+func (r *orderRepository) recordRegroup(tx *gorm.DB, sourceIDs []uint, results []*entity.Order, linkType string, createdBy uint, note string) error {
+	var creator *uint
+	if createdBy != 0 {
+		creator = &createdBy
+	}
+
+	links := make([]entity.OrderLink, 0, len(sourceIDs)*len(results))
+	for _, sourceID := range sourceIDs {
+		for _, result := range results {
+			links = append(links, entity.OrderLink{
+				SourceOrderID: sourceID,
+				ResultOrderID: result.ID,
+				LinkType:      linkType,
+				CreatedBy:     creator,
+			})
+		}
+	}
+	if err := tx.Create(&links).Error; err != nil {
+		return err
+	}
+
+	// Order target merge sudah punya riwayat, hanya order baru yang dicatat status awalnya
+	if linkType == entity.OrderLinkMerge {
+		return nil
+	}
+	for _, result := range results {
+		if err := tx.Create(&entity.OrderStatusHistory{
+			OrderID:   result.ID,
+			ToStatus:  result.Status,
+			ChangedBy: creator,
+			Note:      note,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withLineTotals menghitung ulang subtotal item setelah quantity berubah
+func withLineTotals(items []entity.OrderItem) []entity.OrderItem {
+	for i := range items {
+		items[i].Subtotal = items[i].Price * float64(items[i].Quantity)
+		items[i].DiscountAmount = 0
+		items[i].PromotionID = nil
+	}
+	return items
+}
+
+// splitAmount membagi nominal menjadi parts bagian yang sama (dibulatkan 2 desimal),
+// sisa pembulatan dimasukkan ke bagian terakhir
+func splitAmount(amount float64, parts int) []float64 {
+	shares := make([]float64, parts)
+	each := roundAmount(amount / float64(parts))
+	for i := range shares {
+		shares[i] = each
+	}
+	shares[parts-1] = roundAmount(amount - each*float64(parts-1))
+	return shares
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InsufficientStockError dikembalikan jika stok produk atau varian tidak mencukupi untuk order
+type InsufficientStockError struct {
+	ProductIDs []uint
+	VariantIDs []uint
+}
+
+func (e *InsufficientStockError) Error() string {
+	if len(e.VariantIDs) > 0 {
+		return fmt.Sprintf("stok tidak mencukupi untuk product_id: %v, variant_id: %v", e.ProductIDs, e.VariantIDs)
+	}
+	return fmt.Sprintf("stok tidak mencukupi untuk product_id: %v", e.ProductIDs)
+}
+
+// stockKey mengidentifikasi stok yang dipakai item order (variantID 0 = produk tanpa varian)
+type stockKey struct {
+	productID uint
+	variantID uint
+}
+
+// adjustStock menerapkan selisih quantity antara oldItems dan newItems ke stok varian dan Product.Stock.
+// Stok produk yang punya varian adalah total stok variannya, sehingga ikut disesuaikan.
+// Item bundle mengurangi stok komponennya (item.Components), bukan stok bundle.
+// Produk yang punya resep dibatasi oleh bahan inventory (lihat checkIngredients), sehingga
+// Product.Stock dan stok variannya tidak dicek maupun diubah.
+// Baris produk lalu varian dikunci (SELECT ... FOR UPDATE) dengan urutan ID yang konsisten agar order
+// yang berjalan bersamaan tidak bisa oversell dan tidak saling deadlock.
+// Gunakan oldItems=nil untuk mengurangi stok, dan newItems=nil untuk mengembalikan stok.
+func (r *orderRepository) adjustStock(tx *gorm.DB, oldItems, newItems []entity.OrderItem) error {
+	deltas := make(map[stockKey]int)
+	for _, item := range oldItems {
+		for key, qty := range itemStockUsage(item) {
+			deltas[key] -= qty
+		}
+	}
+	for _, item := range newItems {
+		for key, qty := range itemStockUsage(item) {
+			deltas[key] += qty
+		}
+	}
+
+	candidateIDs := make([]uint, 0, len(deltas))
+	for key := range deltas {
+		candidateIDs = append(candidateIDs, key.productID)
+	}
+	recipeProducts, err := recipeProductIDs(tx, candidateIDs)
+	if err != nil {
+		return err
+	}
+
+	productDeltas := make(map[uint]int)
+	variantDeltas := make(map[uint]int)
+	for key, delta := range deltas {
+		if delta == 0 || recipeProducts[key.productID] {
+			continue
+		}
+		productDeltas[key.productID] += delta
+		if key.variantID != 0 {
+			variantDeltas[key.variantID] += delta
+		}
+	}
+
+	productIDs := make([]uint, 0, len(productDeltas))
+	for id := range productDeltas {
+		productIDs = append(productIDs, id)
+	}
+	variantIDs := make([]uint, 0, len(variantDeltas))
+	for id := range variantDeltas {
+		variantIDs = append(variantIDs, id)
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+	sort.Slice(variantIDs, func(i, j int) bool { return variantIDs[i] < variantIDs[j] })
+
+	// Unscoped agar stok tetap bisa dikembalikan ke produk/varian yang sudah di-soft delete
+	var products []entity.Product
+	if err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", productIDs).
+		Order("id").
+		Find(&products).Error; err != nil {
+		return err
+	}
+	var variants []entity.ProductVariant
+	if len(variantIDs) > 0 {
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", variantIDs).
+			Order("id").
+			Find(&variants).Error; err != nil {
+			return err
+		}
+	}
+
+	// Item tanpa varian memakai stok produk, item dengan varian memakai stok variannya
+	var insufficientProducts, insufficientVariants []uint
+	for _, p := range products {
+		if delta := deltas[stockKey{productID: p.ID}]; delta > 0 && p.Stock < delta {
+			insufficientProducts = append(insufficientProducts, p.ID)
+		}
+	}
+	for _, v := range variants {
+		if delta := variantDeltas[v.ID]; delta > 0 && v.Stock < delta {
+			insufficientProducts = append(insufficientProducts, v.ProductID)
+			insufficientVariants = append(insufficientVariants, v.ID)
+		}
+	}
+	if len(insufficientProducts) > 0 {
+		return &InsufficientStockError{ProductIDs: insufficientProducts, VariantIDs: insufficientVariants}
+	}
+
+	now := time.Now()
+	for _, v := range variants {
+		newStock := v.Stock - variantDeltas[v.ID]
+		if err := tx.Unscoped().Model(&entity.ProductVariant{}).Where("id = ?", v.ID).UpdateColumns(stockColumns(v.Stock, newStock, now)).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, p := range products {
+		newStock := p.Stock - productDeltas[p.ID]
+		if err := tx.Unscoped().Model(&entity.Product{}).Where("id = ?", p.ID).UpdateColumns(stockColumns(p.Stock, newStock, now)).Error; err != nil {
+			return err
+		}
+
+		r.logger.Debug("Product stock adjusted",
+			zap.Uint("product_id", p.ID),
+			zap.Int("delta", -productDeltas[p.ID]),
+			zap.Int("stock", newStock))
+	}
+
+	return nil
+}
+
+// itemStockUsage mengembalikan quantity stok yang dipakai item order; untuk bundle berupa stok
+// setiap komponennya
+func itemStockUsage(item entity.OrderItem) map[stockKey]int {
+	if len(item.Components) == 0 {
+		return map[stockKey]int{itemStockKey(item): item.Quantity}
+	}
+	usage := make(map[stockKey]int, len(item.Components))
+	for _, c := range item.Components {
+		key := stockKey{productID: c.ProductID}
+		if c.VariantID != nil {
+			key.variantID = *c.VariantID
+		}
+		usage[key] += c.Quantity * item.Quantity
+	}
+	return usage
+}
+
+// itemStockKey mengembalikan stok yang dipakai item order (tanpa memperhitungkan komponen bundle)
+func itemStockKey(item entity.OrderItem) stockKey {
+	key := stockKey{productID: item.ProductID}
+	if item.VariantID != nil {
+		key.variantID = *item.VariantID
+	}
+	return key
+}
+
+// stockColumns membentuk kolom update stok produk/varian. is_available hanya diubah saat stok
+// melewati nol (habis -> tidak tersedia, terisi lagi -> tersedia), sehingga produk yang ditandai
+// tidak tersedia secara manual oleh manager tidak ikut diaktifkan saat stoknya dikembalikan.
+func stockColumns(oldStock, newStock int, now time.Time) map[string]interface{} {
+	columns := map[string]interface{}{
+		"stock":      newStock,
+		"updated_at": now,
+	}
+	switch {
+	case oldStock > 0 && newStock <= 0:
+		columns["is_available"] = false
+	case oldStock <= 0 && newStock > 0:
+		columns["is_available"] = true
+	}
+	return columns
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// occupyTable menandai meja sebagai occupied untuk order dine-in.
+// Meja yang sedang reserved boleh ditempati (tamu reservasi datang), tetapi meja
+// out_of_service ditolak.
+func (r *orderRepository) occupyTable(tx *gorm.DB, tableID uint) error {
+	var table entity.Table
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&table, tableID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: table_id %d", ErrTableNotFound, tableID)
+		}
+		return err
+	}
+	if table.Status == entity.TableStatusOutOfService {
+		return fmt.Errorf("%w: meja %s sedang out_of_service", ErrTableUnavailable, table.Number)
+	}
+	if table.Status == entity.TableStatusOccupied {
+		return nil
+	}
+
+	r.logger.Info("Table occupied by order",
+		zap.Uint("table_id", tableID),
+		zap.String("from_status", table.Status))
+
+	return tx.Model(&entity.Table{}).Where("id = ?", tableID).UpdateColumns(map[string]interface{}{
+		"status":         entity.TableStatusOccupied,
+		"reservation_id": nil,
+		"updated_at":     time.Now(),
+	}).Error
+}
+
+// releaseTableIfIdle mengembalikan meja menjadi available jika sudah tidak ada order
+// dine-in yang masih berjalan di meja tersebut. excludeOrderID adalah order yang sedang
+// ditutup dalam transaksi yang sama. Status selain occupied tidak disentuh.
+func (r *orderRepository) releaseTableIfIdle(tx *gorm.DB, tableID, excludeOrderID uint) error {
+	var table entity.Table
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&table, tableID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Meja sudah dihapus, tidak ada yang perlu dilepas
+			return nil
+		}
+		return err
+	}
+	if table.Status != entity.TableStatusOccupied {
+		return nil
+	}
+
+	var openOrders int64
+	if err := tx.Model(&entity.Order{}).
+		Where("table_id = ? AND id <> ? AND order_type = ? AND status IN ?",
+			tableID, excludeOrderID, entity.OrderTypeDineIn, entity.OpenOrderStatuses).
+		Count(&openOrders).Error; err != nil {
+		return err
+	}
+	if openOrders > 0 {
+		return nil
+	}
+
+	r.logger.Info("Table released", zap.Uint("table_id", tableID))
+
+	return tx.Model(&entity.Table{}).Where("id = ?", tableID).UpdateColumns(map[string]interface{}{
+		"status":     entity.TableStatusAvailable,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// isOpenOrderStatus mengecek apakah status order masih berjalan
+func isOpenOrderStatus(status string) bool {
+	for _, s := range entity.OpenOrderStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
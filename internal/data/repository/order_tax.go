@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"gorm.io/gorm"
+)
+
+// orderTotals adalah hasil perhitungan pajak dan total sebuah order
+type orderTotals struct {
+	Subtotal         float64
+	DiscountAmount   float64
+	ServiceCharge    float64
+	Tax              float64
+	TotalAmount      float64
+	PricesIncludeTax bool
+	Taxes            []entity.OrderTax
+}
+
+// apply menyalin hasil perhitungan ke order
+func (t orderTotals) apply(order *entity.Order) {
+	order.Subtotal = t.Subtotal
+	order.DiscountAmount = t.DiscountAmount
+	order.ServiceCharge = t.ServiceCharge
+	order.Tax = t.Tax
+	order.TotalAmount = t.TotalAmount
+	order.PricesIncludeTax = t.PricesIncludeTax
+}
+
+// calculateOrderTotals menghitung pajak dan service charge order berdasarkan aturan pajak
+// outlet dan kategori produk, dari subtotal item setelah diskon. Jika outlet memakai harga
+// inclusive, pajak diekstrak dari nilai item sehingga total order sama dengan subtotal dikurangi diskon.
+func (r *orderRepository) calculateOrderTotals(tx *gorm.DB, outletID uint, items []entity.OrderItem) (orderTotals, error) {
+	var outlet entity.Outlet
+	if err := tx.First(&outlet, outletID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return orderTotals{}, fmt.Errorf("%w: outlet_id %d", ErrOutletNotFound, outletID)
+		}
+		return orderTotals{}, err
+	}
+
+	var rules []entity.TaxRule
+	if err := tx.Where("is_active = ? AND (outlet_id IS NULL OR outlet_id = ?)", true, outletID).
+		Order("priority ASC, id ASC").
+		Find(&rules).Error; err != nil {
+		return orderTotals{}, err
+	}
+
+	// Kategori produk dibutuhkan untuk aturan per kategori
+	categoryOf, err := productCategories(tx, items)
+	if err != nil {
+		return orderTotals{}, err
+	}
+
+	return computeOrderTotals(rules, outlet.PricesIncludeTax, categoryOf, items), nil
+}
+
+// computeOrderTotals menghitung subtotal, diskon, pajak, service charge, dan total dari item order
+// dengan aturan pajak yang sudah urut prioritas; categoryOf memetakan product_id ke category_id
+func computeOrderTotals(rules []entity.TaxRule, pricesIncludeTax bool, categoryOf map[uint]uint, items []entity.OrderItem) orderTotals {
+	totals := orderTotals{PricesIncludeTax: pricesIncludeTax}
+	bases := make([]float64, len(rules))
+	amounts := make([]float64, len(rules))
+	applicable := make([]bool, len(rules))
+
+	for _, item := range items {
+		totals.Subtotal += item.Subtotal
+		totals.DiscountAmount += item.DiscountAmount
+		categoryID := categoryOf[item.ProductID]
+
+		net := item.Subtotal - item.DiscountAmount
+		if pricesIncludeTax {
+			// Harga sudah termasuk pajak: cari faktor total dengan net = 1, lalu ekstrak net sebenarnya
+			_, unitAmounts := applyTaxRules(rules, categoryID, 1)
+			factor := 1.0
+			for _, amount := range unitAmounts {
+				factor += amount
+			}
+			net = net / factor
+		}
+
+		itemBases, itemAmounts := applyTaxRules(rules, categoryID, net)
+		for i := range rules {
+			if rules[i].AppliesTo(categoryID) {
+				applicable[i] = true
+				bases[i] += itemBases[i]
+				amounts[i] += itemAmounts[i]
+			}
+		}
+	}
+
+	for i, rule := range rules {
+		if !applicable[i] {
+			continue
+		}
+		ruleID := rule.ID
+		line := entity.OrderTax{
+			TaxRuleID:   &ruleID,
+			Name:        rule.Name,
+			Type:        rule.Type,
+			Rate:        rule.Rate,
+			BaseAmount:  roundAmount(bases[i]),
+			Amount:      roundAmount(amounts[i]),
+			IsInclusive: pricesIncludeTax,
+		}
+		if line.Type == entity.TaxTypeServiceCharge {
+			totals.ServiceCharge += line.Amount
+		} else {
+			totals.Tax += line.Amount
+		}
+		totals.Taxes = append(totals.Taxes, line)
+	}
+
+	totals.Subtotal = roundAmount(totals.Subtotal)
+	totals.DiscountAmount = roundAmount(totals.DiscountAmount)
+	totals.ServiceCharge = roundAmount(totals.ServiceCharge)
+	totals.Tax = roundAmount(totals.Tax)
+	totals.TotalAmount = roundAmount(totals.Subtotal - totals.DiscountAmount)
+	if !pricesIncludeTax {
+		totals.TotalAmount = roundAmount(totals.TotalAmount + totals.ServiceCharge + totals.Tax)
+	}
+
+	return totals
+}
+
+// applyTaxRules menghitung dasar pengenaan dan nilai setiap aturan untuk satu item dengan nilai net.
+// Aturan compound dihitung dari net ditambah aturan sebelumnya yang berlaku untuk item tersebut.
+func applyTaxRules(rules []entity.TaxRule, categoryID uint, net float64) ([]float64, []float64) {
+	bases := make([]float64, len(rules))
+	amounts := make([]float64, len(rules))
+
+	var applied float64
+	for i := range rules {
+		if !rules[i].AppliesTo(categoryID) {
+			continue
+		}
+		base := net
+		if rules[i].IsCompound {
+			base += applied
+		}
+		bases[i] = base
+		amounts[i] = base * rules[i].Rate / 100
+		applied += amounts[i]
+	}
+
+	return bases, amounts
+}
+
+// replaceOrderTaxes mengganti rincian pajak order dengan hasil perhitungan terbaru
+func replaceOrderTaxes(tx *gorm.DB, orderID uint, taxes []entity.OrderTax) error {
+	if err := tx.Where("order_id = ?", orderID).Delete(&entity.OrderTax{}).Error; err != nil {
+		return err
+	}
+	if len(taxes) == 0 {
+		return nil
+	}
+	for i := range taxes {
+		taxes[i].OrderID = orderID
+	}
+	return tx.Create(&taxes).Error
+}
+
+// roundAmount membulatkan nominal ke 2 desimal
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
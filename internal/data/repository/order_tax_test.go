@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"math"
+	"testing"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestComputeOrderTotals(t *testing.T) {
+	tax := entity.TaxRule{ID: 1, Name: "PPN", Type: entity.TaxTypeTax, Rate: 10}
+	serviceCharge := entity.TaxRule{ID: 2, Name: "Service", Type: entity.TaxTypeServiceCharge, Rate: 5}
+	compoundTax := entity.TaxRule{ID: 3, Name: "PPN", Type: entity.TaxTypeTax, Rate: 10, IsCompound: true}
+	drinkTax := entity.TaxRule{ID: 4, Name: "Pajak minuman", Type: entity.TaxTypeTax, Rate: 20, CategoryID: uintPtr(2)}
+
+	tests := []struct {
+		name             string
+		rules            []entity.TaxRule
+		pricesIncludeTax bool
+		categoryOf       map[uint]uint
+		items            []entity.OrderItem
+		want             orderTotals
+		wantLines        int
+	}{
+		{
+			name:      "exclusive tax ditambahkan ke total",
+			rules:     []entity.TaxRule{tax},
+			items:     []entity.OrderItem{{ProductID: 1, Subtotal: 100}},
+			want:      orderTotals{Subtotal: 100, Tax: 10, TotalAmount: 110},
+			wantLines: 1,
+		},
+		{
+			name:      "exclusive tax dihitung setelah diskon",
+			rules:     []entity.TaxRule{tax},
+			items:     []entity.OrderItem{{ProductID: 1, Subtotal: 100, DiscountAmount: 20}},
+			want:      orderTotals{Subtotal: 100, DiscountAmount: 20, Tax: 8, TotalAmount: 88},
+			wantLines: 1,
+		},
+		{
+			name:      "compound tax dihitung dari net ditambah service charge",
+			rules:     []entity.TaxRule{serviceCharge, compoundTax},
+			items:     []entity.OrderItem{{ProductID: 1, Subtotal: 100}},
+			want:      orderTotals{Subtotal: 100, ServiceCharge: 5, Tax: 10.5, TotalAmount: 115.5},
+			wantLines: 2,
+		},
+		{
+			name:             "inclusive tax diekstrak dari harga",
+			rules:            []entity.TaxRule{tax},
+			pricesIncludeTax: true,
+			items:            []entity.OrderItem{{ProductID: 1, Subtotal: 110}},
+			want:             orderTotals{Subtotal: 110, Tax: 10, TotalAmount: 110, PricesIncludeTax: true},
+			wantLines:        1,
+		},
+		{
+			name:             "inclusive compound tax",
+			rules:            []entity.TaxRule{serviceCharge, compoundTax},
+			pricesIncludeTax: true,
+			items:            []entity.OrderItem{{ProductID: 1, Subtotal: 115.5}},
+			want:             orderTotals{Subtotal: 115.5, ServiceCharge: 5, Tax: 10.5, TotalAmount: 115.5, PricesIncludeTax: true},
+			wantLines:        2,
+		},
+		{
+			name:  "pajak per item dibulatkan setelah dijumlahkan",
+			rules: []entity.TaxRule{tax},
+			items: []entity.OrderItem{
+				{ProductID: 1, Subtotal: 33.33},
+				{ProductID: 1, Subtotal: 33.33},
+				{ProductID: 1, Subtotal: 33.33},
+			},
+			want:      orderTotals{Subtotal: 99.99, Tax: 10, TotalAmount: 109.99},
+			wantLines: 1,
+		},
+		{
+			name:       "aturan kategori hanya untuk item di kategori tersebut",
+			rules:      []entity.TaxRule{tax, drinkTax},
+			categoryOf: map[uint]uint{1: 1, 2: 2},
+			items: []entity.OrderItem{
+				{ProductID: 1, Subtotal: 100},
+				{ProductID: 2, Subtotal: 50},
+			},
+			want:      orderTotals{Subtotal: 150, Tax: 25, TotalAmount: 175},
+			wantLines: 2,
+		},
+		{
+			name:       "aturan kategori yang tidak dipakai item tidak dicatat",
+			rules:      []entity.TaxRule{tax, drinkTax},
+			categoryOf: map[uint]uint{1: 1},
+			items:      []entity.OrderItem{{ProductID: 1, Subtotal: 100}},
+			want:       orderTotals{Subtotal: 100, Tax: 10, TotalAmount: 110},
+			wantLines:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeOrderTotals(tt.rules, tt.pricesIncludeTax, tt.categoryOf, tt.items)
+
+			if got.Subtotal != tt.want.Subtotal || got.DiscountAmount != tt.want.DiscountAmount ||
+				got.ServiceCharge != tt.want.ServiceCharge || got.Tax != tt.want.Tax ||
+				got.TotalAmount != tt.want.TotalAmount || got.PricesIncludeTax != tt.want.PricesIncludeTax {
+				t.Errorf("computeOrderTotals() = %+v, want %+v", got, tt.want)
+			}
+			if len(got.Taxes) != tt.wantLines {
+				t.Errorf("computeOrderTotals() tax lines = %d, want %d", len(got.Taxes), tt.wantLines)
+			}
+			for _, line := range got.Taxes {
+				if line.IsInclusive != tt.pricesIncludeTax {
+					t.Errorf("tax line %s IsInclusive = %v, want %v", line.Name, line.IsInclusive, tt.pricesIncludeTax)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyTaxRules(t *testing.T) {
+	rules := []entity.TaxRule{
+		{Name: "Service", Type: entity.TaxTypeServiceCharge, Rate: 5},
+		{Name: "PPN", Type: entity.TaxTypeTax, Rate: 10, IsCompound: true},
+		{Name: "Pajak minuman", Type: entity.TaxTypeTax, Rate: 20, CategoryID: uintPtr(2)},
+	}
+
+	tests := []struct {
+		name        string
+		categoryID  uint
+		net         float64
+		wantBases   []float64
+		wantAmounts []float64
+	}{
+		{
+			name:        "compound memakai net ditambah aturan sebelumnya",
+			categoryID:  1,
+			net:         200,
+			wantBases:   []float64{200, 210, 0},
+			wantAmounts: []float64{10, 21, 0},
+		},
+		{
+			name:        "aturan kategori ikut dihitung",
+			categoryID:  2,
+			net:         100,
+			wantBases:   []float64{100, 105, 100},
+			wantAmounts: []float64{5, 10.5, 20},
+		},
+		{
+			name:        "net nol",
+			categoryID:  1,
+			net:         0,
+			wantBases:   []float64{0, 0, 0},
+			wantAmounts: []float64{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bases, amounts := applyTaxRules(rules, tt.categoryID, tt.net)
+			for i := range rules {
+				if math.Abs(bases[i]-tt.wantBases[i]) > 1e-9 {
+					t.Errorf("base[%d] = %v, want %v", i, bases[i], tt.wantBases[i])
+				}
+				if math.Abs(amounts[i]-tt.wantAmounts[i]) > 1e-9 {
+					t.Errorf("amount[%d] = %v, want %v", i, amounts[i], tt.wantAmounts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundAmount(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want float64
+	}{
+		{12.3456, 12.35},
+		{12.344, 12.34},
+		{0.1 + 0.2, 0.3},
+		{-1.236, -1.24},
+		{5, 5},
+	}
+
+	for _, tt := range tests {
+		if got := roundAmount(tt.in); got != tt.want {
+			t.Errorf("roundAmount(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
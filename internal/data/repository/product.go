@@ -8,15 +8,27 @@ import (
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
 	FindAll(ctx context.Context, f dto.ProductFilterRequest) ([]entity.Product, int64, error)
 	Create(ctx context.Context, product *entity.Product) error
-	Update(ctx context.Context, product *entity.Product) error
+	Update(ctx context.Context, product *entity.Product, base ProductStockBase) error
 	Detail(ctx context.Context, id uint) (*entity.Product, error)
 	Delete(ctx context.Context, id uint) error
 	FindByItemID(ctx context.Context, itemID string) (*entity.Product, error)
+	VariantSKUExists(ctx context.Context, sku string, excludeID uint) (bool, error)
+	ClaimLowStock(ctx context.Context) ([]entity.Product, error)
+	ClearRestockedAlerts(ctx context.Context) (int64, error)
+}
+
+// ProductStockBase adalah stok produk dan varian saat dibaca sebelum diubah. Update menyimpan stok
+// sebagai selisih terhadap nilai ini di atas stok terkini, sehingga stok yang dikurangi order di
+// antara baca dan simpan tidak tertimpa.
+type ProductStockBase struct {
+	Stock    int
+	Variants map[uint]int // variant ID -> stok
 }
 
 type productRepository struct {
@@ -39,10 +51,12 @@ func (r *productRepository) FindAll(ctx context.Context, f dto.ProductFilterRequ
 
 	query := r.db.WithContext(ctx).Model(&entity.Product{})
 
-	// Search filter
+	// Search filter (termasuk SKU dan nama varian)
 	if f.Search != "" {
 		searchPattern := "%" + f.Search + "%"
-		query = query.Where("product_name ILIKE ? OR item_id ILIKE ?", searchPattern, searchPattern)
+		query = query.Where(
+			"product_name ILIKE ? OR item_id ILIKE ? OR EXISTS (SELECT 1 FROM product_variants pv WHERE pv.product_id = products.id AND pv.deleted_at IS NULL AND (pv.sku ILIKE ? OR pv.name ILIKE ?))",
+			searchPattern, searchPattern, searchPattern, searchPattern)
 	}
 
 	// Category filter
@@ -89,8 +103,8 @@ func (r *productRepository) FindAll(ctx context.Context, f dto.ProductFilterRequ
 
 	offset := (f.Page - 1) * f.Limit
 
-	// Preload Category
-	err := query.Preload("Category").Limit(f.Limit).Offset(offset).Find(&products).Error
+	// Preload Category, Variants, dan komponen bundle
+	err := preloadProductDetail(query).Limit(f.Limit).Offset(offset).Find(&products).Error
 	if err != nil {
 		r.logger.Error("Failed to find all products", zap.Error(err))
 		return nil, 0, err
@@ -108,7 +122,15 @@ func (r *productRepository) Create(ctx context.Context, product *entity.Product)
 		zap.String("product_name", product.ProductName),
 		zap.String("item_id", product.ItemID))
 
-	err := r.db.WithContext(ctx).Create(product).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Variants", "BundleSlots", "Recipe").Create(product).Error; err != nil {
+			return err
+		}
+		if err := r.saveVariants(tx, product); err != nil {
+			return err
+		}
+		return r.saveBundleSlots(tx, product)
+	})
 	if err != nil {
 		r.logger.Error("Failed to create product",
 			zap.String("product_name", product.ProductName),
@@ -122,12 +144,23 @@ func (r *productRepository) Create(ctx context.Context, product *entity.Product)
 	return nil
 }
 
-func (r *productRepository) Update(ctx context.Context, product *entity.Product) error {
+func (r *productRepository) Update(ctx context.Context, product *entity.Product, base ProductStockBase) error {
 	r.logger.Info("Updating product",
 		zap.Uint("id", product.ID),
 		zap.String("product_name", product.ProductName))
 
-	err := r.db.WithContext(ctx).Save(product).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockStockDelta(tx, product, base); err != nil {
+			return err
+		}
+		if err := tx.Omit("Variants", "BundleSlots", "Recipe").Save(product).Error; err != nil {
+			return err
+		}
+		if err := r.saveVariants(tx, product); err != nil {
+			return err
+		}
+		return r.saveBundleSlots(tx, product)
+	})
 	if err != nil {
 		r.logger.Error("Failed to update product",
 			zap.Uint("id", product.ID),
@@ -143,7 +176,7 @@ func (r *productRepository) Detail(ctx context.Context, id uint) (*entity.Produc
 	r.logger.Info("Getting product detail", zap.Uint("id", id))
 
 	var product entity.Product
-	err := r.db.WithContext(ctx).Preload("Category").First(&product, id).Error
+	err := preloadProductDetail(r.db.WithContext(ctx)).First(&product, id).Error
 	if err != nil {
 		r.logger.Error("Failed to get product detail",
 			zap.Uint("id", id),
@@ -160,8 +193,19 @@ func (r *productRepository) Detail(ctx context.Context, id uint) (*entity.Produc
 func (r *productRepository) Delete(ctx context.Context, id uint) error {
 	r.logger.Info("Deleting product", zap.Uint("id", id))
 
-	// Soft delete
-	err := r.db.WithContext(ctx).Delete(&entity.Product{}, id).Error
+	// Soft delete beserta variannya; produk juga dilepas dari slot bundle yang memakainya
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", id).Delete(&entity.ProductVariant{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ?", id).Delete(&entity.BundleSlotOption{}).Error; err != nil {
+			return err
+		}
+		if err := deleteBundleSlots(tx, id); err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Product{}, id).Error
+	})
 	if err != nil {
 		r.logger.Error("Failed to delete product",
 			zap.Uint("id", id),
@@ -190,3 +234,157 @@ func (r *productRepository) FindByItemID(ctx context.Context, itemID string) (*e
 		zap.String("item_id", product.ItemID))
 	return &product, nil
 }
+
+// VariantSKUExists mengecek apakah SKU sudah dipakai varian lain (termasuk varian yang sudah dihapus,
+// karena SKU tetap unik di tabel)
+func (r *productRepository) VariantSKUExists(ctx context.Context, sku string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Unscoped().Model(&entity.ProductVariant{}).Where("sku = ?", sku)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		r.logger.Error("Failed to check variant sku", zap.String("sku", sku), zap.Error(err))
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// lockStockDelta mengunci baris produk dan variannya (urutan sama dengan adjustStock), lalu mengganti
+// stok di product menjadi stok terkini ditambah perubahan yang diminta terhadap base. Stok produk
+// yang punya varian tetap total stok variannya.
+func (r *productRepository) lockStockDelta(tx *gorm.DB, product *entity.Product, base ProductStockBase) error {
+	var current entity.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock").
+		First(&current, product.ID).Error; err != nil {
+		return err
+	}
+	var variants []entity.ProductVariant
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "stock").
+		Where("product_id = ?", product.ID).
+		Order("id").
+		Find(&variants).Error; err != nil {
+		return err
+	}
+	currentVariants := make(map[uint]int, len(variants))
+	for _, v := range variants {
+		currentVariants[v.ID] = v.Stock
+	}
+
+	if len(product.Variants) == 0 {
+		product.Stock = applyStockDelta(current.Stock, base.Stock, product.Stock)
+		return nil
+	}
+	product.Stock = 0
+	for i := range product.Variants {
+		v := &product.Variants[i]
+		if stock, ok := currentVariants[v.ID]; ok && v.ID != 0 {
+			v.Stock = applyStockDelta(stock, base.Variants[v.ID], v.Stock)
+		}
+		product.Stock += v.Stock
+	}
+	return nil
+}
+
+// applyStockDelta mengembalikan stok terkini ditambah perubahan yang diminta (requested - base).
+// Hasil tidak pernah negatif, sama seperti stok yang dijaga adjustStock.
+func applyStockDelta(current, base, requested int) int {
+	stock := current + requested - base
+	if stock < 0 {
+		return 0
+	}
+	return stock
+}
+
+// saveVariants menyimpan varian produk. Varian dengan ID diperbarui, varian tanpa ID ditambahkan,
+// dan varian lama yang tidak dikirim dihapus (soft delete, riwayat order tetap menyimpan namanya).
+func (r *productRepository) saveVariants(tx *gorm.DB, product *entity.Product) error {
+	keep := make([]uint, 0, len(product.Variants))
+	for i := range product.Variants {
+		variant := &product.Variants[i]
+		variant.ProductID = product.ID
+		if variant.ID == 0 {
+			if err := tx.Create(variant).Error; err != nil {
+				return err
+			}
+		} else {
+			variant.IsAvailable = variant.Stock > 0
+			result := tx.Model(&entity.ProductVariant{}).
+				Where("id = ? AND product_id = ?", variant.ID, product.ID).
+				Updates(map[string]interface{}{
+					"sku":          variant.SKU,
+					"name":         variant.Name,
+					"price":        variant.Price,
+					"stock":        variant.Stock,
+					"is_available": variant.IsAvailable,
+					"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		keep = append(keep, variant.ID)
+	}
+
+	query := tx.Where("product_id = ?", product.ID)
+	if len(keep) > 0 {
+		query = query.Where("id NOT IN ?", keep)
+	}
+	return query.Delete(&entity.ProductVariant{}).Error
+}
+
+// saveBundleSlots mengganti seluruh slot komponen bundle. Order menyimpan snapshot komponennya
+// sendiri, sehingga slot lama boleh dihapus permanen.
+func (r *productRepository) saveBundleSlots(tx *gorm.DB, product *entity.Product) error {
+	if err := deleteBundleSlots(tx, product.ID); err != nil {
+		return err
+	}
+	for i := range product.BundleSlots {
+		slot := &product.BundleSlots[i]
+		slot.ID = 0
+		slot.BundleID = product.ID
+		if err := tx.Omit(clause.Associations).Create(slot).Error; err != nil {
+			return err
+		}
+		for j := range slot.Options {
+			slot.Options[j].ID = 0
+			slot.Options[j].SlotID = slot.ID
+		}
+		if len(slot.Options) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&slot.Options).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteBundleSlots menghapus slot bundle beserta opsinya
+func deleteBundleSlots(tx *gorm.DB, bundleID uint) error {
+	slotIDs := tx.Model(&entity.BundleSlot{}).Select("id").Where("bundle_id = ?", bundleID)
+	if err := tx.Where("slot_id IN (?)", slotIDs).Delete(&entity.BundleSlotOption{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("bundle_id = ?", bundleID).Delete(&entity.BundleSlot{}).Error
+}
+
+// preloadProductDetail memuat kategori, varian, komponen bundle, dan resep produk
+func preloadProductDetail(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Category").
+		Preload("Variants", orderByID).
+		Preload("BundleSlots", orderByID).
+		Preload("BundleSlots.Options", orderByID).
+		Preload("BundleSlots.Options.Product").
+		Preload("BundleSlots.Options.Variant").
+		Preload("Recipe.Inventory.PackSizes")
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
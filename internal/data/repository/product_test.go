@@ -0,0 +1,28 @@
+package repository
+
+import "testing"
+
+func TestApplyStockDelta(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   int
+		base      int
+		requested int
+		want      int
+	}{
+		{name: "tanpa perubahan stok", current: 10, base: 10, requested: 10, want: 10},
+		{name: "restock tanpa order di antaranya", current: 10, base: 10, requested: 25, want: 25},
+		{name: "pengurangan order di antaranya tetap terhitung", current: 7, base: 10, requested: 25, want: 22},
+		{name: "stok tidak diubah tetapi ada order di antaranya", current: 7, base: 10, requested: 10, want: 7},
+		{name: "koreksi turun", current: 10, base: 10, requested: 4, want: 4},
+		{name: "tidak pernah negatif", current: 3, base: 10, requested: 4, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyStockDelta(tt.current, tt.base, tt.requested); got != tt.want {
+				t.Errorf("applyStockDelta(%d, %d, %d) = %d, want %d", tt.current, tt.base, tt.requested, got, tt.want)
+			}
+		})
+	}
+}
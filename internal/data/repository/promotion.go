@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	FindAll(ctx context.Context, activeOnly bool) ([]entity.Promotion, error)
+	FindByID(ctx context.Context, id uint) (*entity.Promotion, error)
+	FindByCode(ctx context.Context, code string) (*entity.Promotion, error)
+	Create(ctx context.Context, promotion *entity.Promotion) error
+	Update(ctx context.Context, promotion *entity.Promotion) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type promotionRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewPromotionRepository(db *gorm.DB, logger *zap.Logger) PromotionRepository {
+	return &promotionRepository{db, logger}
+}
+
+func (r *promotionRepository) FindAll(ctx context.Context, activeOnly bool) ([]entity.Promotion, error) {
+	r.logger.Info("Finding all promotions", zap.Bool("active_only", activeOnly))
+
+	query := r.db.WithContext(ctx).Order("id DESC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var promotions []entity.Promotion
+	if err := query.Find(&promotions).Error; err != nil {
+		r.logger.Error("Failed to find all promotions", zap.Error(err))
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) FindByID(ctx context.Context, id uint) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, id).Error; err != nil {
+		r.logger.Error("Failed to find promotion by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// FindByCode mencari promo berdasarkan kode (tidak case sensitive)
+func (r *promotionRepository) FindByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := r.db.WithContext(ctx).Where("UPPER(code) = UPPER(?)", code).First(&promotion).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	r.logger.Info("Creating new promotion", zap.String("name", promotion.Name))
+
+	if err := r.db.WithContext(ctx).Create(promotion).Error; err != nil {
+		r.logger.Error("Failed to create promotion", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Update menyimpan semua field promo kecuali usage_count yang hanya diubah oleh order
+func (r *promotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	r.logger.Info("Updating promotion", zap.Uint("id", promotion.ID))
+
+	err := r.db.WithContext(ctx).Select("*").Omit("id", "usage_count", "created_at", "deleted_at").Updates(promotion).Error
+	if err != nil {
+		r.logger.Error("Failed to update promotion", zap.Uint("id", promotion.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Delete melakukan soft delete promo dan melepas kodenya agar bisa dipakai promo baru
+func (r *promotionRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting promotion", zap.Uint("id", id))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Promotion{}).Where("id = ?", id).UpdateColumn("code", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Promotion{}, id).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to delete promotion", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
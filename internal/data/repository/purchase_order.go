@@ -0,0 +1,449 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrPurchaseOrderNotEditable dikembalikan jika purchase order bukan draft lagi
+	ErrPurchaseOrderNotEditable = errors.New("purchase order sudah tidak bisa diubah")
+	// ErrPurchaseOrderStatusConflict dikembalikan jika status purchase order berubah atau tidak sesuai
+	ErrPurchaseOrderStatusConflict = errors.New("status purchase order tidak sesuai")
+	// ErrInvalidGoodsReceipt dikembalikan jika item penerimaan barang tidak sesuai purchase order
+	ErrInvalidGoodsReceipt = errors.New("penerimaan barang tidak valid")
+)
+
+// openPurchaseOrderStatuses berisi status purchase order yang barangnya masih ditunggu
+var openPurchaseOrderStatuses = []string{
+	entity.PurchaseOrderStatusDraft,
+	entity.PurchaseOrderStatusSent,
+	entity.PurchaseOrderStatusPartiallyReceived,
+}
+
+// PurchaseOrderFilter berisi filter daftar purchase order
+type PurchaseOrderFilter struct {
+	Status     string
+	SupplierID uint
+}
+
+// ReorderCandidate berisi inventory di bawah MinStock beserta barang yang sudah dipesan dan
+// harga/supplier penerimaan terakhir
+type ReorderCandidate struct {
+	Inventory        entity.Inventories
+	OnOrder          float64
+	LastSupplierID   *uint
+	LastSupplierName string
+	LastUnitCost     float64
+}
+
+type PurchaseOrderRepository interface {
+	FindAll(ctx context.Context, filter PurchaseOrderFilter) ([]entity.PurchaseOrder, error)
+	FindByID(ctx context.Context, id uint) (*entity.PurchaseOrder, error)
+	Create(ctx context.Context, po *entity.PurchaseOrder) error
+	UpdateDraft(ctx context.Context, po *entity.PurchaseOrder) error
+	Delete(ctx context.Context, id uint) error
+	ChangeStatus(ctx context.Context, id uint, fromStatuses []string, toStatus string) error
+	Receive(ctx context.Context, id uint, receipt *entity.GoodsReceipt) error
+	FindReorderCandidates(ctx context.Context) ([]ReorderCandidate, error)
+}
+
+type purchaseOrderRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB, logger *zap.Logger) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db, logger}
+}
+
+// preloadPurchaseOrder memuat supplier, item (termasuk inventory yang sudah dihapus) dan penerimaan barang
+func preloadPurchaseOrder(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Supplier", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Receipts", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Receipts.Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") })
+}
+
+func (r *purchaseOrderRepository) FindAll(ctx context.Context, filter PurchaseOrderFilter) ([]entity.PurchaseOrder, error) {
+	r.logger.Info("Finding all purchase orders",
+		zap.String("status", filter.Status),
+		zap.Uint("supplier_id", filter.SupplierID))
+
+	query := r.db.WithContext(ctx).
+		Preload("Supplier", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("id DESC")
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.SupplierID != 0 {
+		query = query.Where("supplier_id = ?", filter.SupplierID)
+	}
+
+	var orders []entity.PurchaseOrder
+	if err := query.Find(&orders).Error; err != nil {
+		r.logger.Error("Failed to find all purchase orders", zap.Error(err))
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *purchaseOrderRepository) FindByID(ctx context.Context, id uint) (*entity.PurchaseOrder, error) {
+	var po entity.PurchaseOrder
+	if err := preloadPurchaseOrder(r.db.WithContext(ctx)).First(&po, id).Error; err != nil {
+		r.logger.Error("Failed to find purchase order by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &po, nil
+}
+
+// Create menyimpan purchase order baru beserta item-nya dan memberi nomor PO dari ID
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *entity.PurchaseOrder) error {
+	r.logger.Info("Creating purchase order",
+		zap.Uint("supplier_id", po.SupplierID),
+		zap.Int("items", len(po.Items)))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Supplier", "Items", "Receipts").Create(po).Error; err != nil {
+			return err
+		}
+		po.PONumber = entity.PurchaseOrderNumber(po.ID)
+		if err := tx.Model(po).UpdateColumn("po_number", po.PONumber).Error; err != nil {
+			return err
+		}
+		return savePurchaseOrderItems(tx, po)
+	})
+	if err != nil {
+		r.logger.Error("Failed to create purchase order", zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Purchase order created", zap.Uint("id", po.ID), zap.String("po_number", po.PONumber))
+	return nil
+}
+
+// UpdateDraft mengganti supplier, catatan, dan item purchase order yang masih draft
+func (r *purchaseOrderRepository) UpdateDraft(ctx context.Context, po *entity.PurchaseOrder) error {
+	r.logger.Info("Updating purchase order", zap.Uint("id", po.ID))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, po.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != entity.PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: status %s", ErrPurchaseOrderNotEditable, current.Status)
+		}
+
+		if err := tx.Model(&current).Updates(map[string]interface{}{
+			"supplier_id":   po.SupplierID,
+			"expected_date": po.ExpectedDate,
+			"note":          po.Note,
+			"total_amount":  po.TotalAmount,
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("purchase_order_id = ?", po.ID).Delete(&entity.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+		return savePurchaseOrderItems(tx, po)
+	})
+	if err != nil {
+		r.logger.Error("Failed to update purchase order", zap.Uint("id", po.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// savePurchaseOrderItems menyimpan item purchase order baru
+func savePurchaseOrderItems(tx *gorm.DB, po *entity.PurchaseOrder) error {
+	for i := range po.Items {
+		po.Items[i].ID = 0
+		po.Items[i].PurchaseOrderID = po.ID
+		po.Items[i].ReceivedQuantity = 0
+	}
+	if len(po.Items) == 0 {
+		return nil
+	}
+	return tx.Omit("Inventory").Create(&po.Items).Error
+}
+
+// Delete menghapus purchase order draft beserta item-nya
+func (r *purchaseOrderRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting purchase order", zap.Uint("id", id))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po entity.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, id).Error; err != nil {
+			return err
+		}
+		if po.Status != entity.PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: status %s", ErrPurchaseOrderNotEditable, po.Status)
+		}
+		if err := tx.Where("purchase_order_id = ?", id).Delete(&entity.PurchaseOrderItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.PurchaseOrder{}, id).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to delete purchase order", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ChangeStatus mengubah status purchase order jika status saat ini ada di fromStatuses
+func (r *purchaseOrderRepository) ChangeStatus(ctx context.Context, id uint, fromStatuses []string, toStatus string) error {
+	r.logger.Info("Changing purchase order status", zap.Uint("id", id), zap.String("to_status", toStatus))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po entity.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, id).Error; err != nil {
+			return err
+		}
+		allowed := false
+		for _, status := range fromStatuses {
+			if po.Status == status {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: tidak bisa mengubah %s menjadi %s", ErrPurchaseOrderStatusConflict, po.Status, toStatus)
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{"status": toStatus, "updated_at": now}
+		if toStatus == entity.PurchaseOrderStatusSent {
+			updates["sent_at"] = now
+		}
+		return tx.Model(&po).Updates(updates).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to change purchase order status", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Receive mencatat penerimaan barang: quantity inventory bertambah lewat pergerakan stok purchase
+// dengan harga satuan aktual, received_quantity item bertambah, dan status purchase order menjadi
+// partially_received atau received. Item penerimaan harus sudah berisi PurchaseOrderItemID,
+// Quantity, dan UnitCost.
+func (r *purchaseOrderRepository) Receive(ctx context.Context, id uint, receipt *entity.GoodsReceipt) error {
+	r.logger.Info("Receiving purchase order", zap.Uint("id", id), zap.Int("items", len(receipt.Items)))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var po entity.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&po, id).Error; err != nil {
+			return err
+		}
+		if !po.CanReceive() {
+			return fmt.Errorf("%w: purchase order %s berstatus %s", ErrPurchaseOrderStatusConflict, po.PONumber, po.Status)
+		}
+
+		var items []entity.PurchaseOrderItem
+		if err := tx.Where("purchase_order_id = ?", id).Find(&items).Error; err != nil {
+			return err
+		}
+		itemByID := make(map[uint]*entity.PurchaseOrderItem, len(items))
+		for i := range items {
+			itemByID[items[i].ID] = &items[i]
+		}
+
+		receivedNow := make(map[uint]float64, len(receipt.Items))
+		for i := range receipt.Items {
+			line := &receipt.Items[i]
+			item, ok := itemByID[line.PurchaseOrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: item %d bukan bagian dari %s", ErrInvalidGoodsReceipt, line.PurchaseOrderItemID, po.PONumber)
+			}
+			receivedNow[item.ID] += line.Quantity
+			if receivedNow[item.ID] > item.RemainingQuantity()+1e-9 {
+				return fmt.Errorf("%w: quantity item %d melebihi sisa pesanan (%.3f)", ErrInvalidGoodsReceipt, item.ID, item.RemainingQuantity())
+			}
+			line.InventoryID = item.InventoryID
+		}
+
+		receipt.ID = 0
+		receipt.PurchaseOrderID = po.ID
+		receipt.TotalCost = 0
+		for _, line := range receipt.Items {
+			receipt.TotalCost += line.Quantity * line.UnitCost
+		}
+		if err := tx.Omit("Items").Create(receipt).Error; err != nil {
+			return err
+		}
+		receipt.ReceiptNumber = entity.GoodsReceiptNumber(receipt.ID)
+		if err := tx.Model(receipt).UpdateColumn("receipt_number", receipt.ReceiptNumber).Error; err != nil {
+			return err
+		}
+
+		// Stok ditambah urut inventory ID agar urutan lock sama dengan transaksi stok lain
+		order := make([]int, len(receipt.Items))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return receipt.Items[order[a]].InventoryID < receipt.Items[order[b]].InventoryID
+		})
+		receiptID := receipt.ID
+		for _, i := range order {
+			line := &receipt.Items[i]
+			unitCost := line.UnitCost
+			movement := &entity.StockMovement{
+				InventoryID:   line.InventoryID,
+				Type:          entity.StockMovementPurchase,
+				Delta:         line.Quantity,
+				UnitCost:      &unitCost,
+				BatchNumber:   line.BatchNumber,
+				ExpiryDate:    line.ExpiryDate,
+				UserID:        receipt.ReceivedBy,
+				ReferenceType: "goods_receipt",
+				ReferenceID:   &receiptID,
+				Note:          fmt.Sprintf("%s / %s", po.PONumber, receipt.ReceiptNumber),
+			}
+			if err := recordStockMovement(tx, movement); err != nil {
+				return err
+			}
+			line.ID = 0
+			line.GoodsReceiptID = receipt.ID
+			line.StockMovementID = movement.ID
+		}
+		if err := tx.Create(&receipt.Items).Error; err != nil {
+			return err
+		}
+
+		complete := true
+		for i := range items {
+			item := &items[i]
+			if qty := receivedNow[item.ID]; qty > 0 {
+				item.ReceivedQuantity += qty
+				if err := tx.Model(item).UpdateColumn("received_quantity", item.ReceivedQuantity).Error; err != nil {
+					return err
+				}
+			}
+			if item.RemainingQuantity() > 1e-9 {
+				complete = false
+			}
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{"status": entity.PurchaseOrderStatusPartiallyReceived, "updated_at": now}
+		if complete {
+			updates["status"] = entity.PurchaseOrderStatusReceived
+			updates["received_at"] = now
+		}
+		return tx.Model(&po).Updates(updates).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to receive purchase order", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Purchase order received",
+		zap.Uint("id", id),
+		zap.String("receipt_number", receipt.ReceiptNumber),
+		zap.Float64("total_cost", receipt.TotalCost))
+	return nil
+}
+
+// FindReorderCandidates mengambil inventory aktif dengan quantity di bawah MinStock, beserta
+// quantity yang masih dipesan di purchase order terbuka dan supplier/harga penerimaan terakhir
+func (r *purchaseOrderRepository) FindReorderCandidates(ctx context.Context) ([]ReorderCandidate, error) {
+	var inventories []entity.Inventories
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND quantity < min_stock", "active").
+		Order("name ASC").
+		Find(&inventories).Error; err != nil {
+		r.logger.Error("Failed to find inventories below min stock", zap.Error(err))
+		return nil, err
+	}
+	if len(inventories) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(inventories))
+	for _, inv := range inventories {
+		ids = append(ids, inv.ID)
+	}
+
+	var onOrder []struct {
+		InventoryID int64
+		Quantity    float64
+	}
+	if err := r.db.WithContext(ctx).
+		Table("purchase_order_items poi").
+		Select("poi.inventory_id, SUM(GREATEST(poi.quantity - poi.received_quantity, 0)) AS quantity").
+		Joins("JOIN purchase_orders po ON po.id = poi.purchase_order_id").
+		Where("po.status IN ? AND poi.inventory_id IN ?", openPurchaseOrderStatuses, ids).
+		Group("poi.inventory_id").
+		Scan(&onOrder).Error; err != nil {
+		r.logger.Error("Failed to sum open purchase order quantities", zap.Error(err))
+		return nil, err
+	}
+
+	lastReceipts, err := findLastReceipts(r.db.WithContext(ctx), ids)
+	if err != nil {
+		r.logger.Error("Failed to find last goods receipts", zap.Error(err))
+		return nil, err
+	}
+
+	candidates := make([]ReorderCandidate, len(inventories))
+	index := make(map[int64]int, len(inventories))
+	for i, inv := range inventories {
+		candidates[i].Inventory = inv
+		index[inv.ID] = i
+	}
+	for _, row := range onOrder {
+		candidates[index[row.InventoryID]].OnOrder = row.Quantity
+	}
+	for _, row := range lastReceipts {
+		c := &candidates[index[row.InventoryID]]
+		supplierID := row.SupplierID
+		c.LastSupplierID = &supplierID
+		c.LastSupplierName = row.SupplierName
+		c.LastUnitCost = row.UnitCost
+	}
+	return candidates, nil
+}
+
+// lastReceipt berisi supplier dan harga satuan penerimaan barang terakhir satu inventory
+type lastReceipt struct {
+	InventoryID  int64
+	SupplierID   uint
+	SupplierName string
+	UnitCost     float64
+}
+
+// findLastReceipts mengambil penerimaan barang terakhir untuk setiap inventory
+func findLastReceipts(db *gorm.DB, inventoryIDs []int64) ([]lastReceipt, error) {
+	var rows []lastReceipt
+	if len(inventoryIDs) == 0 {
+		return rows, nil
+	}
+	err := db.Raw(`
+		SELECT DISTINCT ON (gri.inventory_id) gri.inventory_id, po.supplier_id, s.name AS supplier_name, gri.unit_cost
+		FROM goods_receipt_items gri
+		JOIN goods_receipts gr ON gr.id = gri.goods_receipt_id
+		JOIN purchase_orders po ON po.id = gr.purchase_order_id
+		JOIN suppliers s ON s.id = po.supplier_id
+		WHERE gri.inventory_id IN ?
+		ORDER BY gri.inventory_id, gri.id DESC`, inventoryIDs).
+		Scan(&rows).Error
+	return rows, err
+}
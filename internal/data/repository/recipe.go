@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type RecipeRepository interface {
+	FindByProduct(ctx context.Context, productID uint) ([]entity.RecipeItem, error)
+	Replace(ctx context.Context, productID uint, items []entity.RecipeItem) error
+}
+
+type recipeRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewRecipeRepository(db *gorm.DB, logger *zap.Logger) RecipeRepository {
+	return &recipeRepository{db, logger}
+}
+
+// FindByProduct mengambil bahan resep produk beserta data inventory-nya
+func (r *recipeRepository) FindByProduct(ctx context.Context, productID uint) ([]entity.RecipeItem, error) {
+	var items []entity.RecipeItem
+	err := r.db.WithContext(ctx).Preload("Inventory.PackSizes").
+		Where("product_id = ?", productID).
+		Order("variant_id ASC NULLS FIRST, id ASC").
+		Find(&items).Error
+	if err != nil {
+		r.logger.Error("Failed to find recipe", zap.Uint("product_id", productID), zap.Error(err))
+		return nil, err
+	}
+	return items, nil
+}
+
+// Replace mengganti seluruh bahan resep produk
+func (r *recipeRepository) Replace(ctx context.Context, productID uint, items []entity.RecipeItem) error {
+	r.logger.Info("Replacing recipe", zap.Uint("product_id", productID), zap.Int("items", len(items)))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.RecipeItem{}).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].ID = 0
+			items[i].ProductID = productID
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.Omit("Inventory").Create(&items).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to replace recipe", zap.Uint("product_id", productID), zap.Error(err))
+		return err
+	}
+	return nil
+}
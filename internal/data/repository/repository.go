@@ -16,6 +16,17 @@ type Repository struct {
 	DashboardRepo   DashboardRepository
 	RevenueRepo     RevenueRepository
 	ReservationRepo ReservationsRepository
+	TableRepo       TableRepository
+	TaxRepo         TaxRepository
+	PromotionRepo   PromotionRepository
+	KitchenRepo     KitchenRepository
+	ModifierRepo    ModifierRepository
+	RecipeRepo      RecipeRepository
+	StockMovementRepo StockMovementRepository
+	SupplierRepo    SupplierRepository
+	PurchaseOrderRepo PurchaseOrderRepository
+	StocktakeRepo     StocktakeRepository
+	WasteRepo         WasteRepository
 }
 
 func NewRepository(db *gorm.DB, logger *zap.Logger) Repository {
@@ -30,5 +41,16 @@ func NewRepository(db *gorm.DB, logger *zap.Logger) Repository {
 		DashboardRepo:   NewDashboardRepository(db, logger),
 		RevenueRepo:     NewRevenueRepository(db, logger),
 		ReservationRepo: NewReservationsRepository(db, logger),
+		TableRepo:       NewTableRepository(db, logger),
+		TaxRepo:         NewTaxRepository(db, logger),
+		PromotionRepo:   NewPromotionRepository(db, logger),
+		KitchenRepo:     NewKitchenRepository(db, logger),
+		ModifierRepo:    NewModifierRepository(db, logger),
+		RecipeRepo:      NewRecipeRepository(db, logger),
+		StockMovementRepo: NewStockMovementRepository(db, logger),
+		SupplierRepo:    NewSupplierRepository(db, logger),
+		PurchaseOrderRepo: NewPurchaseOrderRepository(db, logger),
+		StocktakeRepo:     NewStocktakeRepository(db, logger),
+		WasteRepo:         NewWasteRepository(db, logger),
 	}
 }
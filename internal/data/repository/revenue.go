@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"math"
 	"time"
 
+	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/dto"
 
 	"go.uber.org/zap"
@@ -13,9 +15,13 @@ import (
 type RevenueRepository interface {
 	GetRevenueByStatus(ctx context.Context, status string) (*dto.RevenueByStatusResponse, error)
 	GetRevenuePerMonth(ctx context.Context, year int, month int) (*dto.RevenuePerMonthResponse, error)
-	GetProductRevenueList(ctx context.Context, productID int) (*dto.ProductRevenueListResponse, error)
+	GetProductRevenueList(ctx context.Context, productID int, valuationMethod string) (*dto.ProductRevenueListResponse, error)
 }
 
+// collectedOrderStatuses adalah status order yang nilainya sudah diterima dan dihitung sebagai
+// pendapatan. Order batal (void) dan yang belum dibayar tidak dihitung; refund dikurangkan terpisah.
+var collectedOrderStatuses = []string{entity.OrderStatusPaid, entity.OrderStatusRefunded}
+
 type revenueRepository struct {
 	db     *gorm.DB
 	logger *zap.Logger
@@ -34,6 +40,9 @@ func (r *revenueRepository) GetRevenueByStatus(ctx context.Context, status strin
 		SELECT
 			status,
 			COALESCE(SUM(total_amount), 0) as total_revenue,
+			COALESCE(SUM(subtotal), 0) as gross_revenue,
+			COALESCE(SUM(discount_amount), 0) as discount_amount,
+			COALESCE(SUM(subtotal - discount_amount), 0) as net_revenue,
 			COUNT(*) as order_count
 		FROM orders
 		WHERE deleted_at IS NULL AND status = ?
@@ -46,9 +55,42 @@ func (r *revenueRepository) GetRevenueByStatus(ctx context.Context, status strin
 		return nil, err
 	}
 
+	var paymentMethods []dto.PaymentMethodRevenue
+	paymentQuery := `
+		SELECT
+			pm.id as payment_method_id,
+			pm.name as payment_method,
+			COALESCE(SUM(op.amount), 0) as total_amount,
+			COUNT(op.id) as payment_count,
+			COUNT(DISTINCT op.order_id) as order_count
+		FROM order_payments op
+		JOIN orders o ON o.id = op.order_id AND o.deleted_at IS NULL
+		JOIN payment_methods pm ON pm.id = op.payment_method_id
+		WHERE o.status = ?
+		GROUP BY pm.id, pm.name
+		ORDER BY total_amount DESC
+	`
+
+	err = r.db.WithContext(ctx).Raw(paymentQuery, status).Scan(&paymentMethods).Error
+	if err != nil {
+		r.logger.Error("Failed to get revenue by payment method", zap.Error(err))
+		return nil, err
+	}
+
+	refunds, err := r.getRefundTotals(ctx, "o.status = ?", status)
+	if err != nil {
+		return nil, err
+	}
+
 	response := &dto.RevenueByStatusResponse{
-		TotalRevenue: breakdown.TotalRevenue,
-		Breakdown:    []dto.RevenueStatusBreakdown{breakdown},
+		TotalRevenue:   breakdown.TotalRevenue,
+		GrossRevenue:   breakdown.GrossRevenue,
+		DiscountAmount: breakdown.DiscountAmount,
+		NetRevenue:     breakdown.NetRevenue,
+		VoidedAmount:   refunds.VoidedAmount,
+		RefundedAmount: refunds.RefundedAmount,
+		Breakdown:      []dto.RevenueStatusBreakdown{breakdown},
+		PaymentMethods: paymentMethods,
 	}
 
 	r.logger.Info("Successfully got revenue by status",
@@ -71,15 +113,18 @@ func (r *revenueRepository) GetRevenuePerMonth(ctx context.Context, year int, mo
 		SELECT
 			EXTRACT(MONTH FROM created_at)::int as month,
 			COALESCE(SUM(total_amount), 0) as total_revenue,
+			COALESCE(SUM(subtotal), 0) as gross_revenue,
+			COALESCE(SUM(discount_amount), 0) as discount_amount,
 			COUNT(*) as order_count
 		FROM orders
 		WHERE deleted_at IS NULL
+			AND status IN ?
 			AND EXTRACT(YEAR FROM created_at) = ?
 			AND EXTRACT(MONTH FROM created_at) = ?
 		GROUP BY EXTRACT(MONTH FROM created_at)
 	`
 
-	err := r.db.WithContext(ctx).Raw(query, year, month).Scan(&detail).Error
+	err := r.db.WithContext(ctx).Raw(query, collectedOrderStatuses, year, month).Scan(&detail).Error
 	if err != nil {
 		r.logger.Error("Failed to get revenue for month", zap.Error(err))
 		return nil, err
@@ -93,10 +138,23 @@ func (r *revenueRepository) GetRevenuePerMonth(ctx context.Context, year int, mo
 		detail.MonthName = monthNames[detail.Month]
 	}
 
+	// Void/refund dihitung pada bulan dicatat, bukan bulan order dibuat
+	refunds, err := r.getRefundTotals(ctx,
+		"EXTRACT(YEAR FROM rf.created_at) = ? AND EXTRACT(MONTH FROM rf.created_at) = ?", year, month)
+	if err != nil {
+		return nil, err
+	}
+	detail.NetRevenue = roundAmount(detail.TotalRevenue - refunds.RefundedAmount)
+
 	response := &dto.RevenuePerMonthResponse{
-		Year:         year,
-		TotalRevenue: detail.TotalRevenue,
-		Monthly:      []dto.RevenueMonthlyDetail{detail},
+		Year:           year,
+		TotalRevenue:   detail.TotalRevenue,
+		GrossRevenue:   detail.GrossRevenue,
+		DiscountAmount: detail.DiscountAmount,
+		NetRevenue:     detail.NetRevenue,
+		VoidedAmount:   refunds.VoidedAmount,
+		RefundedAmount: refunds.RefundedAmount,
+		Monthly:        []dto.RevenueMonthlyDetail{detail},
 	}
 
 	r.logger.Info("Successfully got revenue for month",
@@ -108,25 +166,30 @@ func (r *revenueRepository) GetRevenuePerMonth(ctx context.Context, year int, mo
 	return response, nil
 }
 
-func (r *revenueRepository) GetProductRevenueList(ctx context.Context, productID int) (*dto.ProductRevenueListResponse, error) {
+func (r *revenueRepository) GetProductRevenueList(ctx context.Context, productID int, valuationMethod string) (*dto.ProductRevenueListResponse, error) {
 	r.logger.Info("Getting product revenue for product", zap.Int("product_id", productID))
 
 	var product dto.ProductRevenueDetail
 
+	// Penjualan bundle dialokasikan ke produk komponennya (lihat productSalesSQL)
 	query := `
 		SELECT
 			p.id as product_id,
-			p.name as product_name,
+			p.product_name as product_name,
 			p.price as price,
-			COALESCE(SUM(oi.subtotal), 0) as total_revenue,
+			COALESCE(SUM(oi.subtotal - oi.discount_amount), 0) as total_revenue,
+			COALESCE(SUM(oi.subtotal), 0) as gross_revenue,
+			COALESCE(SUM(oi.discount_amount), 0) as discount_amount,
+			COALESCE(SUM(oi.subtotal - oi.discount_amount), 0) as net_revenue,
 			COALESCE(SUM(oi.quantity), 0) as total_sold,
 			COUNT(DISTINCT oi.order_id) as order_count,
-			MAX(o.created_at) as last_order_at
+			MAX(o.created_at) as last_order_at,
+			COALESCE(SUM(oi.modifier_amount), 0) as modifier_revenue
 		FROM products p
-		LEFT JOIN order_items oi ON p.id = oi.product_id AND oi.deleted_at IS NULL
+		LEFT JOIN (` + productSalesSQL + `) oi ON p.id = oi.product_id
 		LEFT JOIN orders o ON oi.order_id = o.id AND o.deleted_at IS NULL
 		WHERE p.deleted_at IS NULL AND p.id = ?
-		GROUP BY p.id, p.name, p.price
+		GROUP BY p.id, p.product_name, p.price
 	`
 
 	err := r.db.WithContext(ctx).Raw(query, productID).Scan(&product).Error
@@ -135,13 +198,76 @@ func (r *revenueRepository) GetProductRevenueList(ctx context.Context, productID
 		return nil, err
 	}
 
+	modifierQuery := `
+		SELECT
+			oim.modifier_option_id as modifier_option_id,
+			MAX(oim.group_name) as group_name,
+			MAX(oim.option_name) as option_name,
+			COALESCE(SUM(oi.quantity), 0) as quantity_sold,
+			COALESCE(SUM(oim.price_delta * oi.quantity), 0) as revenue
+		FROM order_item_modifiers oim
+		JOIN order_items oi ON oim.order_item_id = oi.id AND oi.deleted_at IS NULL
+		JOIN orders o ON oi.order_id = o.id AND o.deleted_at IS NULL
+		WHERE oi.product_id = ? AND ` + soldOrderSQL + `
+		GROUP BY oim.modifier_option_id
+		ORDER BY quantity_sold DESC, oim.modifier_option_id ASC
+	`
+
+	product.Modifiers = []dto.ProductModifierRevenue{}
+	if err := r.db.WithContext(ctx).Raw(modifierQuery, productID).Scan(&product.Modifiers).Error; err != nil {
+		r.logger.Error("Failed to get product modifier revenue", zap.Error(err))
+		return nil, err
+	}
+
+	// COGS dari bahan inventory yang terpakai resep produk ini (bundle: dicatat di produk komponennya),
+	// dari order yang sama dengan penjualannya (soldOrderSQL; order refund tidak dihitung)
+	costColumn := "sc.fifo_cost"
+	if valuationMethod == entity.ValuationWeightedAverage {
+		costColumn = "sc.average_cost"
+	}
+	err = r.db.WithContext(ctx).Table("sale_costs sc").
+		Select("COALESCE(SUM("+costColumn+"), 0)").
+		Joins("JOIN orders o ON o.id = sc.order_id AND o.deleted_at IS NULL").
+		Where("sc.product_id = ? AND "+soldOrderSQL, productID).
+		Scan(&product.CostOfGoodsSold).Error
+	if err != nil {
+		r.logger.Error("Failed to get product cost of goods sold", zap.Error(err))
+		return nil, err
+	}
+	product.GrossProfit = product.NetRevenue - product.CostOfGoodsSold
+	if product.NetRevenue != 0 {
+		product.GrossMargin = math.Round(product.GrossProfit/product.NetRevenue*10000) / 100
+	}
+
 	response := &dto.ProductRevenueListResponse{
-		TotalProducts: 1,
-		Products:      []dto.ProductRevenueDetail{product},
+		ValuationMethod: valuationMethod,
+		TotalProducts:   1,
+		Products:        []dto.ProductRevenueDetail{product},
 	}
 
 	r.logger.Info("Successfully got product revenue",
 		zap.Int("product_id", productID))
 
 	return response, nil
-}
\ No newline at end of file
+}
+
+// getRefundTotals menjumlahkan void dan refund yang memenuhi kondisi (alias rf = order_refunds, o = orders)
+func (r *revenueRepository) getRefundTotals(ctx context.Context, condition string, args ...interface{}) (*dto.RefundTotals, error) {
+	var totals dto.RefundTotals
+
+	err := r.db.WithContext(ctx).Table("order_refunds rf").
+		Select(`COALESCE(SUM(CASE WHEN rf.type = ? THEN rf.amount END), 0) as voided_amount,
+			COUNT(CASE WHEN rf.type = ? THEN 1 END) as void_count,
+			COALESCE(SUM(CASE WHEN rf.type = ? THEN rf.amount END), 0) as refunded_amount,
+			COUNT(CASE WHEN rf.type = ? THEN 1 END) as refund_count`,
+			entity.OrderRefundTypeVoid, entity.OrderRefundTypeVoid, entity.OrderRefundTypeRefund, entity.OrderRefundTypeRefund).
+		Joins("JOIN orders o ON o.id = rf.order_id AND o.deleted_at IS NULL").
+		Where(condition, args...).
+		Scan(&totals).Error
+	if err != nil {
+		r.logger.Error("Failed to get refund totals", zap.Error(err))
+		return nil, err
+	}
+
+	return &totals, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrZeroStockMovement dikembalikan jika pergerakan stok tidak mengubah quantity
+var ErrZeroStockMovement = errors.New("delta pergerakan stok tidak boleh nol")
+
+// StockMovementFilter berisi filter riwayat pergerakan stok satu inventory
+type StockMovementFilter struct {
+	Type      string
+	StartDate *time.Time // Inklusif
+	EndDate   *time.Time // Eksklusif
+	Page      int
+	Limit     int
+}
+
+type StockMovementRepository interface {
+	FindByInventory(ctx context.Context, inventoryID int64, filter StockMovementFilter) ([]entity.StockMovement, int64, error)
+	Record(ctx context.Context, movement *entity.StockMovement) error
+}
+
+type stockMovementRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewStockMovementRepository(db *gorm.DB, logger *zap.Logger) StockMovementRepository {
+	return &stockMovementRepository{db, logger}
+}
+
+// FindByInventory mengambil riwayat pergerakan stok inventory, terbaru lebih dulu
+func (r *stockMovementRepository) FindByInventory(ctx context.Context, inventoryID int64, filter StockMovementFilter) ([]entity.StockMovement, int64, error) {
+	var movements []entity.StockMovement
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.StockMovement{}).Where("inventory_id = ?", inventoryID)
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("created_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("created_at < ?", *filter.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count stock movements", zap.Int64("inventory_id", inventoryID), zap.Error(err))
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Offset(offset).Find(&movements).Error; err != nil {
+		r.logger.Error("Failed to find stock movements", zap.Int64("inventory_id", inventoryID), zap.Error(err))
+		return nil, 0, err
+	}
+	return movements, total, nil
+}
+
+// Record mencatat pergerakan stok dan memperbarui quantity inventory dalam satu transaksi
+func (r *stockMovementRepository) Record(ctx context.Context, movement *entity.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recordStockMovement(tx, movement)
+	})
+	if err != nil {
+		r.logger.Error("Failed to record stock movement",
+			zap.Int64("inventory_id", movement.InventoryID),
+			zap.String("type", movement.Type),
+			zap.Float64("delta", movement.Delta),
+			zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Stock movement recorded",
+		zap.Int64("inventory_id", movement.InventoryID),
+		zap.String("type", movement.Type),
+		zap.Float64("delta", movement.Delta),
+		zap.Float64("balance", movement.Balance))
+	return nil
+}
+
+// recordStockMovement adalah satu-satunya jalur perubahan quantity inventory: baris inventory dikunci,
+// quantity ditambah Delta, lalu pergerakan dicatat dengan Balance dan nilai harga pokoknya (lihat
+// costStockMovement) serta batch yang bertambah/berkurang (lihat applyBatchMovement). Harus dipanggil
+// di dalam transaksi; pemanggil yang mengubah beberapa inventory sekaligus harus memanggilnya urut ID.
+func recordStockMovement(tx *gorm.DB, movement *entity.StockMovement) error {
+	if movement.Delta == 0 {
+		return ErrZeroStockMovement
+	}
+
+	var inventory entity.Inventories
+	if err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&inventory, movement.InventoryID).Error; err != nil {
+		return err
+	}
+
+	cost, err := costStockMovement(tx, &inventory, movement)
+	if err != nil {
+		return err
+	}
+
+	movement.ID = 0
+	movement.Balance = inventory.Quantity + movement.Delta
+	if err := tx.Unscoped().Model(&entity.Inventories{}).Where("id = ?", inventory.ID).
+		UpdateColumns(map[string]interface{}{
+			"quantity":     movement.Balance,
+			"average_cost": cost.averageCost,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(movement).Error; err != nil {
+		return err
+	}
+	if err := createCostLayer(tx, movement, cost); err != nil {
+		return err
+	}
+	return applyBatchMovement(tx, inventory.Quantity, movement)
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrStocktakeNotCounting dikembalikan jika sesi stocktake sudah disetujui atau dibatalkan
+	ErrStocktakeNotCounting = errors.New("stocktake sudah tidak menerima perubahan")
+	// ErrEmptyStocktake dikembalikan jika tidak ada inventory yang bisa dihitung
+	ErrEmptyStocktake = errors.New("tidak ada inventory untuk stocktake")
+	// ErrInvalidStocktakeCount dikembalikan jika hasil hitung tidak sesuai baris stocktake
+	ErrInvalidStocktakeCount = errors.New("hasil hitung stocktake tidak valid")
+)
+
+// StocktakeCountInput berisi hasil hitung satu inventory dari satu counter
+type StocktakeCountInput struct {
+	InventoryID int64
+	Quantity    float64
+	Note        string
+}
+
+type StocktakeRepository interface {
+	FindAll(ctx context.Context, status string) ([]entity.Stocktake, error)
+	FindByID(ctx context.Context, id uint) (*entity.Stocktake, error)
+	Create(ctx context.Context, stocktake *entity.Stocktake) error
+	RecordCounts(ctx context.Context, id uint, countedBy uint, counts []StocktakeCountInput) error
+	Approve(ctx context.Context, id uint, approvedBy uint) error
+	Cancel(ctx context.Context, id uint) error
+}
+
+type stocktakeRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewStocktakeRepository(db *gorm.DB, logger *zap.Logger) StocktakeRepository {
+	return &stocktakeRepository{db, logger}
+}
+
+// preloadStocktakeLines memuat baris stocktake beserta inventory (termasuk yang sudah dihapus)
+// dan hasil hitung setiap counter
+func preloadStocktakeLines(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Inventory", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Lines.Counts", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") })
+}
+
+func (r *stocktakeRepository) FindAll(ctx context.Context, status string) ([]entity.Stocktake, error) {
+	r.logger.Info("Finding all stocktakes", zap.String("status", status))
+
+	query := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Order("id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var stocktakes []entity.Stocktake
+	if err := query.Find(&stocktakes).Error; err != nil {
+		r.logger.Error("Failed to find all stocktakes", zap.Error(err))
+		return nil, err
+	}
+	return stocktakes, nil
+}
+
+func (r *stocktakeRepository) FindByID(ctx context.Context, id uint) (*entity.Stocktake, error) {
+	var stocktake entity.Stocktake
+	if err := preloadStocktakeLines(r.db.WithContext(ctx)).First(&stocktake, id).Error; err != nil {
+		r.logger.Error("Failed to find stocktake by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &stocktake, nil
+}
+
+// Create membuat sesi stocktake dan men-snapshot quantity, harga jual, serta harga beli terakhir
+// semua inventory aktif (atau satu kategori jika Category diisi)
+func (r *stocktakeRepository) Create(ctx context.Context, stocktake *entity.Stocktake) error {
+	r.logger.Info("Creating stocktake", zap.String("category", stocktake.Category))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("status = ?", "active").Order("name ASC, id ASC")
+		if stocktake.Category != "" {
+			query = query.Where("LOWER(category) = ?", strings.ToLower(stocktake.Category))
+		}
+		var inventories []entity.Inventories
+		if err := query.Find(&inventories).Error; err != nil {
+			return err
+		}
+		if len(inventories) == 0 {
+			return ErrEmptyStocktake
+		}
+
+		ids := make([]int64, 0, len(inventories))
+		for _, inv := range inventories {
+			ids = append(ids, inv.ID)
+		}
+		lastReceipts, err := findLastReceipts(tx, ids)
+		if err != nil {
+			return err
+		}
+		unitCosts := make(map[int64]float64, len(lastReceipts))
+		for _, row := range lastReceipts {
+			unitCosts[row.InventoryID] = row.UnitCost
+		}
+
+		stocktake.ID = 0
+		stocktake.Status = entity.StocktakeStatusCounting
+		if err := tx.Omit("Lines").Create(stocktake).Error; err != nil {
+			return err
+		}
+		stocktake.Number = entity.StocktakeNumber(stocktake.ID)
+		if err := tx.Model(stocktake).UpdateColumn("number", stocktake.Number).Error; err != nil {
+			return err
+		}
+
+		stocktake.Lines = make([]entity.StocktakeLine, 0, len(inventories))
+		for _, inv := range inventories {
+			stocktake.Lines = append(stocktake.Lines, entity.StocktakeLine{
+				StocktakeID:      stocktake.ID,
+				InventoryID:      inv.ID,
+				ExpectedQuantity: inv.Quantity,
+				RetailPrice:      inv.RetailPrice,
+				UnitCost:         unitCosts[inv.ID],
+			})
+		}
+		return tx.Omit("Inventory", "Counts").Create(&stocktake.Lines).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to create stocktake", zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Stocktake created",
+		zap.Uint("id", stocktake.ID),
+		zap.String("number", stocktake.Number),
+		zap.Int("lines", len(stocktake.Lines)))
+	return nil
+}
+
+// lockCountingStocktake mengunci sesi stocktake dan memastikan statusnya masih counting
+func lockCountingStocktake(tx *gorm.DB, id uint) (*entity.Stocktake, error) {
+	var stocktake entity.Stocktake
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&stocktake, id).Error; err != nil {
+		return nil, err
+	}
+	if stocktake.Status != entity.StocktakeStatusCounting {
+		return nil, fmt.Errorf("%w: %s berstatus %s", ErrStocktakeNotCounting, stocktake.Number, stocktake.Status)
+	}
+	return &stocktake, nil
+}
+
+// RecordCounts menyimpan hasil hitung satu counter (hitung ulang menimpa hasil counter yang sama)
+// lalu menghitung ulang CountedQuantity baris sebagai jumlah hasil semua counter
+func (r *stocktakeRepository) RecordCounts(ctx context.Context, id uint, countedBy uint, counts []StocktakeCountInput) error {
+	r.logger.Info("Recording stocktake counts",
+		zap.Uint("id", id),
+		zap.Uint("counted_by", countedBy),
+		zap.Int("items", len(counts)))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stocktake, err := lockCountingStocktake(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var lines []entity.StocktakeLine
+		if err := tx.Where("stocktake_id = ?", id).Find(&lines).Error; err != nil {
+			return err
+		}
+		lineByInventory := make(map[int64]uint, len(lines))
+		for _, line := range lines {
+			lineByInventory[line.InventoryID] = line.ID
+		}
+
+		touched := make(map[uint]bool, len(counts))
+		now := time.Now()
+		for _, input := range counts {
+			lineID, ok := lineByInventory[input.InventoryID]
+			if !ok {
+				return fmt.Errorf("%w: inventory_id %d tidak ada di %s", ErrInvalidStocktakeCount, input.InventoryID, stocktake.Number)
+			}
+			if touched[lineID] {
+				return fmt.Errorf("%w: inventory_id %d ditulis lebih dari sekali", ErrInvalidStocktakeCount, input.InventoryID)
+			}
+			touched[lineID] = true
+
+			count := entity.StocktakeCount{
+				StocktakeLineID: lineID,
+				CountedBy:       countedBy,
+				Quantity:        input.Quantity,
+				Note:            input.Note,
+				UpdatedAt:       now,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "stocktake_line_id"}, {Name: "counted_by"}},
+				DoUpdates: clause.AssignmentColumns([]string{"quantity", "note", "updated_at"}),
+			}).Create(&count).Error; err != nil {
+				return err
+			}
+		}
+
+		lineIDs := make([]uint, 0, len(touched))
+		for lineID := range touched {
+			lineIDs = append(lineIDs, lineID)
+		}
+		return tx.Exec(`
+			UPDATE stocktake_lines sl
+			SET counted_quantity = (SELECT SUM(sc.quantity) FROM stocktake_counts sc WHERE sc.stocktake_line_id = sl.id)
+			WHERE sl.id IN ?`, lineIDs).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to record stocktake counts", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Approve menyetujui stocktake: selisih hitung fisik setiap baris yang sudah dihitung diposting
+// sebagai pergerakan stok adjustment. Selisih diterapkan ke quantity saat ini sehingga penjualan
+// atau penerimaan barang setelah snapshot tetap terhitung.
+func (r *stocktakeRepository) Approve(ctx context.Context, id uint, approvedBy uint) error {
+	r.logger.Info("Approving stocktake", zap.Uint("id", id), zap.Uint("approved_by", approvedBy))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stocktake, err := lockCountingStocktake(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var lines []entity.StocktakeLine
+		if err := tx.Where("stocktake_id = ? AND counted_quantity IS NOT NULL", id).Find(&lines).Error; err != nil {
+			return err
+		}
+		sort.Slice(lines, func(i, j int) bool { return lines[i].InventoryID < lines[j].InventoryID })
+
+		stocktakeID := stocktake.ID
+		for _, line := range lines {
+			variance, _ := line.Variance()
+			if variance == 0 {
+				continue
+			}
+			movement := &entity.StockMovement{
+				InventoryID:   line.InventoryID,
+				Type:          entity.StockMovementAdjustment,
+				Delta:         variance,
+				UserID:        &approvedBy,
+				ReferenceType: "stocktake",
+				ReferenceID:   &stocktakeID,
+				Note:          "Stocktake " + stocktake.Number,
+			}
+			if err := recordStockMovement(tx, movement); err != nil {
+				return err
+			}
+			if err := tx.Model(&entity.StocktakeLine{}).Where("id = ?", line.ID).
+				UpdateColumn("adjustment_movement_id", movement.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(stocktake).Updates(map[string]interface{}{
+			"status":      entity.StocktakeStatusApproved,
+			"approved_by": approvedBy,
+			"approved_at": now,
+			"updated_at":  now,
+		}).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to approve stocktake", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Cancel membatalkan stocktake yang masih counting tanpa mengubah stok
+func (r *stocktakeRepository) Cancel(ctx context.Context, id uint) error {
+	r.logger.Info("Cancelling stocktake", zap.Uint("id", id))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		stocktake, err := lockCountingStocktake(tx, id)
+		if err != nil {
+			return err
+		}
+		return tx.Model(stocktake).Updates(map[string]interface{}{
+			"status":     entity.StocktakeStatusCancelled,
+			"updated_at": time.Now(),
+		}).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to cancel stocktake", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SupplierRepository interface {
+	FindAll(ctx context.Context, activeOnly bool) ([]entity.Supplier, error)
+	FindByID(ctx context.Context, id uint) (*entity.Supplier, error)
+	Create(ctx context.Context, supplier *entity.Supplier) error
+	Update(ctx context.Context, supplier *entity.Supplier) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type supplierRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewSupplierRepository(db *gorm.DB, logger *zap.Logger) SupplierRepository {
+	return &supplierRepository{db, logger}
+}
+
+func (r *supplierRepository) FindAll(ctx context.Context, activeOnly bool) ([]entity.Supplier, error) {
+	r.logger.Info("Finding all suppliers", zap.Bool("active_only", activeOnly))
+
+	query := r.db.WithContext(ctx).Order("name ASC")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var suppliers []entity.Supplier
+	if err := query.Find(&suppliers).Error; err != nil {
+		r.logger.Error("Failed to find all suppliers", zap.Error(err))
+		return nil, err
+	}
+	return suppliers, nil
+}
+
+func (r *supplierRepository) FindByID(ctx context.Context, id uint) (*entity.Supplier, error) {
+	var supplier entity.Supplier
+	if err := r.db.WithContext(ctx).First(&supplier, id).Error; err != nil {
+		r.logger.Error("Failed to find supplier by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier *entity.Supplier) error {
+	r.logger.Info("Creating new supplier", zap.String("name", supplier.Name))
+
+	if err := r.db.WithContext(ctx).Create(supplier).Error; err != nil {
+		r.logger.Error("Failed to create supplier", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *supplierRepository) Update(ctx context.Context, supplier *entity.Supplier) error {
+	r.logger.Info("Updating supplier", zap.Uint("id", supplier.ID))
+
+	err := r.db.WithContext(ctx).Select("*").Omit("id", "created_at", "deleted_at").Updates(supplier).Error
+	if err != nil {
+		r.logger.Error("Failed to update supplier", zap.Uint("id", supplier.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Delete menghapus supplier (soft delete); purchase order lama tetap menyimpan supplier_id
+func (r *supplierRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting supplier", zap.Uint("id", id))
+
+	result := r.db.WithContext(ctx).Delete(&entity.Supplier{}, id)
+	if result.Error != nil {
+		r.logger.Error("Failed to delete supplier", zap.Uint("id", id), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTableNotFound dikembalikan jika meja tidak ada atau sudah dihapus
+	ErrTableNotFound = errors.New("meja tidak ditemukan")
+	// ErrTableUnavailable dikembalikan jika meja tidak bisa dipakai (out_of_service)
+	ErrTableUnavailable = errors.New("meja tidak dapat digunakan")
+)
+
+// activeReservationStatuses adalah status reservasi yang masih menahan meja
+var activeReservationStatuses = []string{"pending", "confirmed"}
+
+type TableRepository interface {
+	FindAll(ctx context.Context, status string) ([]entity.Table, error)
+	FindByID(ctx context.Context, id uint) (*entity.Table, error)
+	FindByNumber(ctx context.Context, number string) (*entity.Table, error)
+	Create(ctx context.Context, table *entity.Table) error
+	Update(ctx context.Context, table *entity.Table) error
+	Delete(ctx context.Context, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	CountOpenOrders(ctx context.Context, tableID uint) (int64, error)
+	CountOpenOrdersByTables(ctx context.Context, tableIDs []uint) (map[uint]int64, error)
+	SyncReservations(ctx context.Context, windowStart, windowEnd time.Time) (reserved int64, released int64, err error)
+}
+
+type tableRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewTableRepository(db *gorm.DB, logger *zap.Logger) TableRepository {
+	return &tableRepository{db, logger}
+}
+
+func (r *tableRepository) FindAll(ctx context.Context, status string) ([]entity.Table, error) {
+	r.logger.Info("Finding all tables", zap.String("status", status))
+
+	var tables []entity.Table
+	query := r.db.WithContext(ctx).Order("number ASC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&tables).Error; err != nil {
+		r.logger.Error("Failed to find all tables", zap.Error(err))
+		return nil, err
+	}
+
+	r.logger.Info("Successfully found all tables", zap.Int("count", len(tables)))
+	return tables, nil
+}
+
+func (r *tableRepository) FindByID(ctx context.Context, id uint) (*entity.Table, error) {
+	r.logger.Info("Finding table by ID", zap.Uint("id", id))
+
+	var table entity.Table
+	if err := r.db.WithContext(ctx).First(&table, id).Error; err != nil {
+		r.logger.Error("Failed to find table by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *tableRepository) FindByNumber(ctx context.Context, number string) (*entity.Table, error) {
+	var table entity.Table
+	if err := r.db.WithContext(ctx).Where("number = ?", number).First(&table).Error; err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (r *tableRepository) Create(ctx context.Context, table *entity.Table) error {
+	r.logger.Info("Creating new table", zap.String("number", table.Number))
+
+	if err := r.db.WithContext(ctx).Create(table).Error; err != nil {
+		r.logger.Error("Failed to create table", zap.String("number", table.Number), zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Successfully created table", zap.Uint("id", table.ID))
+	return nil
+}
+
+func (r *tableRepository) Update(ctx context.Context, table *entity.Table) error {
+	r.logger.Info("Updating table", zap.Uint("id", table.ID))
+
+	err := r.db.WithContext(ctx).Model(&entity.Table{}).Where("id = ?", table.ID).Updates(map[string]interface{}{
+		"number":     table.Number,
+		"capacity":   table.Capacity,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		r.logger.Error("Failed to update table", zap.Uint("id", table.ID), zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Successfully updated table", zap.Uint("id", table.ID))
+	return nil
+}
+
+func (r *tableRepository) Delete(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting table", zap.Uint("id", id))
+
+	if err := r.db.WithContext(ctx).Delete(&entity.Table{}, id).Error; err != nil {
+		r.logger.Error("Failed to delete table", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Successfully deleted table", zap.Uint("id", id))
+	return nil
+}
+
+// UpdateStatus mengubah status meja secara manual. Penahanan oleh reservasi dilepas,
+// sehingga sinkronisasi reservasi tidak menimpa status yang diatur staff.
+func (r *tableRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	r.logger.Info("Updating table status", zap.Uint("id", id), zap.String("status", status))
+
+	err := r.db.WithContext(ctx).Model(&entity.Table{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"status":         status,
+		"reservation_id": nil,
+		"updated_at":     time.Now(),
+	}).Error
+	if err != nil {
+		r.logger.Error("Failed to update table status", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// CountOpenOrders menghitung order dine-in yang masih berjalan di meja
+func (r *tableRepository) CountOpenOrders(ctx context.Context, tableID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Order{}).
+		Where("table_id = ? AND order_type = ? AND status IN ?", tableID, entity.OrderTypeDineIn, entity.OpenOrderStatuses).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count open orders", zap.Uint("table_id", tableID), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountOpenOrdersByTables seperti CountOpenOrders untuk banyak meja sekaligus dalam satu query.
+// Meja tanpa order berjalan tidak ada di hasil (dianggap 0).
+func (r *tableRepository) CountOpenOrdersByTables(ctx context.Context, tableIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(tableIDs))
+	if len(tableIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TableID uint
+		Count   int64
+	}
+	err := r.db.WithContext(ctx).Model(&entity.Order{}).
+		Select("table_id, COUNT(*) as count").
+		Where("table_id IN ? AND order_type = ? AND status IN ?", tableIDs, entity.OrderTypeDineIn, entity.OpenOrderStatuses).
+		Group("table_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to count open orders by tables", zap.Int("tables", len(tableIDs)), zap.Error(err))
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TableID] = row.Count
+	}
+	return counts, nil
+}
+
+// SyncReservations menyelaraskan status meja dengan reservasi aktif yang reservation_time-nya
+// berada di antara windowStart dan windowEnd. Meja available yang punya reservasi aktif di
+// jendela tersebut menjadi reserved; meja yang ditahan reservasi tetapi reservasinya sudah
+// keluar dari jendela (atau dibatalkan) kembali available. Meja yang di-set manual tidak disentuh.
+func (r *tableRepository) SyncReservations(ctx context.Context, windowStart, windowEnd time.Time) (int64, int64, error) {
+	var reserved, released int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Lepas meja yang penahannya sudah tidak aktif
+		activeHolds := tx.Model(&entity.Reservations{}).
+			Select("id").
+			Where("status IN ? AND reservation_time BETWEEN ? AND ?", activeReservationStatuses, windowStart, windowEnd)
+		result := tx.Model(&entity.Table{}).
+			Where("status = ? AND reservation_id IS NOT NULL AND reservation_id NOT IN (?)", entity.TableStatusReserved, activeHolds).
+			UpdateColumns(map[string]interface{}{
+				"status":         entity.TableStatusAvailable,
+				"reservation_id": nil,
+				"updated_at":     now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		released = result.RowsAffected
+
+		// Tahan meja available untuk reservasi yang masuk jendela, urut dari yang paling awal
+		var upcoming []entity.Reservations
+		if err := tx.Where("status IN ? AND reservation_time BETWEEN ? AND ?", activeReservationStatuses, windowStart, windowEnd).
+			Order("reservation_time ASC").
+			Find(&upcoming).Error; err != nil {
+			return err
+		}
+		for _, reservation := range upcoming {
+			reservationID := reservation.ID
+			result := tx.Model(&entity.Table{}).
+				Where("id = ? AND status = ?", reservation.TableID, entity.TableStatusAvailable).
+				UpdateColumns(map[string]interface{}{
+					"status":         entity.TableStatusReserved,
+					"reservation_id": reservationID,
+					"updated_at":     now,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			reserved += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to sync table reservations", zap.Error(err))
+		return 0, 0, err
+	}
+
+	return reserved, released, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrOutletNotFound dikembalikan jika outlet tidak ada atau sudah dihapus
+var ErrOutletNotFound = errors.New("outlet tidak ditemukan")
+
+type TaxRepository interface {
+	FindAllOutlets(ctx context.Context) ([]entity.Outlet, error)
+	FindOutletByID(ctx context.Context, id uint) (*entity.Outlet, error)
+	CreateOutlet(ctx context.Context, outlet *entity.Outlet) error
+	UpdateOutlet(ctx context.Context, outlet *entity.Outlet) error
+	FindTaxRules(ctx context.Context, outletID, categoryID uint) ([]entity.TaxRule, error)
+	FindTaxRuleByID(ctx context.Context, id uint) (*entity.TaxRule, error)
+	CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error
+	UpdateTaxRule(ctx context.Context, rule *entity.TaxRule) error
+	DeleteTaxRule(ctx context.Context, id uint) error
+}
+
+type taxRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewTaxRepository(db *gorm.DB, logger *zap.Logger) TaxRepository {
+	return &taxRepository{db, logger}
+}
+
+func (r *taxRepository) FindAllOutlets(ctx context.Context) ([]entity.Outlet, error) {
+	r.logger.Info("Finding all outlets")
+
+	var outlets []entity.Outlet
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&outlets).Error; err != nil {
+		r.logger.Error("Failed to find all outlets", zap.Error(err))
+		return nil, err
+	}
+	return outlets, nil
+}
+
+func (r *taxRepository) FindOutletByID(ctx context.Context, id uint) (*entity.Outlet, error) {
+	var outlet entity.Outlet
+	if err := r.db.WithContext(ctx).First(&outlet, id).Error; err != nil {
+		r.logger.Error("Failed to find outlet by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &outlet, nil
+}
+
+func (r *taxRepository) CreateOutlet(ctx context.Context, outlet *entity.Outlet) error {
+	r.logger.Info("Creating new outlet", zap.String("name", outlet.Name))
+
+	if err := r.db.WithContext(ctx).Create(outlet).Error; err != nil {
+		r.logger.Error("Failed to create outlet", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *taxRepository) UpdateOutlet(ctx context.Context, outlet *entity.Outlet) error {
+	r.logger.Info("Updating outlet", zap.Uint("id", outlet.ID))
+
+	if err := r.db.WithContext(ctx).Select("name", "address", "phone", "prices_include_tax", "receipt_header", "receipt_footer", "receipt_width", "updated_at").Updates(outlet).Error; err != nil {
+		r.logger.Error("Failed to update outlet", zap.Uint("id", outlet.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindTaxRules mengambil aturan pajak, bisa difilter per outlet dan kategori (0 = tanpa filter).
+// Filter outlet juga mengikutsertakan aturan global yang berlaku di semua outlet.
+func (r *taxRepository) FindTaxRules(ctx context.Context, outletID, categoryID uint) ([]entity.TaxRule, error) {
+	r.logger.Info("Finding tax rules", zap.Uint("outlet_id", outletID), zap.Uint("category_id", categoryID))
+
+	query := r.db.WithContext(ctx).Order("priority ASC, id ASC")
+	if outletID != 0 {
+		query = query.Where("outlet_id IS NULL OR outlet_id = ?", outletID)
+	}
+	if categoryID != 0 {
+		query = query.Where("category_id IS NULL OR category_id = ?", categoryID)
+	}
+
+	var rules []entity.TaxRule
+	if err := query.Find(&rules).Error; err != nil {
+		r.logger.Error("Failed to find tax rules", zap.Error(err))
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *taxRepository) FindTaxRuleByID(ctx context.Context, id uint) (*entity.TaxRule, error) {
+	var rule entity.TaxRule
+	if err := r.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		r.logger.Error("Failed to find tax rule by ID", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *taxRepository) CreateTaxRule(ctx context.Context, rule *entity.TaxRule) error {
+	r.logger.Info("Creating new tax rule", zap.String("name", rule.Name), zap.Float64("rate", rule.Rate))
+
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		r.logger.Error("Failed to create tax rule", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *taxRepository) UpdateTaxRule(ctx context.Context, rule *entity.TaxRule) error {
+	r.logger.Info("Updating tax rule", zap.Uint("id", rule.ID))
+
+	err := r.db.WithContext(ctx).
+		Select("outlet_id", "category_id", "name", "type", "rate", "priority", "is_compound", "is_active", "updated_at").
+		Updates(rule).Error
+	if err != nil {
+		r.logger.Error("Failed to update tax rule", zap.Uint("id", rule.ID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *taxRepository) DeleteTaxRule(ctx context.Context, id uint) error {
+	r.logger.Info("Deleting tax rule", zap.Uint("id", id))
+
+	if err := r.db.WithContext(ctx).Delete(&entity.TaxRule{}, id).Error; err != nil {
+		r.logger.Error("Failed to delete tax rule", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInsufficientWasteStock dikembalikan jika quantity waste inventory melebihi stok saat ini
+var ErrInsufficientWasteStock = errors.New("quantity waste melebihi stok inventory")
+
+// WasteFilter berisi filter daftar waste
+type WasteFilter struct {
+	Reason      string
+	InventoryID int64
+	ProductID   uint
+	StartDate   *time.Time // Inklusif
+	EndDate     *time.Time // Eksklusif
+	Page        int
+	Limit       int
+}
+
+// WasteReportFilter berisi periode dan filter laporan waste
+type WasteReportFilter struct {
+	StartDate time.Time // Inklusif
+	EndDate   time.Time // Eksklusif
+	Period    string    // Satuan date_trunc: day, week, atau month
+	Reason    string
+}
+
+// WastePeriodTotal berisi total waste dan harga pokok penjualan dalam satu periode
+type WastePeriodTotal struct {
+	Period           time.Time
+	Count            int64
+	FifoCost         float64
+	AverageCost      float64
+	SalesFifoCost    float64
+	SalesAverageCost float64
+}
+
+// WasteReasonTotal berisi total waste per alasan
+type WasteReasonTotal struct {
+	Reason      string
+	Count       int64
+	FifoCost    float64
+	AverageCost float64
+}
+
+// WasteItemTotal berisi total waste per inventory atau produk
+type WasteItemTotal struct {
+	InventoryID *int64
+	ProductID   *uint
+	Name        string
+	Unit        string
+	Quantity    float64
+	Count       int64
+	FifoCost    float64
+	AverageCost float64
+}
+
+type WasteRepository interface {
+	FindAll(ctx context.Context, filter WasteFilter) ([]entity.WasteLog, int64, error)
+	FindByID(ctx context.Context, id uint) (*entity.WasteLog, error)
+	Create(ctx context.Context, waste *entity.WasteLog) error
+	GetTotalsByPeriod(ctx context.Context, filter WasteReportFilter) ([]WastePeriodTotal, error)
+	GetTotalsByReason(ctx context.Context, filter WasteReportFilter) ([]WasteReasonTotal, error)
+	GetTotalsByItem(ctx context.Context, filter WasteReportFilter) ([]WasteItemTotal, error)
+}
+
+type wasteRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewWasteRepository(db *gorm.DB, logger *zap.Logger) WasteRepository {
+	return &wasteRepository{db, logger}
+}
+
+// preloadWasteLog memuat inventory, produk, varian (termasuk yang sudah dihapus) dan staff
+func preloadWasteLog(db *gorm.DB) *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB { return db.Unscoped() }
+	return db.
+		Preload("Inventory", unscoped).
+		Preload("Product", unscoped).
+		Preload("Variant", unscoped).
+		Preload("Staff", unscoped)
+}
+
+// FindAll mengambil daftar waste, terbaru lebih dulu
+func (r *wasteRepository) FindAll(ctx context.Context, filter WasteFilter) ([]entity.WasteLog, int64, error) {
+	var wastes []entity.WasteLog
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.WasteLog{})
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	if filter.InventoryID != 0 {
+		query = query.Where("inventory_id = ?", filter.InventoryID)
+	}
+	if filter.ProductID != 0 {
+		query = query.Where("product_id = ?", filter.ProductID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("wasted_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("wasted_at < ?", *filter.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Error("Failed to count waste logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	if err := preloadWasteLog(query).Order("wasted_at DESC, id DESC").Limit(filter.Limit).Offset(offset).Find(&wastes).Error; err != nil {
+		r.logger.Error("Failed to find waste logs", zap.Error(err))
+		return nil, 0, err
+	}
+	return wastes, total, nil
+}
+
+func (r *wasteRepository) FindByID(ctx context.Context, id uint) (*entity.WasteLog, error) {
+	var waste entity.WasteLog
+	if err := preloadWasteLog(r.db.WithContext(ctx)).First(&waste, id).Error; err != nil {
+		r.logger.Error("Failed to find waste log", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	return &waste, nil
+}
+
+// Create mencatat waste dan mengurangi stoknya dalam satu transaksi. Waste inventory ditolak jika
+// melebihi stok; waste produk mengurangi stok produk/varian (ditolak jika tidak cukup) lalu bahan
+// resepnya, yang boleh menjadi negatif seperti pengurangan bahan saat order dibayar.
+// FifoCost dan AverageCost diisi dari nilai pergerakan stok yang tercatat.
+func (r *wasteRepository) Create(ctx context.Context, waste *entity.WasteLog) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(waste).Error; err != nil {
+			return err
+		}
+
+		var movements []*entity.StockMovement
+		var err error
+		if waste.InventoryID != nil {
+			movements, err = r.wasteInventory(tx, waste)
+		} else {
+			movements, err = r.wasteProduct(tx, waste)
+		}
+		if err != nil {
+			return err
+		}
+
+		waste.FifoCost, waste.AverageCost = 0, 0
+		for _, movement := range movements {
+			waste.FifoCost -= movement.FifoValue
+			waste.AverageCost -= movement.AverageValue
+		}
+		waste.FifoCost = roundCost(waste.FifoCost)
+		waste.AverageCost = roundCost(waste.AverageCost)
+		return tx.Model(&entity.WasteLog{}).Where("id = ?", waste.ID).UpdateColumns(map[string]interface{}{
+			"fifo_cost":    waste.FifoCost,
+			"average_cost": waste.AverageCost,
+		}).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to create waste log", zap.String("reason", waste.Reason), zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Waste log created",
+		zap.Uint("id", waste.ID),
+		zap.String("reason", waste.Reason),
+		zap.Float64("quantity", waste.Quantity),
+		zap.Float64("fifo_cost", waste.FifoCost))
+	return nil
+}
+
+// wasteInventory mengurangi stok inventory sebesar quantity waste (dalam satuan inventory)
+func (r *wasteRepository) wasteInventory(tx *gorm.DB, waste *entity.WasteLog) ([]*entity.StockMovement, error) {
+	var inventory entity.Inventories
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&inventory, *waste.InventoryID).Error; err != nil {
+		return nil, err
+	}
+	if waste.Quantity > inventory.Quantity {
+		return nil, fmt.Errorf("%w: %s tersisa %v %s", ErrInsufficientWasteStock, inventory.Name, inventory.Quantity, inventory.Unit)
+	}
+
+	movement := r.wasteMovement(waste, inventory.ID, waste.Quantity)
+	if err := recordStockMovement(tx, movement); err != nil {
+		return nil, err
+	}
+	return []*entity.StockMovement{movement}, nil
+}
+
+// wasteProduct mengurangi stok produk/varian sebanyak porsi waste, lalu bahan resepnya. Perhitungan
+// stok dan bahan memakai jalur yang sama dengan order agar hasilnya konsisten.
+func (r *wasteRepository) wasteProduct(tx *gorm.DB, waste *entity.WasteLog) ([]*entity.StockMovement, error) {
+	items := []entity.OrderItem{{
+		ProductID: *waste.ProductID,
+		VariantID: waste.VariantID,
+		Quantity:  int(waste.Quantity),
+	}}
+	orders := &orderRepository{db: tx, logger: r.logger}
+	if err := orders.adjustStock(tx, nil, items); err != nil {
+		return nil, err
+	}
+
+	usage, err := orders.loadIngredientUsage(tx, items)
+	if err != nil {
+		return nil, err
+	}
+	inventoryIDs := make([]int64, 0, len(usage.quantities))
+	for id, quantity := range usage.quantities {
+		if quantity > 0 {
+			inventoryIDs = append(inventoryIDs, id)
+		}
+	}
+	sort.Slice(inventoryIDs, func(i, j int) bool { return inventoryIDs[i] < inventoryIDs[j] })
+
+	movements := make([]*entity.StockMovement, 0, len(inventoryIDs))
+	for _, inventoryID := range inventoryIDs {
+		movement := r.wasteMovement(waste, inventoryID, usage.quantities[inventoryID])
+		if err := recordStockMovement(tx, movement); err != nil {
+			return nil, err
+		}
+		if movement.Balance < 0 {
+			r.logger.Warn("Inventory quantity below zero after product waste",
+				zap.Uint("waste_id", waste.ID),
+				zap.Int64("inventory_id", inventoryID),
+				zap.Float64("quantity", movement.Balance))
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+// wasteMovement membuat pergerakan stok waste yang mereferensikan waste log
+func (r *wasteRepository) wasteMovement(waste *entity.WasteLog, inventoryID int64, quantity float64) *entity.StockMovement {
+	wasteID := waste.ID
+	note := waste.Reason
+	if waste.Note != "" {
+		note += ": " + waste.Note
+	}
+	return &entity.StockMovement{
+		InventoryID:   inventoryID,
+		Type:          entity.StockMovementWaste,
+		Delta:         -quantity,
+		UserID:        waste.RecordedBy,
+		ReferenceType: "waste",
+		ReferenceID:   &wasteID,
+		Note:          note,
+	}
+}
+
+// wasteReportScope menerapkan periode dan alasan laporan ke query waste_logs (alias w)
+func wasteReportScope(filter WasteReportFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("w.wasted_at >= ? AND w.wasted_at < ?", filter.StartDate, filter.EndDate)
+		if filter.Reason != "" {
+			db = db.Where("w.reason = ?", filter.Reason)
+		}
+		return db
+	}
+}
+
+// GetTotalsByPeriod menghitung total waste per periode beserta harga pokok penjualan pada periode
+// yang sama (dari sale_costs) sebagai pembanding
+func (r *wasteRepository) GetTotalsByPeriod(ctx context.Context, filter WasteReportFilter) ([]WastePeriodTotal, error) {
+	var totals []WastePeriodTotal
+	err := r.db.WithContext(ctx).Table("waste_logs w").
+		Select(`date_trunc(?, w.wasted_at) AS period, COUNT(*) AS count,
+			COALESCE(SUM(w.fifo_cost), 0) AS fifo_cost, COALESCE(SUM(w.average_cost), 0) AS average_cost`, filter.Period).
+		Scopes(wasteReportScope(filter)).
+		Group("1").
+		Scan(&totals).Error
+	if err != nil {
+		r.logger.Error("Failed to get waste totals by period", zap.Error(err))
+		return nil, err
+	}
+
+	var sales []WastePeriodTotal
+	err = r.db.WithContext(ctx).Table("sale_costs sc").
+		Select(`date_trunc(?, sc.created_at) AS period,
+			COALESCE(SUM(sc.fifo_cost), 0) AS sales_fifo_cost, COALESCE(SUM(sc.average_cost), 0) AS sales_average_cost`, filter.Period).
+		Where("sc.created_at >= ? AND sc.created_at < ?", filter.StartDate, filter.EndDate).
+		Group("1").
+		Scan(&sales).Error
+	if err != nil {
+		r.logger.Error("Failed to get sales cost by period", zap.Error(err))
+		return nil, err
+	}
+
+	byPeriod := make(map[int64]int, len(totals))
+	for i := range totals {
+		byPeriod[totals[i].Period.Unix()] = i
+	}
+	for _, s := range sales {
+		i, ok := byPeriod[s.Period.Unix()]
+		if !ok {
+			totals = append(totals, WastePeriodTotal{Period: s.Period})
+			i = len(totals) - 1
+		}
+		totals[i].SalesFifoCost = s.SalesFifoCost
+		totals[i].SalesAverageCost = s.SalesAverageCost
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Period.Before(totals[j].Period) })
+	return totals, nil
+}
+
+// GetTotalsByReason menghitung total waste per alasan
+func (r *wasteRepository) GetTotalsByReason(ctx context.Context, filter WasteReportFilter) ([]WasteReasonTotal, error) {
+	var totals []WasteReasonTotal
+	err := r.db.WithContext(ctx).Table("waste_logs w").
+		Select(`w.reason, COUNT(*) AS count,
+			COALESCE(SUM(w.fifo_cost), 0) AS fifo_cost, COALESCE(SUM(w.average_cost), 0) AS average_cost`).
+		Scopes(wasteReportScope(filter)).
+		Group("w.reason").
+		Order("w.reason").
+		Scan(&totals).Error
+	if err != nil {
+		r.logger.Error("Failed to get waste totals by reason", zap.Error(err))
+		return nil, err
+	}
+	return totals, nil
+}
+
+// GetTotalsByItem menghitung total waste per inventory atau produk (termasuk yang sudah dihapus)
+func (r *wasteRepository) GetTotalsByItem(ctx context.Context, filter WasteReportFilter) ([]WasteItemTotal, error) {
+	var totals []WasteItemTotal
+	err := r.db.WithContext(ctx).Table("waste_logs w").
+		Select(`w.inventory_id, w.product_id, COALESCE(i.name, p.product_name, '') AS name, w.unit,
+			COALESCE(SUM(w.quantity), 0) AS quantity, COUNT(*) AS count,
+			COALESCE(SUM(w.fifo_cost), 0) AS fifo_cost, COALESCE(SUM(w.average_cost), 0) AS average_cost`).
+		Joins("LEFT JOIN inventories i ON i.id = w.inventory_id").
+		Joins("LEFT JOIN products p ON p.id = w.product_id").
+		Scopes(wasteReportScope(filter)).
+		Group("w.inventory_id, w.product_id, i.name, p.product_name, w.unit").
+		Order("name").
+		Scan(&totals).Error
+	if err != nil {
+		r.logger.Error("Failed to get waste totals by item", zap.Error(err))
+		return nil, err
+	}
+	return totals, nil
+}
@@ -9,21 +9,30 @@ type DashboardSummaryResponse struct {
 
 // SalesSummary untuk ringkasan penjualan
 type SalesSummary struct {
-	TotalOrders     int     `json:"total_orders"`
-	TotalRevenue    float64 `json:"total_revenue"`
-	TotalTax        float64 `json:"total_tax"`
-	AverageOrder    float64 `json:"average_order"`
-	PaidOrders      int     `json:"paid_orders"`
-	PendingOrders   int     `json:"pending_orders"`
-	CancelledOrders int     `json:"cancelled_orders"`
+	TotalOrders     int                    `json:"total_orders"`
+	TotalRevenue    float64                `json:"total_revenue"` // Order yang dibayar dikurangi refund pada periode ini
+	TotalTax        float64                `json:"total_tax"`
+	AverageOrder    float64                `json:"average_order"` // Rata-rata nilai order yang dibayar
+	PaidOrders      int                    `json:"paid_orders"`
+	PendingOrders   int                    `json:"pending_orders"`
+	InKitchenOrders int                    `json:"in_kitchen_orders"`
+	ServedOrders    int                    `json:"served_orders"`
+	CancelledOrders int                    `json:"cancelled_orders"`
+	RefundedOrders  int                    `json:"refunded_orders"`
+	VoidedAmount    float64                `json:"voided_amount"` // Nilai order yang di-void pada periode ini
+	VoidCount       int                    `json:"void_count"`
+	RefundedAmount  float64                `json:"refunded_amount"` // Nilai refund (penuh/sebagian) pada periode ini
+	RefundCount     int                    `json:"refund_count"`
+	PaymentMethods  []PaymentMethodRevenue `json:"payment_methods"` // Pembayaran order pada periode ini per metode pembayaran
 }
 
 // TableSummary untuk ringkasan meja
 type TableSummary struct {
-	TotalTables     int `json:"total_tables"`
-	AvailableTables int `json:"available_tables"`
-	OccupiedTables  int `json:"occupied_tables"`
-	ReservedTables  int `json:"reserved_tables"`
+	TotalTables        int `json:"total_tables"`
+	AvailableTables    int `json:"available_tables"`
+	OccupiedTables     int `json:"occupied_tables"`
+	ReservedTables     int `json:"reserved_tables"`
+	OutOfServiceTables int `json:"out_of_service_tables"`
 }
 
 // PopularProductResponse untuk daftar produk populer
@@ -1,43 +1,71 @@
 package dto
 
+import "time"
+
 // InventoriesFilter merepresentasikan parameter filter untuk pencarian Inventories
 type InventoriesFilter struct {
-	Search   string  `json:"search" form:"search"`       // Pencarian berdasarkan nama
-	Status   string  `json:"status" form:"status"`       // Filter berdasarkan status (active/inactive)
-	Category string  `json:"category" form:"category"`   // Filter berdasarkan kategori
-	Stock    string  `json:"stock" form:"stock"`         // Filter berdasarkan status stok (instock/lowstock/outofstock)
-	MinPrice float64 `json:"min_price" form:"min_price"` // Filter harga minimum
-	MaxPrice float64 `json:"max_price" form:"max_price"` // Filter harga maksimum
-	Unit     string  `json:"unit" form:"unit"`           // Filter berdasarkan satuan (litre/pcs/kg)
-	MinQty   int     `json:"min_qty" form:"min_qty"`     // Filter quantity minimum
-	MaxQty   int     `json:"max_qty" form:"max_qty"`     // Filter quantity maksimum
-	Page     int     `json:"page" form:"page"`           // Nomor halaman untuk pagination
-	Limit    int     `json:"limit" form:"limit"`         // Jumlah item per halaman
-	SortBy   string  `json:"sort_by" form:"sort_by"`     // Field untuk sorting (name/quantity/price/created_at)
-	SortDir  string  `json:"sort_dir" form:"sort_dir"`   // Arah sorting (asc/desc)
+	Search         string  `json:"search" form:"search"`                   // Pencarian berdasarkan nama
+	Status         string  `json:"status" form:"status"`                   // Filter berdasarkan status (active/inactive)
+	Category       string  `json:"category" form:"category"`               // Filter berdasarkan kategori
+	Stock          string  `json:"stock" form:"stock"`                     // Filter berdasarkan status stok (instock/lowstock/outofstock)
+	MinPrice       float64 `json:"min_price" form:"min_price"`             // Filter harga minimum
+	MaxPrice       float64 `json:"max_price" form:"max_price"`             // Filter harga maksimum
+	Unit           string  `json:"unit" form:"unit"`                       // Filter berdasarkan family satuan (litre juga cocok dengan ml; atau mass/volume/count)
+	MinQty         int     `json:"min_qty" form:"min_qty"`                 // Filter quantity minimum
+	MaxQty         int     `json:"max_qty" form:"max_qty"`                 // Filter quantity maksimum
+	ExpiringWithin int     `json:"expiring_within" form:"expiring_within"` // Filter inventory dengan batch kedaluwarsa dalam N hari
+	Page           int     `json:"page" form:"page"`                       // Nomor halaman untuk pagination
+	Limit          int     `json:"limit" form:"limit"`                     // Jumlah item per halaman
+	SortBy         string  `json:"sort_by" form:"sort_by"`                 // Field untuk sorting (name/quantity/price/created_at)
+	SortDir        string  `json:"sort_dir" form:"sort_dir"`               // Arah sorting (asc/desc)
 }
 
 // InventoriesRequest merepresentasikan request payload untuk create/update Inventories
 type InventoriesRequest struct {
-	Image       string  `json:"image"`
-	Name        string  `json:"name" binding:"required"`
-	Category    string  `json:"category" binding:"required"`
-	Quantity    int     `json:"quantity" binding:"required,min=0"`
-	Status      string  `json:"status" binding:"required,oneof=active inactive"`
-	RetailPrice float64 `json:"retail_price" binding:"required,min=0"`
+	Image        string                     `json:"image"`
+	Name         string                     `json:"name" binding:"required"`
+	Category     string                     `json:"category" binding:"required"`
+	Quantity     float64                    `json:"quantity" binding:"required,min=0"`
+	QuantityUnit string                     `json:"quantity_unit" binding:"max=50"`      // Satuan Quantity jika berbeda dari Unit (contoh: ml, atau nama kemasan seperti crate); dikonversi ke Unit
+	Unit         string                     `json:"unit" binding:"max=50"`               // Satuan stok (contoh: litre, kg, pcs); default pcs
+	PackSizes    []InventoryPackSizeRequest `json:"pack_sizes" binding:"omitempty,dive"` // Kemasan pembelian; jika diisi saat update, mengganti semua kemasan lama
+	Status       string                     `json:"status" binding:"required,oneof=active inactive"`
+	RetailPrice  float64                    `json:"retail_price" binding:"required,min=0"`
+	MinStock     *int                       `json:"min_stock" binding:"omitempty,min=0"` // Batas stok minimum untuk saran pemesanan ulang; default 5
+	ChangedBy    uint                       `json:"changed_by"`                          // User ID yang mengubah stok (dicatat di stock movement)
+	Note         string                     `json:"note" binding:"max=500"`              // Alasan perubahan quantity
+}
+
+// InventoryPackSizeRequest merepresentasikan satu kemasan pembelian inventory (contoh: 1 crate = 24 litre)
+type InventoryPackSizeRequest struct {
+	Name     string  `json:"name" binding:"required,max=50"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"` // Isi satu kemasan
+	Unit     string  `json:"unit" binding:"max=50"`            // Satuan isi kemasan; default Unit inventory
+}
+
+// InventoryPackSizeResponse merepresentasikan kemasan pembelian inventory
+type InventoryPackSizeResponse struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"` // Isi satu kemasan dalam satuan inventory
 }
 
 // InventoriesResponse merepresentasikan response payload untuk Inventories
 type InventoriesResponse struct {
-	ID          int64   `json:"id"`
-	Image       string  `json:"image"`
-	Name        string  `json:"name"`
-	Category    string  `json:"category"`
-	Quantity    int     `json:"quantity"`
-	Status      string  `json:"status"`
-	RetailPrice float64 `json:"retail_price"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID          int64                       `json:"id"`
+	Image       string                      `json:"image"`
+	Name        string                      `json:"name"`
+	Category    string                      `json:"category"`
+	Quantity    float64                     `json:"quantity"`
+	Unit        string                      `json:"unit"`
+	Status      string                      `json:"status"`
+	RetailPrice float64                     `json:"retail_price"`
+	MinStock    int                         `json:"min_stock"`
+	AverageCost float64                     `json:"average_cost"`          // Harga pokok rata-rata tertimbang per unit
+	UnitFamily  string                      `json:"unit_family,omitempty"` // mass/volume/count; kosong jika satuan tidak ada di katalog
+	PackSizes   []InventoryPackSizeResponse `json:"pack_sizes,omitempty"`
+	CreatedAt   string                      `json:"created_at"`
+	UpdatedAt   string                      `json:"updated_at"`
 }
 
 // InventoriesListResponse merepresentasikan response list dengan pagination
@@ -54,3 +82,110 @@ type InventoriesSummary struct {
 	LowStockProducts   int `json:"low_stock_products"`
 	OutOfStockProducts int `json:"out_of_stock_products"`
 }
+
+// StockMovementFilter merepresentasikan parameter filter riwayat pergerakan stok
+type StockMovementFilter struct {
+	Type      string `form:"type" binding:"omitempty,oneof=purchase sale adjustment waste transfer"`
+	StartDate string `form:"start_date"` // Format YYYY-MM-DD (inklusif)
+	EndDate   string `form:"end_date"`   // Format YYYY-MM-DD (inklusif)
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+// StockMovementResponse merepresentasikan satu pergerakan stok inventory
+type StockMovementResponse struct {
+	ID            uint     `json:"id"`
+	InventoryID   int64    `json:"inventory_id"`
+	Type          string   `json:"type"`
+	Delta         float64  `json:"delta"`
+	Balance       float64  `json:"balance"`
+	UserID        *uint    `json:"user_id,omitempty"`
+	ReferenceType string   `json:"reference_type,omitempty"`
+	ReferenceID   *uint    `json:"reference_id,omitempty"`
+	Note          string   `json:"note,omitempty"`
+	UnitCost      *float64 `json:"unit_cost,omitempty"`
+	FifoValue     float64  `json:"fifo_value"`    // Perubahan nilai persediaan metode FIFO
+	AverageValue  float64  `json:"average_value"` // Perubahan nilai persediaan metode weighted average
+	CreatedAt     string   `json:"created_at"`
+}
+
+// StockMovementListResponse merepresentasikan riwayat pergerakan stok dengan pagination
+type StockMovementListResponse struct {
+	InventoryID   int64                   `json:"inventory_id"`
+	InventoryName string                  `json:"inventory_name"`
+	Unit          string                  `json:"unit"`
+	Quantity      float64                 `json:"quantity"`
+	Data          []StockMovementResponse `json:"data"`
+	Pagination    Pagination              `json:"pagination"`
+}
+
+// InventoryValuationFilter merepresentasikan parameter laporan nilai persediaan
+type InventoryValuationFilter struct {
+	Method   string `form:"method" binding:"omitempty,oneof=fifo weighted_average"` // Default dari konfigurasi INVENTORY_VALUATION_METHOD
+	Category string `form:"category"`
+}
+
+// InventoryValuationResponse merepresentasikan laporan nilai persediaan
+type InventoryValuationResponse struct {
+	Method           string                   `json:"method"`
+	TotalValue       float64                  `json:"total_value"`        // Nilai persediaan berdasarkan harga pokok
+	TotalRetailValue float64                  `json:"total_retail_value"` // Nilai persediaan berdasarkan harga jual
+	Items            []InventoryValuationItem `json:"items"`
+}
+
+// InventoryValuationItem merepresentasikan nilai persediaan satu inventory
+type InventoryValuationItem struct {
+	InventoryID int64   `json:"inventory_id"`
+	Name        string  `json:"name"`
+	Category    string  `json:"category"`
+	Unit        string  `json:"unit"`
+	Quantity    float64 `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"` // Harga pokok per unit (FIFO: rata-rata sisa cost layer)
+	Value       float64 `json:"value"`
+	RetailPrice float64 `json:"retail_price"`
+	RetailValue float64 `json:"retail_value"`
+}
+
+// InventoryBatchFilter merepresentasikan parameter daftar batch inventory
+type InventoryBatchFilter struct {
+	IncludeEmpty bool `form:"include_empty"` // Sertakan batch yang sudah habis
+}
+
+// InventoryBatchResponse merepresentasikan satu batch/lot inventory
+type InventoryBatchResponse struct {
+	ID                uint       `json:"id"`
+	BatchNumber       string     `json:"batch_number"`
+	ExpiryDate        *time.Time `json:"expiry_date,omitempty"`
+	DaysToExpiry      *int       `json:"days_to_expiry,omitempty"` // Negatif jika sudah kedaluwarsa
+	Quantity          float64    `json:"quantity"`
+	RemainingQuantity float64    `json:"remaining_quantity"`
+	StockMovementID   *uint      `json:"stock_movement_id,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// InventoryBatchListResponse merepresentasikan batch inventory urut FEFO
+type InventoryBatchListResponse struct {
+	InventoryID   int64                    `json:"inventory_id"`
+	InventoryName string                   `json:"inventory_name"`
+	Unit          string                   `json:"unit"`
+	Quantity      float64                  `json:"quantity"`
+	Batches       []InventoryBatchResponse `json:"batches"`
+}
+
+// UnitCatalogResponse merepresentasikan katalog satuan yang bisa dikonversi, dikelompokkan per family
+type UnitCatalogResponse struct {
+	Families []UnitFamilyResponse `json:"families"`
+}
+
+// UnitFamilyResponse merepresentasikan satu family satuan beserta faktor konversinya
+type UnitFamilyResponse struct {
+	Family string               `json:"family"`
+	Base   string               `json:"base"`
+	Units  []UnitFactorResponse `json:"units"`
+}
+
+// UnitFactorResponse merepresentasikan satu satuan: 1 Unit = Factor x satuan dasar family
+type UnitFactorResponse struct {
+	Unit   string  `json:"unit"`
+	Factor float64 `json:"factor"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// Jenis event websocket dapur
+const (
+	KitchenEventSnapshot      = "snapshot"       // Dikirim sekali saat koneksi dibuka, berisi tiket aktif
+	KitchenEventTicketUpdated = "ticket_updated" // Tiket baru atau status tiket/item berubah
+)
+
+// KitchenStationRequest untuk create/update station dapur
+type KitchenStationRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=50"`
+	CategoryIDs []uint `json:"category_ids"` // Kategori produk yang diarahkan ke station ini
+	IsDefault   bool   `json:"is_default"`   // Station untuk kategori yang tidak dipetakan
+}
+
+// KitchenStationResponse untuk response station dapur
+type KitchenStationResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	CategoryIDs []uint    `json:"category_ids"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// KitchenTicketResponse untuk response tiket dapur
+type KitchenTicketResponse struct {
+	ID           uint                        `json:"id"`
+	OrderID      uint                        `json:"order_id"`
+	OrderType    string                      `json:"order_type"`
+	OrderStatus  string                      `json:"order_status"`
+	TableNumber  string                      `json:"table_number"`
+	CustomerName string                      `json:"customer_name"`
+	StationID    *uint                       `json:"station_id,omitempty"`
+	StationName  string                      `json:"station_name"`
+	Status       string                      `json:"status"`
+	ReadyAt      *time.Time                  `json:"ready_at,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	Items        []KitchenTicketItemResponse `json:"items"`
+}
+
+// KitchenTicketItemResponse untuk response item tiket dapur
+type KitchenTicketItemResponse struct {
+	ID          uint       `json:"id"`
+	ProductID   uint       `json:"product_id"`
+	ProductName string     `json:"product_name"`
+	Note        string     `json:"note,omitempty"`
+	Quantity    int        `json:"quantity"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	ReadyAt     *time.Time `json:"ready_at,omitempty"`
+}
+
+// KitchenEvent adalah pesan yang dikirim lewat websocket dapur
+type KitchenEvent struct {
+	Type    string                  `json:"type"`
+	Ticket  *KitchenTicketResponse  `json:"ticket,omitempty"`
+	Tickets []KitchenTicketResponse `json:"tickets,omitempty"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// ModifierOptionRequest untuk satu opsi modifier. ID diisi saat update untuk mempertahankan opsi lama.
+type ModifierOptionRequest struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name" binding:"required,min=1,max=50"`
+	PriceDelta  float64 `json:"price_delta"`  // Tambahan harga per satuan, boleh 0 atau negatif
+	IsAvailable *bool   `json:"is_available"` // Default true
+}
+
+// ModifierGroupRequest untuk create/update modifier group
+// MinSelect > 0 berarti group wajib dipilih, MaxSelect 0 berarti tanpa batas
+type ModifierGroupRequest struct {
+	Name        string                  `json:"name" binding:"required,min=1,max=50"`
+	MinSelect   int                     `json:"min_select" binding:"min=0"`
+	MaxSelect   int                     `json:"max_select" binding:"min=0"`
+	Options     []ModifierOptionRequest `json:"options" binding:"required,min=1,dive"`
+	ProductIDs  []uint                  `json:"product_ids"`  // Produk yang memakai group ini
+	CategoryIDs []uint                  `json:"category_ids"` // Kategori yang memakai group ini (berlaku untuk semua produknya)
+}
+
+// ModifierGroupResponse untuk response modifier group
+type ModifierGroupResponse struct {
+	ID          uint                     `json:"id"`
+	Name        string                   `json:"name"`
+	MinSelect   int                      `json:"min_select"`
+	MaxSelect   int                      `json:"max_select"`
+	Options     []ModifierOptionResponse `json:"options"`
+	ProductIDs  []uint                   `json:"product_ids"`
+	CategoryIDs []uint                   `json:"category_ids"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// ModifierOptionResponse untuk response opsi modifier
+type ModifierOptionResponse struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name"`
+	PriceDelta  float64 `json:"price_delta"`
+	IsAvailable bool    `json:"is_available"`
+}
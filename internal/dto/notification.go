@@ -2,6 +2,12 @@ package dto
 
 import "time"
 
+// Jenis event stream notifikasi (websocket dan SSE)
+const (
+	NotificationEventCreated     = "notification" // Notifikasi baru, ID event = ID notifikasi
+	NotificationEventUnreadCount = "unread_count" // Jumlah notifikasi belum dibaca berubah
+)
+
 // NotificationListRequest untuk filter notifikasi
 type NotificationListRequest struct {
 	UserID    uint   `json:"user_id" binding:"required"`
@@ -71,3 +77,12 @@ type CreateNotificationRequest struct {
 	Type    string `json:"type" binding:"required,oneof=order payment system alert"`
 	Data    string `json:"data"`
 }
+
+// NotificationStreamEvent adalah pesan yang dikirim lewat stream notifikasi. ID hanya diisi untuk
+// event notification dan dipakai client sebagai Last-Event-ID saat menyambung ulang.
+type NotificationStreamEvent struct {
+	ID           uint                  `json:"id,omitempty"`
+	Type         string                `json:"type"`
+	Notification *NotificationResponse `json:"notification,omitempty"`
+	UnreadCount  int64                 `json:"unread_count"`
+}
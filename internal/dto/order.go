@@ -3,52 +3,278 @@ package dto
 import "time"
 
 // OrderItemRequest untuk item dalam order
+// Harga diambil dari katalog produk ditambah price delta modifier yang dipilih. Price adalah harga
+// satuan termasuk modifier (sama dengan Price di response) dan hanya dianggap override, yang butuh
+// persetujuan manager, jika berbeda dari harga tersebut.
 type OrderItemRequest struct {
-	ProductID uint    `json:"product_id" binding:"required"`
-	Quantity  int     `json:"quantity" binding:"required,min=1"`
-	Price     float64 `json:"price" binding:"required,min=0"`
+	ProductID         uint                       `json:"product_id" binding:"required"`
+	Quantity          int                        `json:"quantity" binding:"required,min=1"`
+	Price             *float64                   `json:"price,omitempty" binding:"omitempty,min=0"`
+	VariantID         *uint                      `json:"variant_id,omitempty"`                    // Wajib untuk produk yang punya varian
+	ModifierOptionIDs []uint                     `json:"modifier_option_ids"`                     // Opsi modifier yang dipilih (contoh: extra cheese, ukuran large)
+	Notes             string                     `json:"notes" binding:"max=255"`                 // Catatan bebas untuk dapur (contoh: tidak pedas)
+	BundleChoices     []OrderBundleChoiceRequest `json:"bundle_choices" binding:"omitempty,dive"` // Pilihan untuk slot bundle yang punya beberapa opsi
+}
+
+// OrderBundleChoiceRequest untuk opsi yang dipilih pada satu slot bundle (contoh: pilih minuman)
+type OrderBundleChoiceRequest struct {
+	SlotID   uint `json:"slot_id" binding:"required"`
+	OptionID uint `json:"option_id" binding:"required"`
 }
 
 // OrderCreateRequest untuk create order baru
 type OrderCreateRequest struct {
 	UserID          uint               `json:"user_id" binding:"required"`
 	TableID         uint               `json:"table_id" binding:"required"`
+	OrderType       string             `json:"order_type" binding:"omitempty,oneof=dine_in take_away"` // Default dine_in
+	OutletID        uint               `json:"outlet_id"`                                              // Default outlet utama
 	PaymentMethodID uint               `json:"payment_method_id" binding:"required"`
 	CustomerName    string             `json:"customer_name" binding:"required,min=1,max=100"`
-	Items           []OrderItemRequest `json:"items" binding:"required,min=1"`
-	Tax             float64            `json:"tax" binding:"min=0"` // Pajak statis, bisa dihitung otomatis jika tidak disediakan
+	Items           []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	PromoCode       string             `json:"promo_code"` // Kode promo opsional
+	PriceApprovedBy uint               `json:"-"`          // User login (JWT); menyetujui override harga jika role-nya manager
 }
 
 // OrderUpdateRequest untuk update order
 type OrderUpdateRequest struct {
 	CustomerName    string             `json:"customer_name" binding:"required,min=1,max=100"`
 	PaymentMethodID uint               `json:"payment_method_id" binding:"required"`
-	Items           []OrderItemRequest `json:"items" binding:"required,min=1"`
+	Items           []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	PromoCode       string             `json:"promo_code"` // Kode promo opsional
+	PriceApprovedBy uint               `json:"-"`          // User login (JWT); menyetujui override harga jika role-nya manager
+}
+
+// OrderStatusUpdateRequest untuk mengubah status order; pelunasan lewat pembayaran, pembatalan lewat void
+type OrderStatusUpdateRequest struct {
+	Status    string `json:"status" binding:"required,oneof=pending in_kitchen served"`
+	ChangedBy uint   `json:"-"` // User login (JWT) yang melakukan perubahan status
+	Note      string `json:"note"`
+}
+
+// OrderPaymentTenderRequest untuk satu tender pembayaran
+// Amount adalah uang yang diterima; untuk tunai boleh lebih dari sisa tagihan (ada kembalian)
+type OrderPaymentTenderRequest struct {
+	PaymentMethodID uint    `json:"payment_method_id" binding:"required"`
+	Amount          float64 `json:"amount" binding:"required,gt=0"`
+	Reference       string  `json:"reference" binding:"max=100"` // Nomor referensi EDC/QRIS
+}
+
+// OrderPaymentRequest untuk membayar order dengan satu atau beberapa tender
+type OrderPaymentRequest struct {
+	Tenders    []OrderPaymentTenderRequest `json:"tenders" binding:"required,min=1,dive"`
+	ReceivedBy uint                        `json:"-"` // User login (JWT) kasir yang menerima pembayaran
+}
+
+// OrderPaymentResponse untuk response satu pembayaran order
+type OrderPaymentResponse struct {
+	ID              uint      `json:"id"`
+	PaymentMethodID uint      `json:"payment_method_id"`
+	PaymentMethod   string    `json:"payment_method"`
+	Amount          float64   `json:"amount"`
+	Tendered        float64   `json:"tendered"`
+	Change          float64   `json:"change"`
+	Reference       string    `json:"reference,omitempty"`
+	ReceivedBy      *uint     `json:"received_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// OrderSettlementResponse untuk response hasil pembayaran order
+type OrderSettlementResponse struct {
+	OrderID         uint                   `json:"order_id"`
+	Status          string                 `json:"status"`
+	TotalAmount     float64                `json:"total_amount"`
+	PaidAmount      float64                `json:"paid_amount"`
+	RemainingAmount float64                `json:"remaining_amount"`
+	ChangeDue       float64                `json:"change_due"` // Kembalian dari tender tunai pada pembayaran ini
+	Payments        []OrderPaymentResponse `json:"payments"`   // Semua pembayaran order
+}
+
+// OrderSplitItemRequest untuk satu item yang dipindah ke order baru
+type OrderSplitItemRequest struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// OrderSplitItemsRequest untuk memindah sebagian item order ke order baru
+type OrderSplitItemsRequest struct {
+	Items        []OrderSplitItemRequest `json:"items" binding:"required,min=1,dive"`
+	TableID      uint                    `json:"table_id"`      // Default meja order asal
+	CustomerName string                  `json:"customer_name"` // Default nama customer order asal
+	CreatedBy    uint                    `json:"-"`             // User login (JWT) yang melakukan split
+}
+
+// OrderSplitEvenRequest untuk membagi order menjadi beberapa bagian dengan nominal sama
+type OrderSplitEvenRequest struct {
+	Parts     int  `json:"parts" binding:"required,min=2,max=20"`
+	CreatedBy uint `json:"-"` // User login (JWT) yang melakukan split
+}
+
+// OrderMergeRequest untuk menggabungkan order lain ke order ini
+type OrderMergeRequest struct {
+	OrderIDs  []uint `json:"order_ids" binding:"required,min=1"`
+	CreatedBy uint   `json:"-"` // User login (JWT) yang melakukan merge
+}
+
+// OrderLinkResponse untuk jejak audit split/merge order
+type OrderLinkResponse struct {
+	ID            uint      `json:"id"`
+	SourceOrderID uint      `json:"source_order_id"`
+	ResultOrderID uint      `json:"result_order_id"`
+	LinkType      string    `json:"link_type"`
+	CreatedBy     *uint     `json:"created_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// OrderVoidRequest untuk void order yang belum dibayar
+type OrderVoidRequest struct {
+	ReasonCode  string `json:"reason_code" binding:"required,oneof=customer_request wrong_order quality_issue duplicate payment_issue other"`
+	Note        string `json:"note"`
+	ApprovedBy  uint   `json:"-"`       // User login (JWT) yang menyetujui, harus manager
+	ProcessedBy uint   `json:"-"`       // User login (JWT) yang memproses
+	Restock     *bool  `json:"restock"` // Default true, false jika item sudah terlanjur dibuat
+}
+
+// OrderRefundItemRequest untuk satu item yang di-refund
+type OrderRefundItemRequest struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Quantity    int  `json:"quantity" binding:"required,min=1"`
+}
+
+// OrderRefundRequest untuk refund order yang sudah dibayar
+// Items kosong berarti refund penuh atas semua item yang belum di-refund
+type OrderRefundRequest struct {
+	ReasonCode      string                   `json:"reason_code" binding:"required,oneof=customer_request wrong_order quality_issue duplicate payment_issue other"`
+	Note            string                   `json:"note"`
+	ApprovedBy      uint                     `json:"-"` // User login (JWT) yang menyetujui, harus manager
+	ProcessedBy     uint                     `json:"-"` // User login (JWT) yang memproses
+	Items           []OrderRefundItemRequest `json:"items" binding:"omitempty,dive"`
+	Restock         bool                     `json:"restock"`           // True jika item yang di-refund dikembalikan ke stok
+	PaymentMethodID uint                     `json:"payment_method_id"` // Metode pengembalian uang, default metode pembayaran order
+}
+
+// OrderRefundItemResponse untuk rincian item yang di-refund
+type OrderRefundItemResponse struct {
+	OrderItemID uint    `json:"order_item_id"`
+	ProductID   uint    `json:"product_id"`
+	Quantity    int     `json:"quantity"`
+	Amount      float64 `json:"amount"`
+}
+
+// OrderRefundResponse untuk response void/refund order
+type OrderRefundResponse struct {
+	ID              uint                      `json:"id"`
+	OrderID         uint                      `json:"order_id"`
+	Type            string                    `json:"type"`
+	ReasonCode      string                    `json:"reason_code"`
+	Note            string                    `json:"note"`
+	Amount          float64                   `json:"amount"`
+	PaymentMethodID *uint                     `json:"payment_method_id,omitempty"`
+	Restock         bool                      `json:"restock"`
+	ApprovedBy      uint                      `json:"approved_by"`
+	ProcessedBy     *uint                     `json:"processed_by,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	Items           []OrderRefundItemResponse `json:"items"`
+}
+
+// OrderStatusHistoryResponse untuk response riwayat status order
+type OrderStatusHistoryResponse struct {
+	ID         uint      `json:"id"`
+	OrderID    uint      `json:"order_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  *uint     `json:"changed_by,omitempty"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 // OrderResponse untuk response order
 type OrderResponse struct {
-	ID              uint                `json:"id"`
-	UserID          uint                `json:"user_id"`
-	TableID         uint                `json:"table_id"`
-	PaymentMethodID uint                `json:"payment_method_id"`
-	CustomerName    string              `json:"customer_name"`
-	TotalAmount     float64             `json:"total_amount"`
-	Tax             float64             `json:"tax"`
-	Status          string              `json:"status"`
-	CreatedAt       time.Time           `json:"created_at"`
-	UpdatedAt       time.Time           `json:"updated_at"`
-	Items           []OrderItemResponse `json:"items"`
+	ID               uint                    `json:"id"`
+	UserID           uint                    `json:"user_id"`
+	TableID          uint                    `json:"table_id"`
+	OrderType        string                  `json:"order_type"`
+	OutletID         uint                    `json:"outlet_id"`
+	PaymentMethodID  uint                    `json:"payment_method_id"`
+	CustomerName     string                  `json:"customer_name"`
+	Subtotal         float64                 `json:"subtotal"`
+	DiscountAmount   float64                 `json:"discount_amount"`
+	PromoCode        string                  `json:"promo_code,omitempty"`
+	ServiceCharge    float64                 `json:"service_charge"`
+	Tax              float64                 `json:"tax"`
+	PricesIncludeTax bool                    `json:"prices_include_tax"`
+	TotalAmount      float64                 `json:"total_amount"`
+	PaidAmount       float64                 `json:"paid_amount"`
+	RefundedAmount   float64                 `json:"refunded_amount"`
+	ShareIndex       int                     `json:"share_index,omitempty"` // Bagian ke-n jika hasil split rata
+	ShareCount       int                     `json:"share_count,omitempty"`
+	Status           string                  `json:"status"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+	Items            []OrderItemResponse     `json:"items"`
+	Taxes            []OrderTaxResponse      `json:"taxes"`
+	Discounts        []OrderDiscountResponse `json:"discounts"`
+	Payments         []OrderPaymentResponse  `json:"payments"`
+}
+
+// OrderDiscountResponse untuk rincian promo yang diterapkan ke order
+type OrderDiscountResponse struct {
+	PromotionID uint    `json:"promotion_id"`
+	Code        string  `json:"code,omitempty"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Scope       string  `json:"scope"`
+	Amount      float64 `json:"amount"`
+}
+
+// OrderTaxResponse untuk rincian pajak dan service charge order
+type OrderTaxResponse struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Rate        float64 `json:"rate"`
+	BaseAmount  float64 `json:"base_amount"`
+	Amount      float64 `json:"amount"`
+	IsInclusive bool    `json:"is_inclusive"`
 }
 
 // OrderItemResponse untuk response item order
 type OrderItemResponse struct {
-	ID        uint    `json:"id"`
-	OrderID   uint    `json:"order_id"`
-	ProductID uint    `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
-	Subtotal  float64 `json:"subtotal"`
+	ID              uint                         `json:"id"`
+	OrderID         uint                         `json:"order_id"`
+	ProductID       uint                         `json:"product_id"`
+	VariantID       *uint                        `json:"variant_id,omitempty"`
+	VariantName     string                       `json:"variant_name,omitempty"`
+	Quantity        int                          `json:"quantity"`
+	Price           float64                      `json:"price"`
+	ListPrice       float64                      `json:"list_price"`
+	IsPriceOverride bool                         `json:"is_price_override"`
+	PriceApprovedBy *uint                        `json:"price_approved_by,omitempty"`
+	Subtotal        float64                      `json:"subtotal"`
+	DiscountAmount  float64                      `json:"discount_amount"`
+	PromotionID     *uint                        `json:"promotion_id,omitempty"`
+	ModifierAmount  float64                      `json:"modifier_amount"` // Total price delta modifier per satuan, sudah termasuk di Price
+	Notes           string                       `json:"notes"`
+	Modifiers       []OrderItemModifierResponse  `json:"modifiers"`
+	Components      []OrderItemComponentResponse `json:"components,omitempty"` // Komponen jika produk adalah bundle
+}
+
+// OrderItemComponentResponse untuk komponen bundle pada item order
+type OrderItemComponentResponse struct {
+	BundleSlotID    uint    `json:"bundle_slot_id"`
+	SlotName        string  `json:"slot_name"`
+	ProductID       uint    `json:"product_id"`
+	VariantID       *uint   `json:"variant_id,omitempty"`
+	ProductName     string  `json:"product_name"`
+	Quantity        int     `json:"quantity"`         // Quantity per satu bundle
+	AllocationRatio float64 `json:"allocation_ratio"` // Porsi pendapatan item yang dialokasikan ke komponen
+}
+
+// OrderItemModifierResponse untuk modifier yang dipilih pada item order
+type OrderItemModifierResponse struct {
+	ModifierGroupID  uint    `json:"modifier_group_id"`
+	ModifierOptionID uint    `json:"modifier_option_id"`
+	GroupName        string  `json:"group_name"`
+	OptionName       string  `json:"option_name"`
+	PriceDelta       float64 `json:"price_delta"`
 }
 
 // OrderListResponse untuk response list order
@@ -88,6 +314,7 @@ type TableResponse struct {
 
 // PaymentMethodResponse untuk response payment method
 type PaymentMethodResponse struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	IsCash bool   `json:"is_cash"`
 }
@@ -14,47 +14,116 @@ type ProductFilterRequest struct {
 
 // ProductCreateRequest untuk create product baru
 type ProductCreateRequest struct {
-	ProductImage string  `json:"product_image"`
-	ProductName  string  `json:"product_name" binding:"required,min=2,max=100"`
-	Stock        int     `json:"stock" binding:"min=0"`
-	CategoryID   uint    `json:"category_id" binding:"required"`
-	Price        float64 `json:"price" binding:"required,min=0"`
+	ProductImage string                  `json:"product_image"`
+	ProductName  string                  `json:"product_name" binding:"required,min=2,max=100"`
+	Stock        int                     `json:"stock" binding:"min=0"`
+	MinStock     *int                    `json:"min_stock" binding:"omitempty,min=0"` // Batas notifikasi stok menipis; default 5 (create) atau nilai lama (update)
+	CategoryID   uint                    `json:"category_id" binding:"required"`
+	Price        float64                 `json:"price" binding:"min=0"` // Wajib jika tanpa varian; jika ada varian, harga dan stok dihitung dari varian
+	Variants     []ProductVariantRequest `json:"variants" binding:"omitempty,dive"`
+	Type         string                  `json:"type" binding:"omitempty,oneof=single bundle"` // Default single
+	BundleSlots  []BundleSlotRequest     `json:"bundle_slots" binding:"omitempty,dive"`        // Wajib untuk type bundle
 }
 
 // ProductUpdateRequest untuk update product
 type ProductUpdateRequest struct {
-	ProductImage string  `json:"product_image"`
-	ProductName  string  `json:"product_name" binding:"required,min=2,max=100"`
-	Stock        int     `json:"stock" binding:"min=0"`
-	CategoryID   uint    `json:"category_id" binding:"required"`
-	Price        float64 `json:"price" binding:"required,min=0"`
+	ProductImage string                  `json:"product_image"`
+	ProductName  string                  `json:"product_name" binding:"required,min=2,max=100"`
+	Stock        int                     `json:"stock" binding:"min=0"`
+	MinStock     *int                    `json:"min_stock" binding:"omitempty,min=0"` // Batas notifikasi stok menipis; default 5 (create) atau nilai lama (update)
+	CategoryID   uint                    `json:"category_id" binding:"required"`
+	Price        float64                 `json:"price" binding:"min=0"` // Wajib jika tanpa varian; jika ada varian, harga dan stok dihitung dari varian
+	Variants     []ProductVariantRequest `json:"variants" binding:"omitempty,dive"`
+	Type         string                  `json:"type" binding:"omitempty,oneof=single bundle"` // Default single
+	BundleSlots  []BundleSlotRequest     `json:"bundle_slots" binding:"omitempty,dive"`        // Wajib untuk type bundle
 }
 
-// ProductResponse untuk response product detail
-type ProductResponse struct {
+// ProductVariantRequest untuk varian product (contoh: Hot Regular, Iced Large).
+// ID diisi untuk memperbarui varian lama; varian lama yang tidak dikirim akan dihapus.
+// SKU dibuat otomatis dari item_id jika kosong.
+type ProductVariantRequest struct {
+	ID    uint    `json:"id"`
+	Name  string  `json:"name" binding:"required,min=1,max=100"`
+	SKU   string  `json:"sku" binding:"max=50"`
+	Price float64 `json:"price" binding:"required,min=0"`
+	Stock int     `json:"stock" binding:"min=0"`
+}
+
+// BundleSlotRequest untuk slot komponen bundle (contoh: Burger, Fries, Drink).
+// Slot dengan satu opsi adalah komponen tetap; beberapa opsi berarti pelanggan memilih salah satu.
+type BundleSlotRequest struct {
+	Name     string                    `json:"name" binding:"required,min=1,max=50"`
+	Quantity int                       `json:"quantity" binding:"min=0"` // Jumlah komponen per bundle, default 1
+	Options  []BundleSlotOptionRequest `json:"options" binding:"required,min=1,dive"`
+}
+
+// BundleSlotOptionRequest untuk produk yang bisa dipilih pada slot bundle
+type BundleSlotOptionRequest struct {
+	ProductID uint  `json:"product_id" binding:"required"`
+	VariantID *uint `json:"variant_id,omitempty"` // Wajib jika produk komponen punya varian
+}
+
+// BundleSlotResponse untuk response slot komponen bundle
+type BundleSlotResponse struct {
+	ID       uint                       `json:"id"`
+	Name     string                     `json:"name"`
+	Quantity int                        `json:"quantity"`
+	Options  []BundleSlotOptionResponse `json:"options"`
+}
+
+// BundleSlotOptionResponse untuk response opsi slot bundle
+type BundleSlotOptionResponse struct {
+	ID          uint    `json:"id"`
+	ProductID   uint    `json:"product_id"`
+	VariantID   *uint   `json:"variant_id,omitempty"`
+	ProductName string  `json:"product_name"`
+	Price       float64 `json:"price"` // Harga katalog komponen, dipakai untuk alokasi pendapatan
+	Stock       int     `json:"stock"`
+}
+
+// ProductVariantResponse untuk response varian product
+type ProductVariantResponse struct {
 	ID           uint    `json:"id"`
-	ProductImage string  `json:"product_image"`
-	ProductName  string  `json:"product_name"`
-	ItemID       string  `json:"item_id"`
-	Stock        int     `json:"stock"`
-	CategoryID   uint    `json:"category_id"`
-	CategoryName string  `json:"category_name"`
+	SKU          string  `json:"sku"`
+	Name         string  `json:"name"`
 	Price        float64 `json:"price"`
+	Stock        int     `json:"stock"`
 	IsAvailable  bool    `json:"is_available"`
 	Availability string  `json:"availability"` // "in_stock" atau "out_of_stock"
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
+}
+
+// ProductResponse untuk response product detail
+type ProductResponse struct {
+	ID           uint                     `json:"id"`
+	ProductImage string                   `json:"product_image"`
+	ProductName  string                   `json:"product_name"`
+	ItemID       string                   `json:"item_id"`
+	Stock        int                      `json:"stock"`
+	MinStock     int                      `json:"min_stock"`
+	CategoryID   uint                     `json:"category_id"`
+	CategoryName string                   `json:"category_name"`
+	Price        float64                  `json:"price"`
+	IsAvailable  bool                     `json:"is_available"`
+	Availability string                   `json:"availability"` // "in_stock" atau "out_of_stock"
+	Variants     []ProductVariantResponse `json:"variants"`     // Harga = harga varian termurah, stok = total stok varian
+	Type         string                   `json:"type"`         // single atau bundle
+	BundleSlots  []BundleSlotResponse     `json:"bundle_slots"` // Stok bundle = jumlah paket yang masih bisa dibuat dari komponen
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
 }
 
 // ProductListResponse untuk response list product (sesuai UI)
 type ProductListResponse struct {
-	ID           uint    `json:"id"`
-	ProductImage string  `json:"product_image"`
-	ProductName  string  `json:"product_name"`
-	ItemID       string  `json:"item_id"`
-	Stock        int     `json:"stock"`
-	CategoryName string  `json:"category_name"`
-	Price        float64 `json:"price"`
-	IsAvailable  bool    `json:"is_available"`
-	Availability string  `json:"availability"` // "in_stock" atau "out_of_stock"
+	ID           uint                     `json:"id"`
+	ProductImage string                   `json:"product_image"`
+	ProductName  string                   `json:"product_name"`
+	ItemID       string                   `json:"item_id"`
+	Stock        int                      `json:"stock"`
+	CategoryName string                   `json:"category_name"`
+	Price        float64                  `json:"price"`
+	IsAvailable  bool                     `json:"is_available"`
+	Availability string                   `json:"availability"` // "in_stock" atau "out_of_stock"
+	Variants     []ProductVariantResponse `json:"variants"`
+	Type         string                   `json:"type"`
+	BundleSlots  []BundleSlotResponse     `json:"bundle_slots"`
 }
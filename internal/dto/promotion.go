@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// PromotionRequest untuk create/update promo
+type PromotionRequest struct {
+	Name           string     `json:"name" binding:"required,min=1,max=100"`
+	Code           string     `json:"code" binding:"max=50"` // Kosong = promo otomatis
+	Type           string     `json:"type" binding:"required,oneof=percentage fixed buy_x_get_y"`
+	Scope          string     `json:"scope" binding:"required,oneof=order category product"`
+	CategoryID     *uint      `json:"category_id"` // Wajib untuk scope category
+	ProductID      *uint      `json:"product_id"`  // Wajib untuk scope product
+	Value          float64    `json:"value" binding:"min=0"`
+	BuyQuantity    int        `json:"buy_quantity" binding:"min=0"`
+	GetQuantity    int        `json:"get_quantity" binding:"min=0"`
+	MinOrderAmount float64    `json:"min_order_amount" binding:"min=0"`
+	MaxDiscount    float64    `json:"max_discount" binding:"min=0"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	DailyStart     string     `json:"daily_start"`  // Happy hour mulai, format HH:MM
+	DailyEnd       string     `json:"daily_end"`    // Happy hour selesai, format HH:MM
+	DaysOfWeek     []int      `json:"days_of_week"` // 0 = Minggu ... 6 = Sabtu, kosong = setiap hari
+	UsageLimit     int        `json:"usage_limit" binding:"min=0"`
+	IsActive       *bool      `json:"is_active"` // Default true
+}
+
+// PromotionResponse untuk response promo
+type PromotionResponse struct {
+	ID             uint       `json:"id"`
+	Name           string     `json:"name"`
+	Code           string     `json:"code,omitempty"`
+	Type           string     `json:"type"`
+	Scope          string     `json:"scope"`
+	CategoryID     *uint      `json:"category_id,omitempty"`
+	ProductID      *uint      `json:"product_id,omitempty"`
+	Value          float64    `json:"value"`
+	BuyQuantity    int        `json:"buy_quantity"`
+	GetQuantity    int        `json:"get_quantity"`
+	MinOrderAmount float64    `json:"min_order_amount"`
+	MaxDiscount    float64    `json:"max_discount"`
+	StartsAt       *time.Time `json:"starts_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	DailyStart     string     `json:"daily_start,omitempty"`
+	DailyEnd       string     `json:"daily_end,omitempty"`
+	DaysOfWeek     []int      `json:"days_of_week,omitempty"`
+	UsageLimit     int        `json:"usage_limit"`
+	UsageCount     int        `json:"usage_count"`
+	IsActive       bool       `json:"is_active"`
+	IsRunningNow   bool       `json:"is_running_now"` // True jika promo berlaku saat ini (periode & happy hour)
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
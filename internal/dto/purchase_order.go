@@ -0,0 +1,113 @@
+package dto
+
+import "time"
+
+// PurchaseOrderRequest untuk membuat/mengubah purchase order draft
+type PurchaseOrderRequest struct {
+	SupplierID   uint                       `json:"supplier_id" binding:"required"`
+	ExpectedDate *time.Time                 `json:"expected_date"`
+	Note         string                     `json:"note"`
+	CreatedBy    uint                       `json:"created_by"`
+	Items        []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// PurchaseOrderItemRequest untuk satu barang inventory yang dipesan (quantity dalam satuan inventory)
+type PurchaseOrderItemRequest struct {
+	InventoryID int64   `json:"inventory_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	UnitCost    float64 `json:"unit_cost" binding:"min=0"`
+}
+
+// PurchaseOrderFilter untuk query daftar purchase order
+type PurchaseOrderFilter struct {
+	Status     string `form:"status" binding:"omitempty,oneof=draft sent partially_received received cancelled"`
+	SupplierID uint   `form:"supplier_id"`
+}
+
+// GoodsReceiptRequest untuk mencatat penerimaan barang purchase order
+type GoodsReceiptRequest struct {
+	ReceivedBy uint                      `json:"received_by"`
+	Note       string                    `json:"note"`
+	Items      []GoodsReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// GoodsReceiptItemRequest untuk quantity dan harga satuan aktual satu item purchase order
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemID uint       `json:"purchase_order_item_id" binding:"required"`
+	Quantity            float64    `json:"quantity" binding:"required,gt=0"`
+	UnitCost            *float64   `json:"unit_cost" binding:"omitempty,min=0"` // Default harga pesanan
+	BatchNumber         string     `json:"batch_number" binding:"max=50"`       // Nomor batch/lot dari supplier
+	ExpiryDate          *time.Time `json:"expiry_date"`                         // Tanggal kedaluwarsa batch (barang perishable)
+}
+
+// PurchaseOrderResponse untuk response purchase order
+type PurchaseOrderResponse struct {
+	ID           uint                        `json:"id"`
+	PONumber     string                      `json:"po_number"`
+	SupplierID   uint                        `json:"supplier_id"`
+	SupplierName string                      `json:"supplier_name"`
+	Status       string                      `json:"status"`
+	ExpectedDate *time.Time                  `json:"expected_date,omitempty"`
+	Note         string                      `json:"note"`
+	TotalAmount  float64                     `json:"total_amount"`
+	CreatedBy    *uint                       `json:"created_by,omitempty"`
+	SentAt       *time.Time                  `json:"sent_at,omitempty"`
+	ReceivedAt   *time.Time                  `json:"received_at,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	Items        []PurchaseOrderItemResponse `json:"items"`
+	Receipts     []GoodsReceiptResponse      `json:"receipts,omitempty"`
+}
+
+// PurchaseOrderItemResponse untuk response item purchase order
+type PurchaseOrderItemResponse struct {
+	ID                uint    `json:"id"`
+	InventoryID       int64   `json:"inventory_id"`
+	InventoryName     string  `json:"inventory_name"`
+	Unit              string  `json:"unit"`
+	Quantity          float64 `json:"quantity"`
+	ReceivedQuantity  float64 `json:"received_quantity"`
+	RemainingQuantity float64 `json:"remaining_quantity"`
+	UnitCost          float64 `json:"unit_cost"`
+	Subtotal          float64 `json:"subtotal"`
+}
+
+// GoodsReceiptResponse untuk response goods received note
+type GoodsReceiptResponse struct {
+	ID              uint                       `json:"id"`
+	ReceiptNumber   string                     `json:"receipt_number"`
+	PurchaseOrderID uint                       `json:"purchase_order_id"`
+	ReceivedBy      *uint                      `json:"received_by,omitempty"`
+	Note            string                     `json:"note"`
+	TotalCost       float64                    `json:"total_cost"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	Items           []GoodsReceiptItemResponse `json:"items"`
+}
+
+// GoodsReceiptItemResponse untuk response item penerimaan barang
+type GoodsReceiptItemResponse struct {
+	ID                  uint       `json:"id"`
+	PurchaseOrderItemID uint       `json:"purchase_order_item_id"`
+	InventoryID         int64      `json:"inventory_id"`
+	Quantity            float64    `json:"quantity"`
+	UnitCost            float64    `json:"unit_cost"`
+	StockMovementID     uint       `json:"stock_movement_id"`
+	BatchNumber         string     `json:"batch_number,omitempty"`
+	ExpiryDate          *time.Time `json:"expiry_date,omitempty"`
+}
+
+// ReorderSuggestionResponse untuk saran pemesanan ulang inventory di bawah MinStock.
+// SuggestedQuantity mengisi stok sampai 2x MinStock setelah dikurangi barang yang sudah dipesan.
+type ReorderSuggestionResponse struct {
+	InventoryID       int64   `json:"inventory_id"`
+	InventoryName     string  `json:"inventory_name"`
+	Unit              string  `json:"unit"`
+	Quantity          float64 `json:"quantity"`
+	MinStock          int     `json:"min_stock"`
+	OnOrder           float64 `json:"on_order"`
+	SuggestedQuantity float64 `json:"suggested_quantity"`
+	SupplierID        *uint   `json:"supplier_id,omitempty"`
+	SupplierName      string  `json:"supplier_name,omitempty"`
+	LastUnitCost      float64 `json:"last_unit_cost"`
+	EstimatedCost     float64 `json:"estimated_cost"`
+}
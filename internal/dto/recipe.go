@@ -0,0 +1,35 @@
+package dto
+
+// RecipeRequest untuk mengganti seluruh resep produk
+type RecipeRequest struct {
+	Items []RecipeItemRequest `json:"items" binding:"omitempty,dive"` // Kosongkan untuk menghapus resep
+}
+
+// RecipeItemRequest untuk satu bahan resep per porsi produk
+type RecipeItemRequest struct {
+	InventoryID int64   `json:"inventory_id" binding:"required"`
+	VariantID   *uint   `json:"variant_id,omitempty"` // Kosong = dipakai semua varian
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+	Unit        string  `json:"unit" binding:"max=50"` // Default satuan inventory; harus bisa dikonversi (contoh: ml ke litre) atau nama kemasan inventory
+}
+
+// RecipeResponse untuk response resep produk
+type RecipeResponse struct {
+	ProductID         uint                 `json:"product_id"`
+	ProductName       string               `json:"product_name"`
+	Items             []RecipeItemResponse `json:"items"`
+	AvailablePortions *float64             `json:"available_portions,omitempty"` // Porsi yang bisa dibuat dari stok bahan (produk tanpa varian)
+}
+
+// RecipeItemResponse untuk response satu bahan resep
+type RecipeItemResponse struct {
+	ID                uint    `json:"id"`
+	VariantID         *uint   `json:"variant_id,omitempty"`
+	InventoryID       int64   `json:"inventory_id"`
+	InventoryName     string  `json:"inventory_name"`
+	Quantity          float64 `json:"quantity"`
+	Unit              string  `json:"unit"`
+	InventoryUnit     string  `json:"inventory_unit"`
+	InventoryQuantity float64 `json:"inventory_quantity"` // Quantity per porsi dalam satuan inventory
+	InventoryStock    float64 `json:"inventory_stock"`
+}
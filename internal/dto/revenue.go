@@ -3,46 +3,102 @@ package dto
 import "time"
 
 // RevenueByStatusResponse untuk response total revenue dan breakdown berdasarkan status
+// GrossRevenue = subtotal sebelum diskon, NetRevenue = GrossRevenue - DiscountAmount (belum termasuk pajak)
 type RevenueByStatusResponse struct {
-	TotalRevenue float64                  `json:"total_revenue"`
-	Breakdown    []RevenueStatusBreakdown `json:"breakdown"`
+	TotalRevenue   float64                  `json:"total_revenue"`
+	GrossRevenue   float64                  `json:"gross_revenue"`
+	DiscountAmount float64                  `json:"discount_amount"`
+	NetRevenue     float64                  `json:"net_revenue"`
+	VoidedAmount   float64                  `json:"voided_amount"`   // Nilai void order dengan status ini
+	RefundedAmount float64                  `json:"refunded_amount"` // Nilai refund order dengan status ini (termasuk refund sebagian)
+	Breakdown      []RevenueStatusBreakdown `json:"breakdown"`
+	PaymentMethods []PaymentMethodRevenue   `json:"payment_methods"` // Pembayaran yang diterima per metode pembayaran
+}
+
+// PaymentMethodRevenue untuk breakdown pembayaran per metode pembayaran
+// TotalAmount adalah nilai yang dipakai melunasi order (tidak termasuk kembalian)
+type PaymentMethodRevenue struct {
+	PaymentMethodID uint    `json:"payment_method_id"`
+	PaymentMethod   string  `json:"payment_method"`
+	TotalAmount     float64 `json:"total_amount"`
+	PaymentCount    int     `json:"payment_count"`
+	OrderCount      int     `json:"order_count"`
 }
 
 // RevenueStatusBreakdown untuk breakdown revenue per status
 type RevenueStatusBreakdown struct {
-	Status       string  `json:"status"`
-	TotalRevenue float64 `json:"total_revenue"`
-	OrderCount   int     `json:"order_count"`
+	Status         string  `json:"status"`
+	TotalRevenue   float64 `json:"total_revenue"`
+	GrossRevenue   float64 `json:"gross_revenue"`
+	DiscountAmount float64 `json:"discount_amount"`
+	NetRevenue     float64 `json:"net_revenue"`
+	OrderCount     int     `json:"order_count"`
 }
 
 // RevenuePerMonthResponse untuk response total revenue per bulan
 type RevenuePerMonthResponse struct {
-	Year         int                    `json:"year"`
-	TotalRevenue float64                `json:"total_revenue"`
-	Monthly      []RevenueMonthlyDetail `json:"monthly"`
+	Year           int                    `json:"year"`
+	TotalRevenue   float64                `json:"total_revenue"`
+	GrossRevenue   float64                `json:"gross_revenue"`
+	DiscountAmount float64                `json:"discount_amount"`
+	NetRevenue     float64                `json:"net_revenue"`     // Total revenue order yang dibayar dikurangi refund bulan ini
+	VoidedAmount   float64                `json:"voided_amount"`   // Nilai void yang dicatat pada bulan ini
+	RefundedAmount float64                `json:"refunded_amount"` // Nilai refund yang dicatat pada bulan ini
+	Monthly        []RevenueMonthlyDetail `json:"monthly"`
 }
 
 // RevenueMonthlyDetail untuk detail revenue per bulan
 type RevenueMonthlyDetail struct {
-	Month        int     `json:"month"`
-	MonthName    string  `json:"month_name"`
-	TotalRevenue float64 `json:"total_revenue"`
-	OrderCount   int     `json:"order_count"`
+	Month          int     `json:"month"`
+	MonthName      string  `json:"month_name"`
+	TotalRevenue   float64 `json:"total_revenue"`
+	GrossRevenue   float64 `json:"gross_revenue"`
+	DiscountAmount float64 `json:"discount_amount"`
+	NetRevenue     float64 `json:"net_revenue"` // Total revenue dikurangi refund bulan ini
+	OrderCount     int     `json:"order_count"` // Jumlah order yang dibayar
 }
 
 // ProductRevenueListResponse untuk response list produk dengan detail revenue
 type ProductRevenueListResponse struct {
-	TotalProducts int                    `json:"total_products"`
-	Products      []ProductRevenueDetail `json:"products"`
+	ValuationMethod string                 `json:"valuation_method"` // Metode valuasi COGS (fifo/weighted_average)
+	TotalProducts   int                    `json:"total_products"`
+	Products        []ProductRevenueDetail `json:"products"`
 }
 
 // ProductRevenueDetail untuk detail revenue per produk
 type ProductRevenueDetail struct {
-	ProductID    uint      `json:"product_id"`
-	ProductName  string    `json:"product_name"`
-	Price        float64   `json:"price"`
-	TotalRevenue float64   `json:"total_revenue"`
-	TotalSold    int       `json:"total_sold"`
-	OrderCount   int       `json:"order_count"`
-	LastOrderAt  time.Time `json:"last_order_at"`
+	ProductID      uint      `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	Price          float64   `json:"price"`
+	TotalRevenue   float64   `json:"total_revenue"`
+	GrossRevenue   float64   `json:"gross_revenue"`
+	DiscountAmount float64   `json:"discount_amount"`
+	NetRevenue     float64   `json:"net_revenue"`
+	TotalSold      int       `json:"total_sold"`
+	OrderCount     int       `json:"order_count"`
+	LastOrderAt    time.Time `json:"last_order_at"`
+
+	CostOfGoodsSold float64 `json:"cost_of_goods_sold" gorm:"-"` // Harga pokok bahan inventory yang terpakai resep
+	GrossProfit     float64 `json:"gross_profit" gorm:"-"`       // NetRevenue - CostOfGoodsSold
+	GrossMargin     float64 `json:"gross_margin" gorm:"-"`       // GrossProfit / NetRevenue dalam persen
+
+	ModifierRevenue float64                  `json:"modifier_revenue"`   // Bagian gross revenue yang berasal dari modifier
+	Modifiers       []ProductModifierRevenue `json:"modifiers" gorm:"-"` // Breakdown penjualan per opsi modifier
+}
+
+// ProductModifierRevenue untuk breakdown penjualan modifier pada satu produk
+type ProductModifierRevenue struct {
+	ModifierOptionID uint    `json:"modifier_option_id"`
+	GroupName        string  `json:"group_name"`
+	OptionName       string  `json:"option_name"`
+	QuantitySold     int     `json:"quantity_sold"`
+	Revenue          float64 `json:"revenue"`
+}
+
+// RefundTotals untuk total void dan refund pada laporan revenue
+type RefundTotals struct {
+	VoidedAmount   float64 `json:"voided_amount"`
+	VoidCount      int     `json:"void_count"`
+	RefundedAmount float64 `json:"refunded_amount"`
+	RefundCount    int     `json:"refund_count"`
 }
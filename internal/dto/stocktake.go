@@ -0,0 +1,79 @@
+package dto
+
+import "time"
+
+// StocktakeRequest untuk membuka sesi stocktake (hitung fisik) baru
+type StocktakeRequest struct {
+	Category  string `json:"category" binding:"max=100"` // Kosong = semua inventory aktif
+	Note      string `json:"note"`
+	CreatedBy uint   `json:"created_by"`
+}
+
+// StocktakeCountRequest untuk hasil hitung satu counter; hitung ulang menimpa hasil sebelumnya
+type StocktakeCountRequest struct {
+	CountedBy uint                        `json:"counted_by"`
+	Items     []StocktakeCountItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// StocktakeCountItemRequest untuk quantity hasil hitung satu inventory (dalam satuan inventory)
+type StocktakeCountItemRequest struct {
+	InventoryID int64    `json:"inventory_id" binding:"required"`
+	Quantity    *float64 `json:"quantity" binding:"required,min=0"`
+	Note        string   `json:"note"`
+}
+
+// StocktakeApproveRequest untuk persetujuan stocktake oleh manager
+type StocktakeApproveRequest struct {
+	ApprovedBy uint `json:"-"` // User login (JWT) yang menyetujui, harus manager
+}
+
+// StocktakeSummary berisi ringkasan selisih stocktake
+type StocktakeSummary struct {
+	TotalLines          int     `json:"total_lines"`
+	CountedLines        int     `json:"counted_lines"`
+	VarianceLines       int     `json:"variance_lines"`
+	VarianceValueRetail float64 `json:"variance_value_retail"` // Total selisih x harga jual
+	VarianceValueCost   float64 `json:"variance_value_cost"`   // Total selisih x harga beli
+}
+
+// StocktakeResponse untuk response sesi stocktake
+type StocktakeResponse struct {
+	ID         uint                    `json:"id"`
+	Number     string                  `json:"number"`
+	Status     string                  `json:"status"`
+	Category   string                  `json:"category,omitempty"`
+	Note       string                  `json:"note"`
+	CreatedBy  *uint                   `json:"created_by,omitempty"`
+	ApprovedBy *uint                   `json:"approved_by,omitempty"`
+	ApprovedAt *time.Time              `json:"approved_at,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at"`
+	Summary    StocktakeSummary        `json:"summary"`
+	Lines      []StocktakeLineResponse `json:"lines,omitempty"`
+}
+
+// StocktakeLineResponse untuk response satu inventory dalam stocktake
+type StocktakeLineResponse struct {
+	ID                   uint                     `json:"id"`
+	InventoryID          int64                    `json:"inventory_id"`
+	InventoryName        string                   `json:"inventory_name"`
+	Category             string                   `json:"category"`
+	Unit                 string                   `json:"unit"`
+	ExpectedQuantity     float64                  `json:"expected_quantity"`
+	CountedQuantity      *float64                 `json:"counted_quantity"`
+	Variance             *float64                 `json:"variance"`
+	RetailPrice          float64                  `json:"retail_price"`
+	UnitCost             float64                  `json:"unit_cost"`
+	VarianceValueRetail  float64                  `json:"variance_value_retail"`
+	VarianceValueCost    float64                  `json:"variance_value_cost"`
+	AdjustmentMovementID *uint                    `json:"adjustment_movement_id,omitempty"`
+	Counts               []StocktakeCountResponse `json:"counts,omitempty"`
+}
+
+// StocktakeCountResponse untuk hasil hitung satu counter
+type StocktakeCountResponse struct {
+	CountedBy uint      `json:"counted_by"`
+	Quantity  float64   `json:"quantity"`
+	Note      string    `json:"note,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// SupplierRequest untuk membuat/mengubah supplier
+type SupplierRequest struct {
+	Name        string `json:"name" binding:"required,max=150"`
+	ContactName string `json:"contact_name" binding:"max=100"`
+	Phone       string `json:"phone" binding:"max=30"`
+	Email       string `json:"email" binding:"omitempty,email,max=150"`
+	Address     string `json:"address"`
+	Note        string `json:"note"`
+	IsActive    *bool  `json:"is_active"` // Default true
+}
+
+// SupplierResponse untuk response supplier
+type SupplierResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContactName string    `json:"contact_name"`
+	Phone       string    `json:"phone"`
+	Email       string    `json:"email"`
+	Address     string    `json:"address"`
+	Note        string    `json:"note"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// TableCreateRequest untuk create meja baru
+type TableCreateRequest struct {
+	Number   string `json:"number" binding:"required,min=1,max=10"`
+	Capacity int    `json:"capacity" binding:"required,min=1"`
+}
+
+// TableUpdateRequest untuk update data meja (status diubah lewat endpoint status)
+type TableUpdateRequest struct {
+	Number   string `json:"number" binding:"required,min=1,max=10"`
+	Capacity int    `json:"capacity" binding:"required,min=1"`
+}
+
+// TableStatusUpdateRequest untuk override status meja secara manual oleh staff
+type TableStatusUpdateRequest struct {
+	Status string `json:"status" binding:"required,oneof=available occupied reserved out_of_service"`
+}
+
+// TableDetailResponse untuk response detail meja
+type TableDetailResponse struct {
+	ID            uint      `json:"id"`
+	Number        string    `json:"number"`
+	Capacity      int       `json:"capacity"`
+	Status        string    `json:"status"`
+	ReservationID *int64    `json:"reservation_id,omitempty"`
+	OpenOrders    int64     `json:"open_orders"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
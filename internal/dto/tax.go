@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// OutletRequest untuk create/update outlet
+type OutletRequest struct {
+	Name             string `json:"name" binding:"required,min=1,max=100"`
+	Address          string `json:"address"`
+	Phone            string `json:"phone"`
+	PricesIncludeTax bool   `json:"prices_include_tax"` // True jika harga katalog sudah termasuk pajak
+	ReceiptHeader    string `json:"receipt_header"`
+	ReceiptFooter    string `json:"receipt_footer"`
+	ReceiptWidth     int    `json:"receipt_width" binding:"omitempty,oneof=58 80"` // Default 58 (mm)
+}
+
+// OutletResponse untuk response outlet
+type OutletResponse struct {
+	ID               uint      `json:"id"`
+	Name             string    `json:"name"`
+	Address          string    `json:"address"`
+	Phone            string    `json:"phone"`
+	PricesIncludeTax bool      `json:"prices_include_tax"`
+	ReceiptHeader    string    `json:"receipt_header"`
+	ReceiptFooter    string    `json:"receipt_footer"`
+	ReceiptWidth     int       `json:"receipt_width"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// TaxRuleRequest untuk create/update aturan pajak
+// OutletID/CategoryID kosong berarti berlaku untuk semua outlet/kategori
+type TaxRuleRequest struct {
+	OutletID   *uint   `json:"outlet_id"`
+	CategoryID *uint   `json:"category_id"`
+	Name       string  `json:"name" binding:"required,min=1,max=50"`
+	Type       string  `json:"type" binding:"required,oneof=tax service_charge"`
+	Rate       float64 `json:"rate" binding:"min=0,max=100"` // Persentase, contoh 10 untuk 10%
+	Priority   int     `json:"priority"`                     // Urutan perhitungan, kecil dihitung lebih dulu
+	IsCompound bool    `json:"is_compound"`                  // Dihitung dari subtotal + aturan sebelumnya
+	IsActive   *bool   `json:"is_active"`                    // Default true
+}
+
+// TaxRuleResponse untuk response aturan pajak
+type TaxRuleResponse struct {
+	ID         uint      `json:"id"`
+	OutletID   *uint     `json:"outlet_id,omitempty"`
+	CategoryID *uint     `json:"category_id,omitempty"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Rate       float64   `json:"rate"`
+	Priority   int       `json:"priority"`
+	IsCompound bool      `json:"is_compound"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,111 @@
+package dto
+
+import "time"
+
+// WasteRequest untuk mencatat waste satu inventory atau satu produk (isi salah satu)
+type WasteRequest struct {
+	InventoryID *int64     `json:"inventory_id,omitempty"`
+	ProductID   *uint      `json:"product_id,omitempty"`
+	VariantID   *uint      `json:"variant_id,omitempty"` // Wajib untuk produk yang punya varian
+	Reason      string     `json:"reason" binding:"required,oneof=spoilage expired damaged staff_meal preparation_error other"`
+	Quantity    float64    `json:"quantity" binding:"required,gt=0"` // Untuk produk: jumlah porsi (bilangan bulat)
+	Unit        string     `json:"unit" binding:"max=50"`            // Untuk inventory: satuan yang bisa dikonversi atau nama kemasan; default satuan inventory
+	StaffID     *uint      `json:"staff_id,omitempty"`               // Staff yang bertanggung jawab
+	PhotoURL    string     `json:"photo_url" binding:"omitempty,max=500"`
+	Note        string     `json:"note" binding:"max=500"`
+	RecordedBy  uint       `json:"recorded_by"`         // User ID yang mencatat (dicatat di stock movement)
+	WastedAt    *time.Time `json:"wasted_at,omitempty"` // Default waktu sekarang; tidak boleh di masa depan
+}
+
+// WasteFilter untuk query daftar waste (tanggal YYYY-MM-DD, inklusif)
+type WasteFilter struct {
+	Reason      string `form:"reason" binding:"omitempty,oneof=spoilage expired damaged staff_meal preparation_error other"`
+	InventoryID int64  `form:"inventory_id"`
+	ProductID   uint   `form:"product_id"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+}
+
+// WasteResponse untuk response satu catatan waste
+type WasteResponse struct {
+	ID          uint      `json:"id"`
+	InventoryID *int64    `json:"inventory_id,omitempty"`
+	ProductID   *uint     `json:"product_id,omitempty"`
+	VariantID   *uint     `json:"variant_id,omitempty"`
+	ItemName    string    `json:"item_name"`
+	VariantName string    `json:"variant_name,omitempty"`
+	Reason      string    `json:"reason"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit"`
+	StaffID     *uint     `json:"staff_id,omitempty"`
+	StaffName   string    `json:"staff_name,omitempty"`
+	PhotoURL    string    `json:"photo_url,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	RecordedBy  *uint     `json:"recorded_by,omitempty"`
+	Cost        float64   `json:"cost"` // Harga pokok waste sesuai metode valuasi default
+	FifoCost    float64   `json:"fifo_cost"`
+	AverageCost float64   `json:"average_cost"`
+	WastedAt    time.Time `json:"wasted_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WasteListResponse untuk daftar waste dengan pagination
+type WasteListResponse struct {
+	Data       []WasteResponse `json:"data"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// WasteReportFilter untuk laporan waste (tanggal YYYY-MM-DD, inklusif; default 30 hari terakhir)
+type WasteReportFilter struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Period    string `form:"period" binding:"omitempty,oneof=day week month"` // Pengelompokan per periode; default day
+	Reason    string `form:"reason" binding:"omitempty,oneof=spoilage expired damaged staff_meal preparation_error other"`
+	Method    string `form:"method" binding:"omitempty,oneof=fifo weighted_average"` // Default dari konfigurasi INVENTORY_VALUATION_METHOD
+}
+
+// WasteReportResponse untuk laporan waste yang dinilai dengan harga pokok. SalesCost adalah harga
+// pokok penjualan (COGS) pada periode yang sama sebagai pembanding.
+type WasteReportResponse struct {
+	Method          string             `json:"method"`
+	StartDate       string             `json:"start_date"`
+	EndDate         string             `json:"end_date"`
+	Period          string             `json:"period"`
+	TotalCount      int64              `json:"total_count"`
+	TotalCost       float64            `json:"total_cost"`
+	SalesCost       float64            `json:"sales_cost"`
+	WastePercentage float64            `json:"waste_percentage"` // Waste / (COGS + waste) x 100
+	ByPeriod        []WastePeriodTotal `json:"by_period"`
+	ByReason        []WasteReasonTotal `json:"by_reason"`
+	ByItem          []WasteItemTotal   `json:"by_item"`
+}
+
+// WastePeriodTotal untuk total waste dan COGS satu periode
+type WastePeriodTotal struct {
+	Period          string  `json:"period"` // Tanggal awal periode (YYYY-MM-DD)
+	Count           int64   `json:"count"`
+	Cost            float64 `json:"cost"`
+	SalesCost       float64 `json:"sales_cost"`
+	WastePercentage float64 `json:"waste_percentage"`
+}
+
+// WasteReasonTotal untuk total waste per alasan
+type WasteReasonTotal struct {
+	Reason     string  `json:"reason"`
+	Count      int64   `json:"count"`
+	Cost       float64 `json:"cost"`
+	Percentage float64 `json:"percentage"` // Porsi dari total biaya waste
+}
+
+// WasteItemTotal untuk total waste per inventory atau produk, urut biaya terbesar
+type WasteItemTotal struct {
+	InventoryID *int64  `json:"inventory_id,omitempty"`
+	ProductID   *uint   `json:"product_id,omitempty"`
+	Name        string  `json:"name"`
+	Unit        string  `json:"unit"`
+	Quantity    float64 `json:"quantity"`
+	Count       int64   `json:"count"`
+	Cost        float64 `json:"cost"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/internal/dto"
 
@@ -42,14 +43,15 @@ func (u *dashboardUseCase) ExportDashboard(ctx context.Context) ([]dto.Dashboard
 		Raw(`
 		       SELECT TO_CHAR(DATE_TRUNC('month', created_at), 'YYYY-MM') as month,
 			      COUNT(*) as total_orders,
-			      SUM(total_amount) as revenue,
-			      SUM(CASE WHEN status = 'paid' THEN 1 ELSE 0 END) as sales
+			      COALESCE(SUM(CASE WHEN status IN ? THEN total_amount END), 0) as revenue,
+			      SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) as sales
 		       FROM orders
-		       WHERE deleted_at IS NULL
+		       WHERE deleted_at IS NULL AND status NOT IN ?
 		       GROUP BY month
 		       ORDER BY month DESC
 		       LIMIT 12
-	       `).Scan(&results).Error
+	       `, []string{entity.OrderStatusPaid, entity.OrderStatusRefunded}, entity.OrderStatusPaid,
+			[]string{entity.OrderStatusSplit, entity.OrderStatusMerged}).Scan(&results).Error
 	if err != nil {
 		u.logger.Error("Failed to export dashboard data", zap.Error(err))
 		return nil, err
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Nama event domain yang dipublikasikan usecase setelah transaksinya berhasil
+const (
+	EventOrderCreated         = "order.created"
+	EventOrderPaid            = "order.paid"
+	EventOrderCancelled       = "order.cancelled"
+	EventReservationCreated   = "reservation.created"
+	EventReservationCancelled = "reservation.cancelled"
+	EventStaffAdded           = "staff.added"
+	EventLowStock             = "inventory.low_stock"
+)
+
+// eventHandlerTimeout adalah batas waktu satu handler memproses satu event
+const eventHandlerTimeout = 30 * time.Second
+
+// DomainEvent adalah kejadian bisnis yang sudah tersimpan di database.
+// Payload berisi salah satu struct *Event di bawah sesuai Name.
+type DomainEvent struct {
+	Name       string
+	OccurredAt time.Time
+	Payload    interface{}
+}
+
+// OrderEvent adalah payload event order.created, order.paid, dan order.cancelled
+type OrderEvent struct {
+	OrderID      uint    `json:"order_id"`
+	CustomerName string  `json:"customer_name"`
+	TableNumber  string  `json:"table_number,omitempty"`
+	Status       string  `json:"status"`
+	TotalAmount  float64 `json:"total_amount"`
+	PaidAmount   float64 `json:"paid_amount"`
+	Reason       string  `json:"reason,omitempty"` // Kode alasan void atau catatan pembatalan
+}
+
+// ReservationEvent adalah payload event reservation.created dan reservation.cancelled
+type ReservationEvent struct {
+	ReservationID   uint       `json:"reservation_id"`
+	CustomerName    string     `json:"customer_name"`
+	CustomerPhone   string     `json:"customer_phone,omitempty"`
+	TableNumber     string     `json:"table_number,omitempty"`
+	ReservationTime *time.Time `json:"reservation_time,omitempty"`
+	Status          string     `json:"status"`
+}
+
+// StaffEvent adalah payload event staff.added
+type StaffEvent struct {
+	StaffID  uint   `json:"staff_id"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+// LowStockEvent adalah payload event inventory.low_stock
+type LowStockEvent struct {
+	Items []lowStockItem `json:"items"`
+}
+
+// EventHandler memproses satu event domain. Error hanya dicatat ke log.
+type EventHandler func(ctx context.Context, event DomainEvent) error
+
+// EventBus adalah bus event domain di dalam proses. Handler dijalankan secara asinkron setelah
+// Publish, sehingga kegagalan handler (misalnya pengiriman notifikasi) tidak pernah membatalkan
+// transaksi bisnis yang mempublikasikan event.
+type EventBus interface {
+	Subscribe(name string, handler EventHandler)
+	Publish(ctx context.Context, name string, payload interface{})
+	Close(ctx context.Context) error
+}
+
+type eventBus struct {
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+	closed   bool
+	running  sync.WaitGroup // Handler yang sedang berjalan, ditunggu saat Close
+}
+
+// NewEventBus membuat instance baru dari EventBus
+func NewEventBus(logger *zap.Logger) EventBus {
+	return &eventBus{
+		logger:   logger,
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+// Subscribe mendaftarkan handler untuk event dengan nama tertentu
+func (b *eventBus) Subscribe(name string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish mengirim event ke semua handler yang berlangganan. Setiap handler berjalan di goroutine
+// sendiri dengan context yang tidak ikut dibatalkan saat request selesai.
+func (b *eventBus) Publish(ctx context.Context, name string, payload interface{}) {
+	event := DomainEvent{Name: name, OccurredAt: time.Now(), Payload: payload}
+
+	// running.Add dilakukan di bawah lock agar tidak balapan dengan Close yang sedang menunggu
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		b.logger.Warn("Event bus closed, domain event dropped", zap.String("event", name))
+		return
+	}
+	handlers := b.handlers[name]
+
+	b.logger.Debug("Publishing domain event", zap.String("event", name), zap.Int("handlers", len(handlers)))
+	b.running.Add(len(handlers))
+	for _, handler := range handlers {
+		go b.dispatch(context.WithoutCancel(ctx), handler, event)
+	}
+}
+
+// Close menolak event baru lalu menunggu handler yang masih berjalan selesai; dipanggil saat
+// server shutdown. Mengembalikan error ctx jika handler belum selesai sebelum ctx habis.
+func (b *eventBus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.logger.Info("Event bus drained")
+		return nil
+	case <-ctx.Done():
+		b.logger.Warn("Event bus closed before all handlers finished", zap.Error(ctx.Err()))
+		return ctx.Err()
+	}
+}
+
+// dispatch menjalankan satu handler, mencatat error maupun panic tanpa meneruskannya ke publisher
+func (b *eventBus) dispatch(ctx context.Context, handler EventHandler, event DomainEvent) {
+	defer b.running.Done()
+
+	ctx, cancel := context.WithTimeout(ctx, eventHandlerTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Domain event handler panicked",
+				zap.String("event", event.Name),
+				zap.String("panic", fmt.Sprint(r)))
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		b.logger.Error("Domain event handler failed", zap.String("event", event.Name), zap.Error(err))
+	}
+}
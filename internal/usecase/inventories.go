@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/internal/dto"
 
+	"aplikasi-pos-team-boolean/pkg/unit"
+
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // InventoriesUsecase mendefinisikan interface untuk business logic inventories
@@ -20,19 +24,52 @@ type InventoriesUsecase interface {
 	DeleteInventory(ctx context.Context, id int64) error
 	GetInventoryByFilter(ctx context.Context, filter dto.InventoriesFilter) (*dto.InventoriesListResponse, error)
 	GetAllInventories(ctx context.Context, filter dto.InventoriesFilter) (*dto.InventoriesListResponse, error)
+	GetStockMovements(ctx context.Context, id int64, filter dto.StockMovementFilter) (*dto.StockMovementListResponse, error)
+	GetValuationReport(ctx context.Context, filter dto.InventoryValuationFilter) (*dto.InventoryValuationResponse, error)
+	GetBatches(ctx context.Context, id int64, filter dto.InventoryBatchFilter) (*dto.InventoryBatchListResponse, error)
+	GetUnitCatalog(ctx context.Context) *dto.UnitCatalogResponse
+	NotifyExpiringBatches(ctx context.Context) (int, error)
+	RunExpiryAlerts(ctx context.Context, interval time.Duration)
+	NotifyLowStock(ctx context.Context) (int, error)
+	RunLowStockAlerts(ctx context.Context, interval time.Duration)
 }
 
+var (
+	// ErrInventoryNotFound dikembalikan jika inventory tidak ada atau sudah dihapus
+	ErrInventoryNotFound = errors.New("inventory tidak ditemukan")
+	// ErrInvalidStockMovementFilter dikembalikan jika filter tanggal riwayat stok tidak valid
+	ErrInvalidStockMovementFilter = errors.New("filter pergerakan stok tidak valid")
+	// ErrInvalidInventoryUnit dikembalikan jika satuan quantity atau kemasan tidak bisa dikonversi ke satuan inventory
+	ErrInvalidInventoryUnit = errors.New("satuan inventory tidak valid")
+)
+
 // inventoriesUsecase adalah implementasi dari interface InventoriesUsecase
 type inventoriesUsecase struct {
-	inventoriesRepo repository.InventoriesRepository
-	logger          *zap.Logger
+	inventoriesRepo   repository.InventoriesRepository
+	stockMovementRepo repository.StockMovementRepository
+	productRepo       repository.ProductRepository
+	authRepo          repository.AuthRepository
+	notificationUC    NotificationUseCase
+	events            EventBus
+	valuationMethod   string
+	expiryAlertDays   int // Batch yang kedaluwarsa dalam sekian hari dikirim notifikasinya
+	logger            *zap.Logger
 }
 
-// NewInventoriesUsecase membuat instance baru dari InventoriesUsecase
-func NewInventoriesUsecase(inventoriesRepo repository.InventoriesRepository, logger *zap.Logger) *inventoriesUsecase {
+// NewInventoriesUsecase membuat instance baru dari InventoriesUsecase. valuationMethod adalah
+// metode valuasi default laporan nilai persediaan (fifo atau weighted_average); expiryAlertDays
+// adalah batas hari notifikasi batch yang akan kedaluwarsa. Stok menipis dipublikasikan ke events.
+func NewInventoriesUsecase(inventoriesRepo repository.InventoriesRepository, stockMovementRepo repository.StockMovementRepository, productRepo repository.ProductRepository, authRepo repository.AuthRepository, notificationUC NotificationUseCase, events EventBus, valuationMethod string, expiryAlertDays int, logger *zap.Logger) *inventoriesUsecase {
 	return &inventoriesUsecase{
-		inventoriesRepo: inventoriesRepo,
-		logger:          logger,
+		inventoriesRepo:   inventoriesRepo,
+		stockMovementRepo: stockMovementRepo,
+		productRepo:       productRepo,
+		authRepo:          authRepo,
+		notificationUC:    notificationUC,
+		events:            events,
+		valuationMethod:   resolveValuationMethod(valuationMethod, logger),
+		expiryAlertDays:   expiryAlertDays,
+		logger:            logger,
 	}
 }
 
@@ -41,7 +78,7 @@ func (u *inventoriesUsecase) CreateInventory(ctx context.Context, req dto.Invent
 	u.logger.Info("Creating new inventory item",
 		zap.String("name", req.Name),
 		zap.String("category", req.Category),
-		zap.Int("quantity", req.Quantity),
+		zap.Float64("quantity", req.Quantity),
 		zap.String("status", req.Status),
 		zap.Float64("retail_price", req.RetailPrice),
 	)
@@ -56,7 +93,7 @@ func (u *inventoriesUsecase) CreateInventory(ctx context.Context, req dto.Invent
 		return nil, errors.New("kategori tidak boleh kosong")
 	}
 	if req.Quantity < 0 {
-		u.logger.Warn("Validation failed: quantity tidak boleh negatif", zap.Int("quantity", req.Quantity))
+		u.logger.Warn("Validation failed: quantity tidak boleh negatif", zap.Float64("quantity", req.Quantity))
 		return nil, errors.New("quantity tidak boleh negatif")
 	}
 	if req.RetailPrice < 0 {
@@ -73,13 +110,17 @@ func (u *inventoriesUsecase) CreateInventory(ctx context.Context, req dto.Invent
 		Image:       req.Image,
 		Name:        req.Name,
 		Category:    req.Category,
-		Quantity:    req.Quantity,
+		Unit:        inventoryUnit(req.Unit, "pcs"),
 		Status:      req.Status,
 		RetailPrice: req.RetailPrice,
+		MinStock:    inventoryMinStock(req.MinStock, defaultMinStock),
+	}
+	if err := u.applyInventoryUnits(inventory, req, nil); err != nil {
+		return nil, err
 	}
 
 	// Simpan ke database
-	if err := u.inventoriesRepo.Create(ctx, inventory); err != nil {
+	if err := u.inventoriesRepo.Create(ctx, inventory, changedBy(req.ChangedBy)); err != nil {
 		u.logger.Error("Failed to create inventory in database",
 			zap.Error(err),
 			zap.String("name", req.Name),
@@ -103,6 +144,7 @@ func (u *inventoriesUsecase) GetInventoryByFilter(ctx context.Context, filter dt
 		zap.String("status", filter.Status),
 		zap.String("category", filter.Category),
 		zap.String("stock", filter.Stock),
+		zap.Int("expiring_within", filter.ExpiringWithin),
 		zap.Int("page", filter.Page),
 		zap.Int("limit", filter.Limit),
 	)
@@ -174,7 +216,7 @@ func (u *inventoriesUsecase) UpdateInventory(ctx context.Context, id int64, req
 		return nil, errors.New("kategori tidak boleh kosong")
 	}
 	if req.Quantity < 0 {
-		u.logger.Warn("Validation failed: quantity tidak boleh negatif", zap.Int("quantity", req.Quantity))
+		u.logger.Warn("Validation failed: quantity tidak boleh negatif", zap.Float64("quantity", req.Quantity))
 		return nil, errors.New("quantity tidak boleh negatif")
 	}
 	if req.RetailPrice < 0 {
@@ -186,19 +228,33 @@ func (u *inventoriesUsecase) UpdateInventory(ctx context.Context, id int64, req
 		return nil, errors.New("status harus active atau inactive")
 	}
 
+	// Ambil data lama agar satuan, min stock, dan created_at tidak tertimpa jika tidak diisi
+	existing, err := u.inventoriesRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInventoryNotFound
+		}
+		return nil, fmt.Errorf("gagal memperbarui inventory: %w", err)
+	}
+
 	// Update entity inventory
 	inventory := &entity.Inventories{
 		ID:          id,
 		Image:       req.Image,
 		Name:        req.Name,
 		Category:    req.Category,
-		Quantity:    req.Quantity,
+		Unit:        inventoryUnit(req.Unit, existing.Unit),
 		Status:      req.Status,
 		RetailPrice: req.RetailPrice,
+		MinStock:    inventoryMinStock(req.MinStock, existing.MinStock),
+		CreatedAt:   existing.CreatedAt,
+	}
+	if err := u.applyInventoryUnits(inventory, req, existing); err != nil {
+		return nil, err
 	}
 
 	// Update ke database
-	if err := u.inventoriesRepo.Update(ctx, inventory); err != nil {
+	if err := u.inventoriesRepo.Update(ctx, inventory, changedBy(req.ChangedBy), req.Note); err != nil {
 		u.logger.Error("Failed to update inventory in database",
 			zap.Error(err),
 			zap.Int64("id", id),
@@ -302,16 +358,286 @@ func (u *inventoriesUsecase) GetAllInventories(ctx context.Context, filter dto.I
 	}, nil
 }
 
+// GetStockMovements mengambil riwayat pergerakan stok inventory (filter tanggal YYYY-MM-DD, inklusif)
+func (u *inventoriesUsecase) GetStockMovements(ctx context.Context, id int64, filter dto.StockMovementFilter) (*dto.StockMovementListResponse, error) {
+	u.logger.Debug("Fetching stock movements",
+		zap.Int64("inventory_id", id),
+		zap.String("type", filter.Type),
+		zap.String("start_date", filter.StartDate),
+		zap.String("end_date", filter.EndDate),
+	)
+
+	inventory, err := u.inventoriesRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInventoryNotFound
+		}
+		return nil, fmt.Errorf("gagal mengambil inventory: %w", err)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = 20
+	}
+	repoFilter := repository.StockMovementFilter{Type: filter.Type, Page: filter.Page, Limit: filter.Limit}
+	if filter.StartDate != "" {
+		start, err := time.ParseInLocation("2006-01-02", filter.StartDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: start_date harus berformat YYYY-MM-DD", ErrInvalidStockMovementFilter)
+		}
+		repoFilter.StartDate = &start
+	}
+	if filter.EndDate != "" {
+		end, err := time.ParseInLocation("2006-01-02", filter.EndDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_date harus berformat YYYY-MM-DD", ErrInvalidStockMovementFilter)
+		}
+		end = end.AddDate(0, 0, 1)
+		repoFilter.EndDate = &end
+	}
+	if repoFilter.StartDate != nil && repoFilter.EndDate != nil && !repoFilter.StartDate.Before(*repoFilter.EndDate) {
+		return nil, fmt.Errorf("%w: start_date tidak boleh setelah end_date", ErrInvalidStockMovementFilter)
+	}
+
+	movements, total, err := u.stockMovementRepo.FindByInventory(ctx, id, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil pergerakan stok: %w", err)
+	}
+
+	responses := make([]dto.StockMovementResponse, 0, len(movements))
+	for _, m := range movements {
+		responses = append(responses, dto.StockMovementResponse{
+			ID:            m.ID,
+			InventoryID:   m.InventoryID,
+			Type:          m.Type,
+			Delta:         m.Delta,
+			Balance:       m.Balance,
+			UserID:        m.UserID,
+			ReferenceType: m.ReferenceType,
+			ReferenceID:   m.ReferenceID,
+			Note:          m.Note,
+			UnitCost:      m.UnitCost,
+			FifoValue:     m.FifoValue,
+			AverageValue:  m.AverageValue,
+			CreatedAt:     m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	totalPages := int(total) / filter.Limit
+	if int(total)%filter.Limit != 0 {
+		totalPages++
+	}
+
+	return &dto.StockMovementListResponse{
+		InventoryID:   inventory.ID,
+		InventoryName: inventory.Name,
+		Unit:          inventory.Unit,
+		Quantity:      inventory.Quantity,
+		Data:          responses,
+		Pagination: dto.Pagination{
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: totalPages,
+			TotalItems: int(total),
+		},
+	}, nil
+}
+
+// GetValuationReport menghitung nilai persediaan setiap inventory dengan metode FIFO (sisa cost layer)
+// atau weighted average (quantity x harga pokok rata-rata). Stok yang tidak tertutup cost layer dinilai
+// dengan harga rata-rata; stok nol atau minus tidak bernilai.
+func (u *inventoriesUsecase) GetValuationReport(ctx context.Context, filter dto.InventoryValuationFilter) (*dto.InventoryValuationResponse, error) {
+	method := filter.Method
+	if method == "" {
+		method = u.valuationMethod
+	}
+	u.logger.Debug("Fetching inventory valuation", zap.String("method", method), zap.String("category", filter.Category))
+
+	rows, err := u.inventoriesRepo.GetValuation(ctx, filter.Category)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil nilai persediaan: %w", err)
+	}
+
+	response := &dto.InventoryValuationResponse{
+		Method: method,
+		Items:  make([]dto.InventoryValuationItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		item := dto.InventoryValuationItem{
+			InventoryID: row.InventoryID,
+			Name:        row.Name,
+			Category:    row.Category,
+			Unit:        row.Unit,
+			Quantity:    row.Quantity,
+			UnitCost:    row.AverageCost,
+			RetailPrice: row.RetailPrice,
+		}
+		if row.Quantity > 0 {
+			item.Value = row.Quantity * row.AverageCost
+			if method == entity.ValuationFIFO {
+				item.Value = row.LayerValue + math.Max(row.Quantity-row.LayerQuantity, 0)*row.AverageCost
+				item.UnitCost = math.Round(item.Value/row.Quantity*10000) / 10000
+			}
+			item.Value = math.Round(item.Value*100) / 100
+			item.RetailValue = math.Round(row.Quantity*row.RetailPrice*100) / 100
+		}
+		response.TotalValue += item.Value
+		response.TotalRetailValue += item.RetailValue
+		response.Items = append(response.Items, item)
+	}
+	response.TotalValue = math.Round(response.TotalValue*100) / 100
+	response.TotalRetailValue = math.Round(response.TotalRetailValue*100) / 100
+	return response, nil
+}
+
+// resolveValuationMethod memvalidasi metode valuasi dari konfigurasi; metode tidak dikenal memakai FIFO
+func resolveValuationMethod(method string, logger *zap.Logger) string {
+	if entity.IsValidValuationMethod(method) {
+		return method
+	}
+	if method != "" {
+		logger.Warn("Unknown inventory valuation method, falling back to fifo", zap.String("method", method))
+	}
+	return entity.ValuationFIFO
+}
+
+// changedBy mengubah user ID dari request menjadi pointer (0 = tidak diketahui)
+func changedBy(userID uint) *uint {
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+// defaultMinStock adalah batas stok minimum inventory baru jika tidak diisi
+const defaultMinStock = 5
+
+// inventoryMinStock mengembalikan min stock dari request, atau fallback jika kosong
+func inventoryMinStock(reqMinStock *int, fallback int) int {
+	if reqMinStock != nil {
+		return *reqMinStock
+	}
+	return fallback
+}
+
+// inventoryUnit mengembalikan satuan dari request, atau fallback jika kosong
+func inventoryUnit(reqUnit, fallback string) string {
+	if u := unit.Normalize(reqUnit); u != "" {
+		return u
+	}
+	return fallback
+}
+
+// applyInventoryUnits mengisi kemasan dan quantity inventory dari request. Kemasan dan quantity
+// dikonversi ke satuan inventory; jika kemasan tidak diisi saat update, kemasan lama dipakai
+// (dikonversi ulang jika satuan inventory berubah).
+func (u *inventoriesUsecase) applyInventoryUnits(inventory *entity.Inventories, req dto.InventoriesRequest, existing *entity.Inventories) error {
+	packRequests := req.PackSizes
+	if packRequests == nil && existing != nil {
+		packRequests = make([]dto.InventoryPackSizeRequest, 0, len(existing.PackSizes))
+		for _, pack := range existing.PackSizes {
+			packRequests = append(packRequests, dto.InventoryPackSizeRequest{
+				Name:     pack.Name,
+				Quantity: pack.Quantity,
+				Unit:     existing.Unit,
+			})
+		}
+	}
+
+	packSizes, err := inventoryPackSizes(packRequests, inventory.Unit)
+	if err != nil {
+		u.logger.Warn("Validation failed: invalid pack size", zap.String("unit", inventory.Unit), zap.Error(err))
+		return err
+	}
+	inventory.PackSizes = packSizes
+
+	quantity, err := inventory.ToStockUnit(req.Quantity, req.QuantityUnit)
+	if err != nil {
+		u.logger.Warn("Validation failed: invalid quantity unit",
+			zap.String("quantity_unit", req.QuantityUnit),
+			zap.String("unit", inventory.Unit),
+			zap.Error(err))
+		return fmt.Errorf("%w: quantity %s: %v", ErrInvalidInventoryUnit, req.QuantityUnit, err)
+	}
+	inventory.Quantity = math.Round(quantity*1000) / 1000
+	return nil
+}
+
+// inventoryPackSizes mengkonversi kemasan dari request ke satuan inventory. Nama kemasan harus
+// unik dan tidak boleh sama dengan satuan katalog agar konversi quantity tidak ambigu.
+// Mengembalikan slice kosong (bukan nil) jika tidak ada kemasan.
+func inventoryPackSizes(reqs []dto.InventoryPackSizeRequest, stockUnit string) ([]entity.InventoryPackSize, error) {
+	packSizes := make([]entity.InventoryPackSize, 0, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		name := unit.Normalize(req.Name)
+		if name == "" || seen[name] {
+			return nil, fmt.Errorf("%w: nama kemasan %q kosong atau ditulis lebih dari sekali", ErrInvalidInventoryUnit, req.Name)
+		}
+		if unit.Family(name) != "" {
+			return nil, fmt.Errorf("%w: nama kemasan %q sudah dipakai sebagai satuan", ErrInvalidInventoryUnit, req.Name)
+		}
+		seen[name] = true
+
+		packUnit := unit.Normalize(req.Unit)
+		if packUnit == "" {
+			packUnit = stockUnit
+		}
+		quantity, err := unit.Convert(req.Quantity, packUnit, stockUnit)
+		if err != nil || quantity <= 0 {
+			return nil, fmt.Errorf("%w: isi kemasan %s (%v %s) tidak bisa dikonversi ke %s", ErrInvalidInventoryUnit, name, req.Quantity, packUnit, stockUnit)
+		}
+		packSizes = append(packSizes, entity.InventoryPackSize{
+			Name:     name,
+			Quantity: math.Round(quantity*10000) / 10000,
+		})
+	}
+	return packSizes, nil
+}
+
+// GetUnitCatalog mengembalikan katalog satuan yang bisa dikonversi, dikelompokkan per family
+func (u *inventoriesUsecase) GetUnitCatalog(ctx context.Context) *dto.UnitCatalogResponse {
+	response := &dto.UnitCatalogResponse{Families: make([]dto.UnitFamilyResponse, 0)}
+	for _, def := range unit.Catalog() {
+		last := len(response.Families) - 1
+		if last < 0 || response.Families[last].Family != def.Family {
+			response.Families = append(response.Families, dto.UnitFamilyResponse{Family: def.Family, Base: def.Base})
+			last++
+		}
+		response.Families[last].Units = append(response.Families[last].Units, dto.UnitFactorResponse{
+			Unit:   def.Unit,
+			Factor: def.Factor,
+		})
+	}
+	return response
+}
+
 // toInventoryResponse mengkonversi entity ke response DTO
 func (u *inventoriesUsecase) toInventoryResponse(inv *entity.Inventories) *dto.InventoriesResponse {
+	var packSizes []dto.InventoryPackSizeResponse
+	for _, pack := range inv.PackSizes {
+		packSizes = append(packSizes, dto.InventoryPackSizeResponse{
+			ID:       pack.ID,
+			Name:     pack.Name,
+			Quantity: pack.Quantity,
+		})
+	}
+
 	return &dto.InventoriesResponse{
 		ID:          inv.ID,
 		Image:       inv.Image,
 		Name:        inv.Name,
 		Category:    inv.Category,
 		Quantity:    inv.Quantity,
+		Unit:        inv.Unit,
 		Status:      inv.Status,
 		RetailPrice: inv.RetailPrice,
+		MinStock:    inv.MinStock,
+		AverageCost: inv.AverageCost,
+		UnitFamily:  unit.Family(inv.Unit),
+		PackSizes:   packSizes,
 		CreatedAt:   inv.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   inv.UpdatedAt.Format(time.RFC3339),
 	}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// GetBatches mengambil batch/lot inventory urut FEFO beserta sisa hari sebelum kedaluwarsa
+func (u *inventoriesUsecase) GetBatches(ctx context.Context, id int64, filter dto.InventoryBatchFilter) (*dto.InventoryBatchListResponse, error) {
+	inventory, err := u.inventoriesRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInventoryNotFound
+		}
+		return nil, fmt.Errorf("gagal mengambil inventory: %w", err)
+	}
+
+	batches, err := u.inventoriesRepo.FindBatches(ctx, id, filter.IncludeEmpty)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil batch inventory: %w", err)
+	}
+
+	today := startOfDay(time.Now())
+	response := &dto.InventoryBatchListResponse{
+		InventoryID:   inventory.ID,
+		InventoryName: inventory.Name,
+		Unit:          inventory.Unit,
+		Quantity:      inventory.Quantity,
+		Batches:       make([]dto.InventoryBatchResponse, 0, len(batches)),
+	}
+	for _, b := range batches {
+		batch := dto.InventoryBatchResponse{
+			ID:                b.ID,
+			BatchNumber:       b.BatchNumber,
+			ExpiryDate:        b.ExpiryDate,
+			Quantity:          b.Quantity,
+			RemainingQuantity: b.RemainingQuantity,
+			StockMovementID:   b.StockMovementID,
+			CreatedAt:         b.CreatedAt,
+		}
+		if b.ExpiryDate != nil {
+			days := daysBetween(today, *b.ExpiryDate)
+			batch.DaysToExpiry = &days
+		}
+		response.Batches = append(response.Batches, batch)
+	}
+	return response, nil
+}
+
+// NotifyExpiringBatches mengirim notifikasi alert ke manager untuk batch yang kedaluwarsa dalam
+// expiryAlertDays hari (termasuk yang sudah lewat). Setiap batch hanya dikirim sekali.
+func (u *inventoriesUsecase) NotifyExpiringBatches(ctx context.Context) (int, error) {
+	until := startOfDay(time.Now()).AddDate(0, 0, u.expiryAlertDays)
+	batches, err := u.inventoriesRepo.FindExpiringBatches(ctx, until)
+	if err != nil {
+		return 0, err
+	}
+	if len(batches) == 0 {
+		return 0, nil
+	}
+
+	type expiringBatch struct {
+		BatchID           uint    `json:"batch_id"`
+		InventoryID       int64   `json:"inventory_id"`
+		InventoryName     string  `json:"inventory_name"`
+		BatchNumber       string  `json:"batch_number,omitempty"`
+		ExpiryDate        string  `json:"expiry_date"`
+		RemainingQuantity float64 `json:"remaining_quantity"`
+		Unit              string  `json:"unit"`
+	}
+	items := make([]expiringBatch, 0, len(batches))
+	lines := make([]string, 0, len(batches))
+	ids := make([]uint, 0, len(batches))
+	for _, b := range batches {
+		expiry := b.ExpiryDate.Format("2006-01-02")
+		items = append(items, expiringBatch{
+			BatchID:           b.ID,
+			InventoryID:       b.InventoryID,
+			InventoryName:     b.Inventory.Name,
+			BatchNumber:       b.BatchNumber,
+			ExpiryDate:        expiry,
+			RemainingQuantity: b.RemainingQuantity,
+			Unit:              b.Inventory.Unit,
+		})
+		label := b.Inventory.Name
+		if b.BatchNumber != "" {
+			label += " batch " + b.BatchNumber
+		}
+		lines = append(lines, fmt.Sprintf("%s (%g %s, %s)", label, b.RemainingQuantity, b.Inventory.Unit, expiry))
+		ids = append(ids, b.ID)
+	}
+
+	message := fmt.Sprintf("%d batch inventory kedaluwarsa dalam %d hari: %s",
+		len(batches), u.expiryAlertDays, strings.Join(lines, "; "))
+	recipients, err := u.notifyManagers(ctx, "Batch inventory segera kedaluwarsa", message,
+		map[string]interface{}{"batches": items})
+	if err != nil {
+		return 0, err
+	}
+	if recipients == 0 {
+		u.logger.Warn("No manager to notify about expiring batches", zap.Int("batches", len(batches)))
+		return 0, nil
+	}
+
+	if err := u.inventoriesRepo.MarkBatchesAlerted(ctx, ids); err != nil {
+		return 0, err
+	}
+
+	u.logger.Info("Expiring batch notifications sent",
+		zap.Int("batches", len(batches)),
+		zap.Int("recipients", recipients))
+	return len(batches), nil
+}
+
+// notifyManagers mengirim notifikasi alert yang sama ke semua user aktif dengan role manager;
+// data disimpan sebagai JSON di field Data. Mengembalikan jumlah penerima.
+func (u *inventoriesUsecase) notifyManagers(ctx context.Context, title, message string, data interface{}) (int, error) {
+	return notifyRoles(ctx, u.authRepo, u.notificationUC, managerRoleNames(), notificationTypeAlert, title, message, data)
+}
+
+// RunExpiryAlerts menjalankan NotifyExpiringBatches secara berkala sampai ctx dibatalkan
+func (u *inventoriesUsecase) RunExpiryAlerts(ctx context.Context, interval time.Duration) {
+	u.logger.Info("Expiry alert job started",
+		zap.Duration("interval", interval),
+		zap.Int("alert_days", u.expiryAlertDays))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.NotifyExpiringBatches(ctx); err != nil && ctx.Err() == nil {
+			u.logger.Error("Failed to send expiring batch notifications", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			u.logger.Info("Expiry alert job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startOfDay mengembalikan jam 00:00 waktu lokal pada tanggal t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween menghitung selisih hari kalender dari tanggal from ke tanggal to
+func daysBetween(from, to time.Time) int {
+	year, month, day := to.Date()
+	toDate := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	return int(math.Round(toDate.Sub(startOfDay(from)).Hours() / 24))
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrKitchenStationNotFound dikembalikan jika station dapur tidak ada atau sudah dihapus
+	ErrKitchenStationNotFound = errors.New("station dapur tidak ditemukan")
+	// ErrKitchenCategoryNotFound dikembalikan jika kategori yang dipetakan ke station tidak ada
+	ErrKitchenCategoryNotFound = errors.New("kategori station dapur tidak ditemukan")
+	// ErrKitchenTicketNotFound dikembalikan jika tiket atau item dapur tidak ada
+	ErrKitchenTicketNotFound = errors.New("tiket dapur tidak ditemukan")
+	// ErrInvalidKitchenStatus dikembalikan jika filter status tiket tidak dikenal
+	ErrInvalidKitchenStatus = errors.New("status tiket dapur tidak valid")
+)
+
+// kitchenSubscriberBuffer adalah kapasitas antrian event per koneksi websocket dapur
+const kitchenSubscriberBuffer = 32
+
+type KitchenUseCase interface {
+	GetAllStations(ctx context.Context) ([]dto.KitchenStationResponse, error)
+	CreateStation(ctx context.Context, req dto.KitchenStationRequest) (*dto.KitchenStationResponse, error)
+	UpdateStation(ctx context.Context, id uint, req dto.KitchenStationRequest) (*dto.KitchenStationResponse, error)
+	DeleteStation(ctx context.Context, id uint) error
+	GetTickets(ctx context.Context, stationID uint, status string) ([]dto.KitchenTicketResponse, error)
+	BumpItem(ctx context.Context, itemID uint) (*dto.KitchenTicketResponse, error)
+	ReadyTicket(ctx context.Context, ticketID uint) (*dto.KitchenTicketResponse, error)
+	PublishOrderTickets(ctx context.Context, orderID uint)
+	Subscribe(stationID uint) (<-chan dto.KitchenEvent, func())
+}
+
+type kitchenUseCase struct {
+	kitchenRepo  repository.KitchenRepository
+	categoryRepo repository.CategoryRepository
+	logger       *zap.Logger
+
+	mu          sync.RWMutex
+	subscribers map[chan dto.KitchenEvent]uint // channel -> station_id filter (0 = semua station)
+}
+
+func NewKitchenUseCase(kitchenRepo repository.KitchenRepository, categoryRepo repository.CategoryRepository, logger *zap.Logger) KitchenUseCase {
+	return &kitchenUseCase{
+		kitchenRepo:  kitchenRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+		subscribers:  make(map[chan dto.KitchenEvent]uint),
+	}
+}
+
+func (uc *kitchenUseCase) GetAllStations(ctx context.Context) ([]dto.KitchenStationResponse, error) {
+	stations, err := uc.kitchenRepo.FindAllStations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.KitchenStationResponse, 0, len(stations))
+	for i := range stations {
+		responses = append(responses, toKitchenStationResponse(&stations[i]))
+	}
+	return responses, nil
+}
+
+func (uc *kitchenUseCase) CreateStation(ctx context.Context, req dto.KitchenStationRequest) (*dto.KitchenStationResponse, error) {
+	uc.logger.Info("Creating kitchen station", zap.String("name", req.Name))
+
+	if err := uc.validateCategories(ctx, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	station := &entity.KitchenStation{Name: req.Name, IsDefault: req.IsDefault}
+	if err := uc.kitchenRepo.CreateStation(ctx, station, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	response := toKitchenStationResponse(station)
+	return &response, nil
+}
+
+func (uc *kitchenUseCase) UpdateStation(ctx context.Context, id uint, req dto.KitchenStationRequest) (*dto.KitchenStationResponse, error) {
+	uc.logger.Info("Updating kitchen station", zap.Uint("id", id))
+
+	station, err := uc.findStation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateCategories(ctx, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	station.Name = req.Name
+	station.IsDefault = req.IsDefault
+	if err := uc.kitchenRepo.UpdateStation(ctx, station, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	response := toKitchenStationResponse(station)
+	return &response, nil
+}
+
+func (uc *kitchenUseCase) DeleteStation(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting kitchen station", zap.Uint("id", id))
+
+	if _, err := uc.findStation(ctx, id); err != nil {
+		return err
+	}
+	return uc.kitchenRepo.DeleteStation(ctx, id)
+}
+
+// GetTickets mengambil tiket dapur per station. status berisi daftar status dipisah koma,
+// kosong berarti tiket yang masih aktif (queued dan cooking).
+func (uc *kitchenUseCase) GetTickets(ctx context.Context, stationID uint, status string) ([]dto.KitchenTicketResponse, error) {
+	statuses, err := parseKitchenStatuses(status)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := uc.kitchenRepo.FindTickets(ctx, stationID, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.KitchenTicketResponse, 0, len(tickets))
+	for i := range tickets {
+		responses = append(responses, toKitchenTicketResponse(&tickets[i]))
+	}
+	return responses, nil
+}
+
+// BumpItem memajukan status item dapur (queued -> cooking -> ready) dan menyiarkan perubahan tiket
+func (uc *kitchenUseCase) BumpItem(ctx context.Context, itemID uint) (*dto.KitchenTicketResponse, error) {
+	uc.logger.Info("Bumping kitchen item", zap.Uint("item_id", itemID))
+
+	ticket, err := uc.kitchenRepo.BumpItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: item_id %d", ErrKitchenTicketNotFound, itemID)
+		}
+		return nil, err
+	}
+
+	response := toKitchenTicketResponse(ticket)
+	uc.publish(response)
+	return &response, nil
+}
+
+// ReadyTicket menandai seluruh item tiket ready dan menyiarkan perubahan tiket
+func (uc *kitchenUseCase) ReadyTicket(ctx context.Context, ticketID uint) (*dto.KitchenTicketResponse, error) {
+	uc.logger.Info("Marking kitchen ticket ready", zap.Uint("ticket_id", ticketID))
+
+	ticket, err := uc.kitchenRepo.ReadyTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: ticket_id %d", ErrKitchenTicketNotFound, ticketID)
+		}
+		return nil, err
+	}
+
+	response := toKitchenTicketResponse(ticket)
+	uc.publish(response)
+	return &response, nil
+}
+
+// PublishOrderTickets menyiarkan tiket dapur sebuah order setelah order dibuat, diubah, atau dibatalkan.
+// Kegagalan hanya dicatat karena order sudah tersimpan.
+func (uc *kitchenUseCase) PublishOrderTickets(ctx context.Context, orderID uint) {
+	tickets, err := uc.kitchenRepo.FindTicketsByOrder(ctx, orderID)
+	if err != nil {
+		uc.logger.Warn("Failed to load kitchen tickets for publishing", zap.Uint("order_id", orderID), zap.Error(err))
+		return
+	}
+	for i := range tickets {
+		uc.publish(toKitchenTicketResponse(&tickets[i]))
+	}
+}
+
+// Subscribe mendaftarkan penerima event tiket untuk satu station (0 = semua station).
+// Fungsi yang dikembalikan wajib dipanggil untuk berhenti berlangganan.
+func (uc *kitchenUseCase) Subscribe(stationID uint) (<-chan dto.KitchenEvent, func()) {
+	ch := make(chan dto.KitchenEvent, kitchenSubscriberBuffer)
+
+	uc.mu.Lock()
+	uc.subscribers[ch] = stationID
+	uc.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			uc.mu.Lock()
+			delete(uc.subscribers, ch)
+			uc.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish mengirim perubahan tiket ke semua subscriber station terkait.
+// Subscriber yang antriannya penuh dilewati agar bump di dapur tidak tertahan koneksi lambat.
+func (uc *kitchenUseCase) publish(ticket dto.KitchenTicketResponse) {
+	event := dto.KitchenEvent{Type: dto.KitchenEventTicketUpdated, Ticket: &ticket}
+
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	for ch, stationID := range uc.subscribers {
+		if stationID != 0 && (ticket.StationID == nil || *ticket.StationID != stationID) {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+			uc.logger.Warn("Kitchen subscriber is lagging, event dropped",
+				zap.Uint("station_id", stationID),
+				zap.Uint("ticket_id", ticket.ID))
+		}
+	}
+}
+
+// validateCategories memastikan semua kategori yang dipetakan ke station ada
+func (uc *kitchenUseCase) validateCategories(ctx context.Context, categoryIDs []uint) error {
+	for _, id := range categoryIDs {
+		if _, err := uc.categoryRepo.Detail(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: category_id %d", ErrKitchenCategoryNotFound, id)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// findStation mengambil station dan mengubah record not found menjadi ErrKitchenStationNotFound
+func (uc *kitchenUseCase) findStation(ctx context.Context, id uint) (*entity.KitchenStation, error) {
+	station, err := uc.kitchenRepo.FindStationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: station_id %d", ErrKitchenStationNotFound, id)
+		}
+		return nil, err
+	}
+	return station, nil
+}
+
+// parseKitchenStatuses membaca filter status tiket dipisah koma
+func parseKitchenStatuses(status string) ([]string, error) {
+	if strings.TrimSpace(status) == "" {
+		return repository.ActiveKitchenStatuses, nil
+	}
+
+	var statuses []string
+	for _, s := range strings.Split(status, ",") {
+		s = strings.TrimSpace(s)
+		switch s {
+		case entity.KitchenStatusQueued, entity.KitchenStatusCooking, entity.KitchenStatusReady, entity.KitchenStatusCancelled:
+			statuses = append(statuses, s)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKitchenStatus, s)
+		}
+	}
+	return statuses, nil
+}
+
+// toKitchenStationResponse mengkonversi entity station dapur ke response DTO
+func toKitchenStationResponse(station *entity.KitchenStation) dto.KitchenStationResponse {
+	categoryIDs := make([]uint, 0, len(station.Categories))
+	for _, c := range station.Categories {
+		categoryIDs = append(categoryIDs, c.CategoryID)
+	}
+
+	return dto.KitchenStationResponse{
+		ID:          station.ID,
+		Name:        station.Name,
+		CategoryIDs: categoryIDs,
+		IsDefault:   station.IsDefault,
+		CreatedAt:   station.CreatedAt,
+		UpdatedAt:   station.UpdatedAt,
+	}
+}
+
+// toKitchenTicketResponse mengkonversi entity tiket dapur ke response DTO
+func toKitchenTicketResponse(ticket *entity.KitchenTicket) dto.KitchenTicketResponse {
+	items := make([]dto.KitchenTicketItemResponse, 0, len(ticket.Items))
+	for _, item := range ticket.Items {
+		items = append(items, dto.KitchenTicketItemResponse{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Note:        item.Note,
+			Quantity:    item.Quantity,
+			Status:      item.Status,
+			StartedAt:   item.StartedAt,
+			ReadyAt:     item.ReadyAt,
+		})
+	}
+
+	response := dto.KitchenTicketResponse{
+		ID:           ticket.ID,
+		OrderID:      ticket.OrderID,
+		OrderType:    ticket.Order.OrderType,
+		OrderStatus:  ticket.Order.Status,
+		CustomerName: ticket.Order.CustomerName,
+		StationID:    ticket.StationID,
+		Status:       ticket.Status,
+		ReadyAt:      ticket.ReadyAt,
+		CreatedAt:    ticket.CreatedAt,
+		UpdatedAt:    ticket.UpdatedAt,
+		Items:        items,
+	}
+	if ticket.Order.OrderType == entity.OrderTypeDineIn {
+		response.TableNumber = ticket.Order.Table.Number
+	}
+	if ticket.Station != nil {
+		response.StationName = ticket.Station.Name
+	}
+	return response
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// lowStockItem adalah data satu item pada notifikasi stok menipis (field Data)
+type lowStockItem struct {
+	Kind        string  `json:"kind"` // inventory atau product
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Category    string  `json:"category,omitempty"`
+	Quantity    float64 `json:"quantity"`
+	Unit        string  `json:"unit,omitempty"`
+	MinStock    int     `json:"min_stock"`
+	StockStatus string  `json:"stock_status"` // lowstock atau outofstock
+}
+
+// NotifyLowStock mempublikasikan event stok menipis untuk inventory dan produk yang stoknya turun
+// di bawah MinStock; aturan notifikasi meneruskannya sebagai alert ke manager. Item diklaim (ditandai)
+// secara atomik sebelum dipublikasikan, sehingga setiap item hanya dipublikasikan sekali sampai stoknya
+// kembali mencapai MinStock, walaupun pengecekan berjalan bersamaan atau sebelumnya gagal di tengah.
+func (u *inventoriesUsecase) NotifyLowStock(ctx context.Context) (int, error) {
+	if _, err := u.inventoriesRepo.ClearRestockedAlerts(ctx); err != nil {
+		return 0, err
+	}
+	if _, err := u.productRepo.ClearRestockedAlerts(ctx); err != nil {
+		return 0, err
+	}
+
+	inventories, err := u.inventoriesRepo.ClaimLowStock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	products, err := u.productRepo.ClaimLowStock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(inventories) == 0 && len(products) == 0 {
+		return 0, nil
+	}
+
+	items := make([]lowStockItem, 0, len(inventories)+len(products))
+	inventoryIDs := make([]int64, 0, len(inventories))
+	for _, inv := range inventories {
+		items = append(items, lowStockItem{
+			Kind:        "inventory",
+			ID:          inv.ID,
+			Name:        inv.Name,
+			Category:    inv.Category,
+			Quantity:    inv.Quantity,
+			Unit:        inv.Unit,
+			MinStock:    inv.MinStock,
+			StockStatus: stockStatus(inv.Quantity),
+		})
+		inventoryIDs = append(inventoryIDs, inv.ID)
+	}
+	productIDs := make([]uint, 0, len(products))
+	for _, p := range products {
+		items = append(items, lowStockItem{
+			Kind:        "product",
+			ID:          int64(p.ID),
+			Name:        p.ProductName,
+			Quantity:    float64(p.Stock),
+			MinStock:    p.MinStock,
+			StockStatus: stockStatus(float64(p.Stock)),
+		})
+		productIDs = append(productIDs, p.ID)
+	}
+
+	u.events.Publish(ctx, EventLowStock, LowStockEvent{Items: items})
+
+	u.logger.Info("Low stock event published",
+		zap.Int("inventories", len(inventoryIDs)),
+		zap.Int("products", len(productIDs)))
+	return len(items), nil
+}
+
+// RunLowStockAlerts menjalankan NotifyLowStock secara berkala sampai ctx dibatalkan;
+// interval <= 0 menonaktifkan job
+func (u *inventoriesUsecase) RunLowStockAlerts(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		u.logger.Info("Low stock alert job disabled")
+		return
+	}
+	u.logger.Info("Low stock alert job started", zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.NotifyLowStock(ctx); err != nil && ctx.Err() == nil {
+			u.logger.Error("Failed to send low stock notifications", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			u.logger.Info("Low stock alert job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stockStatus mengembalikan status stok item yang sudah di bawah batas minimum
+func stockStatus(quantity float64) string {
+	if quantity <= 0 {
+		return "outofstock"
+	}
+	return "lowstock"
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrModifierGroupNotFound dikembalikan jika modifier group tidak ada atau sudah dihapus
+	ErrModifierGroupNotFound = errors.New("modifier group tidak ditemukan")
+	// ErrInvalidModifierGroup dikembalikan jika aturan min/max atau opsi modifier group tidak valid
+	ErrInvalidModifierGroup = errors.New("data modifier group tidak valid")
+	// ErrModifierTargetNotFound dikembalikan jika produk/kategori tempat group dipasang tidak ada
+	ErrModifierTargetNotFound = errors.New("produk atau kategori modifier tidak ditemukan")
+)
+
+type ModifierUseCase interface {
+	GetAllModifierGroups(ctx context.Context, productID uint) ([]dto.ModifierGroupResponse, error)
+	GetModifierGroupByID(ctx context.Context, id uint) (*dto.ModifierGroupResponse, error)
+	CreateModifierGroup(ctx context.Context, req dto.ModifierGroupRequest) (*dto.ModifierGroupResponse, error)
+	UpdateModifierGroup(ctx context.Context, id uint, req dto.ModifierGroupRequest) (*dto.ModifierGroupResponse, error)
+	DeleteModifierGroup(ctx context.Context, id uint) error
+}
+
+type modifierUseCase struct {
+	modifierRepo repository.ModifierRepository
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	logger       *zap.Logger
+}
+
+func NewModifierUseCase(
+	modifierRepo repository.ModifierRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	logger *zap.Logger,
+) ModifierUseCase {
+	return &modifierUseCase{
+		modifierRepo: modifierRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+	}
+}
+
+// GetAllModifierGroups mengambil semua modifier group, atau hanya group yang berlaku untuk
+// produk jika productID diisi (group milik produk dan kategorinya)
+func (uc *modifierUseCase) GetAllModifierGroups(ctx context.Context, productID uint) ([]dto.ModifierGroupResponse, error) {
+	var groups []entity.ModifierGroup
+	var err error
+	if productID != 0 {
+		groups, err = uc.modifierRepo.FindForProduct(ctx, productID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: product_id %d", ErrModifierTargetNotFound, productID)
+		}
+	} else {
+		groups, err = uc.modifierRepo.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ModifierGroupResponse, 0, len(groups))
+	for i := range groups {
+		responses = append(responses, toModifierGroupResponse(&groups[i]))
+	}
+	return responses, nil
+}
+
+func (uc *modifierUseCase) GetModifierGroupByID(ctx context.Context, id uint) (*dto.ModifierGroupResponse, error) {
+	group, err := uc.findModifierGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toModifierGroupResponse(group)
+	return &response, nil
+}
+
+func (uc *modifierUseCase) CreateModifierGroup(ctx context.Context, req dto.ModifierGroupRequest) (*dto.ModifierGroupResponse, error) {
+	uc.logger.Info("Creating modifier group", zap.String("name", req.Name))
+
+	group := &entity.ModifierGroup{}
+	if err := uc.validateModifierGroup(ctx, group, req); err != nil {
+		return nil, err
+	}
+
+	applyModifierGroupRequest(group, req)
+	if err := uc.modifierRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+
+	response := toModifierGroupResponse(group)
+	return &response, nil
+}
+
+func (uc *modifierUseCase) UpdateModifierGroup(ctx context.Context, id uint, req dto.ModifierGroupRequest) (*dto.ModifierGroupResponse, error) {
+	uc.logger.Info("Updating modifier group", zap.Uint("id", id))
+
+	group, err := uc.findModifierGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateModifierGroup(ctx, group, req); err != nil {
+		return nil, err
+	}
+
+	applyModifierGroupRequest(group, req)
+	if err := uc.modifierRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	response := toModifierGroupResponse(group)
+	return &response, nil
+}
+
+func (uc *modifierUseCase) DeleteModifierGroup(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting modifier group", zap.Uint("id", id))
+
+	if _, err := uc.findModifierGroup(ctx, id); err != nil {
+		return err
+	}
+	return uc.modifierRepo.Delete(ctx, id)
+}
+
+// validateModifierGroup memastikan aturan min/max bisa dipenuhi oleh jumlah opsi, opsi lama yang
+// diperbarui memang milik group, serta produk dan kategori tujuan ada
+func (uc *modifierUseCase) validateModifierGroup(ctx context.Context, group *entity.ModifierGroup, req dto.ModifierGroupRequest) error {
+	if req.MaxSelect > 0 && req.MinSelect > req.MaxSelect {
+		return fmt.Errorf("%w: min_select tidak boleh lebih dari max_select", ErrInvalidModifierGroup)
+	}
+	if req.MinSelect > len(req.Options) {
+		return fmt.Errorf("%w: min_select melebihi jumlah opsi", ErrInvalidModifierGroup)
+	}
+
+	existing := make(map[uint]bool, len(group.Options))
+	for _, option := range group.Options {
+		existing[option.ID] = true
+	}
+	seen := make(map[uint]bool, len(req.Options))
+	for _, option := range req.Options {
+		if option.ID == 0 {
+			continue
+		}
+		if !existing[option.ID] || seen[option.ID] {
+			return fmt.Errorf("%w: opsi id %d bukan milik group ini", ErrInvalidModifierGroup, option.ID)
+		}
+		seen[option.ID] = true
+	}
+
+	for _, productID := range req.ProductIDs {
+		if _, err := uc.productRepo.Detail(ctx, productID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: product_id %d", ErrModifierTargetNotFound, productID)
+			}
+			return err
+		}
+	}
+	for _, categoryID := range req.CategoryIDs {
+		if _, err := uc.categoryRepo.Detail(ctx, categoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: category_id %d", ErrModifierTargetNotFound, categoryID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// findModifierGroup mengambil modifier group dan mengubah record not found menjadi ErrModifierGroupNotFound
+func (uc *modifierUseCase) findModifierGroup(ctx context.Context, id uint) (*entity.ModifierGroup, error) {
+	group, err := uc.modifierRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: modifier_group_id %d", ErrModifierGroupNotFound, id)
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+// applyModifierGroupRequest menyalin isi request ke entity modifier group
+func applyModifierGroupRequest(group *entity.ModifierGroup, req dto.ModifierGroupRequest) {
+	group.Name = req.Name
+	group.MinSelect = req.MinSelect
+	group.MaxSelect = req.MaxSelect
+
+	group.Options = make([]entity.ModifierOption, 0, len(req.Options))
+	for _, option := range req.Options {
+		group.Options = append(group.Options, entity.ModifierOption{
+			ID:          option.ID,
+			Name:        option.Name,
+			PriceDelta:  option.PriceDelta,
+			IsAvailable: option.IsAvailable == nil || *option.IsAvailable,
+		})
+	}
+
+	group.Products = nil
+	seenProducts := make(map[uint]bool, len(req.ProductIDs))
+	for _, productID := range req.ProductIDs {
+		if !seenProducts[productID] {
+			seenProducts[productID] = true
+			group.Products = append(group.Products, entity.ProductModifierGroup{ProductID: productID})
+		}
+	}
+	group.Categories = nil
+	seenCategories := make(map[uint]bool, len(req.CategoryIDs))
+	for _, categoryID := range req.CategoryIDs {
+		if !seenCategories[categoryID] {
+			seenCategories[categoryID] = true
+			group.Categories = append(group.Categories, entity.CategoryModifierGroup{CategoryID: categoryID})
+		}
+	}
+}
+
+func toModifierGroupResponse(group *entity.ModifierGroup) dto.ModifierGroupResponse {
+	options := make([]dto.ModifierOptionResponse, 0, len(group.Options))
+	for _, option := range group.Options {
+		options = append(options, dto.ModifierOptionResponse{
+			ID:          option.ID,
+			Name:        option.Name,
+			PriceDelta:  option.PriceDelta,
+			IsAvailable: option.IsAvailable,
+		})
+	}
+	productIDs := make([]uint, 0, len(group.Products))
+	for _, p := range group.Products {
+		productIDs = append(productIDs, p.ProductID)
+	}
+	categoryIDs := make([]uint, 0, len(group.Categories))
+	for _, c := range group.Categories {
+		categoryIDs = append(categoryIDs, c.CategoryID)
+	}
+
+	return dto.ModifierGroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		MinSelect:   group.MinSelect,
+		MaxSelect:   group.MaxSelect,
+		Options:     options,
+		ProductIDs:  productIDs,
+		CategoryIDs: categoryIDs,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
@@ -17,11 +17,15 @@ type NotificationUseCase interface {
 	UpdateNotificationStatus(ctx context.Context, userID uint, notificationID uint, req *dto.UpdateNotificationStatusRequest) (*dto.UpdateNotificationStatusResponse, error)
 	DeleteNotification(ctx context.Context, userID uint, notificationID uint) (*dto.DeleteNotificationResponse, error)
 	CreateNotification(ctx context.Context, notification *entity.Notification) error
+	Subscribe(userID uint) (<-chan dto.NotificationStreamEvent, func(), error)
+	GetStreamBacklog(ctx context.Context, userID uint, lastEventID uint) ([]dto.NotificationStreamEvent, error)
+	CloseStreams()
 }
 
 // notificationUseCase implementasi dari NotificationUseCase interface
 type notificationUseCase struct {
 	repo   repository.NotificationRepository
+	hub    *notificationHub
 	logger *zap.Logger
 }
 
@@ -29,6 +33,7 @@ type notificationUseCase struct {
 func NewNotificationUseCase(repo repository.NotificationRepository, logger *zap.Logger) NotificationUseCase {
 	return &notificationUseCase{
 		repo:   repo,
+		hub:    newNotificationHub(logger),
 		logger: logger,
 	}
 }
@@ -192,6 +197,8 @@ func (u *notificationUseCase) UpdateNotificationStatus(ctx context.Context, user
 		zap.String("status", req.Status),
 	)
 
+	u.publishUnreadCount(ctx, notification.UserID)
+
 	return response, nil
 }
 
@@ -251,6 +258,8 @@ func (u *notificationUseCase) DeleteNotification(ctx context.Context, userID uin
 		zap.Uint("user_id", userID),
 	)
 
+	u.publishUnreadCount(ctx, userID)
+
 	return response, nil
 }
 
@@ -292,5 +301,7 @@ func (u *notificationUseCase) CreateNotification(ctx context.Context, notificati
 		zap.Uint("user_id", notification.UserID),
 	)
 
+	u.publishCreated(ctx, notification)
+
 	return nil
 }
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+
+	"go.uber.org/zap"
+)
+
+// Tipe notifikasi yang valid (lihat entity.Notification)
+const (
+	notificationTypeOrder   = "order"
+	notificationTypePayment = "payment"
+	notificationTypeSystem  = "system"
+	notificationTypeAlert   = "alert"
+)
+
+// Role penerima notifikasi operasional di luar managerRoles
+var (
+	cashierRoles = []string{"cashier"}
+	floorRoles   = []string{"cashier", "staff", "supervisor", "user"}
+)
+
+// notificationRules menerjemahkan event domain menjadi notifikasi untuk role terkait
+type notificationRules struct {
+	authRepo       repository.AuthRepository
+	notificationUC NotificationUseCase
+	logger         *zap.Logger
+}
+
+// RegisterNotificationRules mendaftarkan aturan notifikasi ke event bus:
+//   - order.created, order.cancelled   -> order, ke manager dan staf lantai
+//   - order.paid                       -> payment, ke manager dan kasir
+//   - reservation.created/cancelled    -> system, ke manager dan staf lantai
+//   - staff.added                      -> system, ke manager
+//   - inventory.low_stock              -> alert, ke manager
+func RegisterNotificationRules(bus EventBus, authRepo repository.AuthRepository, notificationUC NotificationUseCase, logger *zap.Logger) {
+	r := &notificationRules{
+		authRepo:       authRepo,
+		notificationUC: notificationUC,
+		logger:         logger,
+	}
+
+	bus.Subscribe(EventOrderCreated, r.onOrderCreated)
+	bus.Subscribe(EventOrderPaid, r.onOrderPaid)
+	bus.Subscribe(EventOrderCancelled, r.onOrderCancelled)
+	bus.Subscribe(EventReservationCreated, r.onReservationCreated)
+	bus.Subscribe(EventReservationCancelled, r.onReservationCancelled)
+	bus.Subscribe(EventStaffAdded, r.onStaffAdded)
+	bus.Subscribe(EventLowStock, r.onLowStock)
+}
+
+func (r *notificationRules) onOrderCreated(ctx context.Context, event DomainEvent) error {
+	order, ok := event.Payload.(OrderEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("Order #%d atas nama %s%s dengan total %.2f", order.OrderID, order.CustomerName, atTable(order.TableNumber), order.TotalAmount)
+	return r.deliver(ctx, event, withManagers(floorRoles), notificationTypeOrder, "Order baru", message, order)
+}
+
+func (r *notificationRules) onOrderPaid(ctx context.Context, event DomainEvent) error {
+	order, ok := event.Payload.(OrderEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("Order #%d atas nama %s%s lunas sebesar %.2f", order.OrderID, order.CustomerName, atTable(order.TableNumber), order.PaidAmount)
+	return r.deliver(ctx, event, withManagers(cashierRoles), notificationTypePayment, "Order dibayar", message, order)
+}
+
+func (r *notificationRules) onOrderCancelled(ctx context.Context, event DomainEvent) error {
+	order, ok := event.Payload.(OrderEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("Order #%d atas nama %s%s dibatalkan", order.OrderID, order.CustomerName, atTable(order.TableNumber))
+	if order.Reason != "" {
+		message += " (" + order.Reason + ")"
+	}
+	return r.deliver(ctx, event, withManagers(floorRoles), notificationTypeOrder, "Order dibatalkan", message, order)
+}
+
+func (r *notificationRules) onReservationCreated(ctx context.Context, event DomainEvent) error {
+	reservation, ok := event.Payload.(ReservationEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("Reservasi atas nama %s%s%s", reservation.CustomerName, atTable(reservation.TableNumber), atTime(reservation))
+	return r.deliver(ctx, event, withManagers(floorRoles), notificationTypeSystem, "Reservasi baru", message, reservation)
+}
+
+func (r *notificationRules) onReservationCancelled(ctx context.Context, event DomainEvent) error {
+	reservation, ok := event.Payload.(ReservationEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("Reservasi atas nama %s%s%s dibatalkan", reservation.CustomerName, atTable(reservation.TableNumber), atTime(reservation))
+	return r.deliver(ctx, event, withManagers(floorRoles), notificationTypeSystem, "Reservasi dibatalkan", message, reservation)
+}
+
+func (r *notificationRules) onStaffAdded(ctx context.Context, event DomainEvent) error {
+	staff, ok := event.Payload.(StaffEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	message := fmt.Sprintf("%s (%s) ditambahkan sebagai %s", staff.FullName, staff.Email, staff.Role)
+	return r.deliver(ctx, event, managerRoleNames(), notificationTypeSystem, "Staff baru", message, staff)
+}
+
+func (r *notificationRules) onLowStock(ctx context.Context, event DomainEvent) error {
+	lowStock, ok := event.Payload.(LowStockEvent)
+	if !ok {
+		return unexpectedPayload(event)
+	}
+	lines := make([]string, 0, len(lowStock.Items))
+	for _, item := range lowStock.Items {
+		if item.Unit != "" {
+			lines = append(lines, fmt.Sprintf("%s (%g/%d %s)", item.Name, item.Quantity, item.MinStock, item.Unit))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s (%g/%d)", item.Name, item.Quantity, item.MinStock))
+		}
+	}
+	message := fmt.Sprintf("%d item stoknya di bawah batas minimum: %s", len(lowStock.Items), strings.Join(lines, "; "))
+	return r.deliver(ctx, event, managerRoleNames(), notificationTypeAlert, "Stok menipis", message, lowStock)
+}
+
+// deliver mengirim notifikasi ke semua user aktif dengan role penerima dan mencatat jumlahnya
+func (r *notificationRules) deliver(ctx context.Context, event DomainEvent, roles []string, notificationType, title, message string, data interface{}) error {
+	recipients, err := notifyRoles(ctx, r.authRepo, r.notificationUC, roles, notificationType, title, message, data)
+	if err != nil {
+		return err
+	}
+	if recipients == 0 {
+		r.logger.Warn("No recipient for event notification", zap.String("event", event.Name), zap.Strings("roles", roles))
+		return nil
+	}
+	r.logger.Info("Event notification sent",
+		zap.String("event", event.Name),
+		zap.String("type", notificationType),
+		zap.Int("recipients", recipients))
+	return nil
+}
+
+// notifyRoles mengirim notifikasi yang sama ke semua user aktif dengan salah satu role;
+// data disimpan sebagai JSON di field Data. Mengembalikan jumlah penerima.
+func notifyRoles(ctx context.Context, authRepo repository.AuthRepository, notificationUC NotificationUseCase, roles []string, notificationType, title, message string, data interface{}) (int, error) {
+	recipients, err := authRepo.GetActiveUsersByRoles(ctx, roles)
+	if err != nil {
+		return 0, err
+	}
+	if len(recipients) == 0 {
+		return 0, nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	for _, user := range recipients {
+		notification := &entity.Notification{
+			UserID:  user.ID,
+			Title:   title,
+			Message: message,
+			Type:    notificationType,
+			Data:    string(payload),
+		}
+		if err := notificationUC.CreateNotification(ctx, notification); err != nil {
+			return 0, err
+		}
+	}
+	return len(recipients), nil
+}
+
+// managerRoleNames mengembalikan nama role di managerRoles secara terurut
+func managerRoleNames() []string {
+	roles := make([]string, 0, len(managerRoles))
+	for role := range managerRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// withManagers menggabungkan role manager dengan role lain
+func withManagers(roles []string) []string {
+	return append(managerRoleNames(), roles...)
+}
+
+func unexpectedPayload(event DomainEvent) error {
+	return fmt.Errorf("payload event %s tidak dikenal: %T", event.Name, event.Payload)
+}
+
+func atTable(number string) string {
+	if number == "" {
+		return ""
+	}
+	return " di meja " + number
+}
+
+func atTime(reservation ReservationEvent) string {
+	if reservation.ReservationTime == nil {
+		return ""
+	}
+	return " pada " + reservation.ReservationTime.Format("2006-01-02 15:04")
+}
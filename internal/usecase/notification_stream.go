@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+)
+
+// ErrNotificationStreamClosed dikembalikan jika hub stream notifikasi sudah ditutup (server shutdown)
+var ErrNotificationStreamClosed = errors.New("stream notifikasi sudah ditutup")
+
+const (
+	// notificationSubscriberBuffer adalah kapasitas antrian event per koneksi stream notifikasi
+	notificationSubscriberBuffer = 32
+	// notificationReplayPageSize adalah jumlah notifikasi yang dibaca per query saat mengirim ulang
+	// notifikasi setelah Last-Event-ID
+	notificationReplayPageSize = 100
+)
+
+// notificationHub mencatat koneksi stream notifikasi yang aktif per user
+type notificationHub struct {
+	logger *zap.Logger
+
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan dto.NotificationStreamEvent]struct{} // user_id -> channel
+	closed      bool
+}
+
+func newNotificationHub(logger *zap.Logger) *notificationHub {
+	return &notificationHub{
+		logger:      logger,
+		subscribers: make(map[uint]map[chan dto.NotificationStreamEvent]struct{}),
+	}
+}
+
+// subscribe mendaftarkan koneksi baru untuk user; fungsi yang dikembalikan wajib dipanggil saat
+// koneksi selesai
+func (h *notificationHub) subscribe(userID uint) (<-chan dto.NotificationStreamEvent, func(), error) {
+	ch := make(chan dto.NotificationStreamEvent, notificationSubscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil, nil, ErrNotificationStreamClosed
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan dto.NotificationStreamEvent]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if _, ok := h.subscribers[userID][ch]; !ok {
+				return // Sudah ditutup oleh close
+			}
+			delete(h.subscribers[userID], ch)
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+			close(ch)
+		})
+	}, nil
+}
+
+// hasSubscribers mengembalikan true jika user punya minimal satu koneksi stream aktif
+func (h *notificationHub) hasSubscribers(userID uint) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[userID]) > 0
+}
+
+// publish mengirim event ke semua koneksi user. Koneksi yang antriannya penuh dilewati; client
+// bisa mengambil event yang terlewat dengan menyambung ulang memakai Last-Event-ID.
+func (h *notificationHub) publish(userID uint, event dto.NotificationStreamEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+			h.logger.Warn("Notification subscriber is lagging, event dropped",
+				zap.Uint("user_id", userID),
+				zap.String("type", event.Type),
+				zap.Uint("id", event.ID))
+		}
+	}
+}
+
+// close menutup semua koneksi stream dan menolak koneksi baru
+func (h *notificationHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	connections := 0
+	for userID, channels := range h.subscribers {
+		for ch := range channels {
+			close(ch)
+			connections++
+		}
+		delete(h.subscribers, userID)
+	}
+	h.logger.Info("Notification stream hub closed", zap.Int("connections", connections))
+}
+
+// Subscribe mendaftarkan koneksi stream notifikasi untuk user.
+// Fungsi yang dikembalikan wajib dipanggil untuk berhenti berlangganan.
+func (u *notificationUseCase) Subscribe(userID uint) (<-chan dto.NotificationStreamEvent, func(), error) {
+	if userID == 0 {
+		return nil, nil, errors.New("user_id tidak valid")
+	}
+	return u.hub.subscribe(userID)
+}
+
+// GetStreamBacklog mengambil event awal koneksi stream: semua notifikasi setelah lastEventID (jika
+// > 0, dibaca per halaman notificationReplayPageSize sampai habis) diikuti jumlah notifikasi belum
+// dibaca saat ini
+func (u *notificationUseCase) GetStreamBacklog(ctx context.Context, userID uint, lastEventID uint) ([]dto.NotificationStreamEvent, error) {
+	events := make([]dto.NotificationStreamEvent, 0)
+	for afterID := lastEventID; afterID > 0; {
+		missed, err := u.repo.GetNotificationsAfterID(ctx, userID, afterID, notificationReplayPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range missed {
+			events = append(events, toNotificationCreatedEvent(&missed[i], 0))
+		}
+		if len(missed) < notificationReplayPageSize {
+			break
+		}
+		afterID = missed[len(missed)-1].ID
+	}
+
+	unreadCount, err := u.repo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].UnreadCount = unreadCount
+	}
+	events = append(events, dto.NotificationStreamEvent{Type: dto.NotificationEventUnreadCount, UnreadCount: unreadCount})
+	return events, nil
+}
+
+// CloseStreams menutup semua koneksi stream notifikasi; dipanggil saat server shutdown
+func (u *notificationUseCase) CloseStreams() {
+	u.hub.close()
+}
+
+// publishCreated mengirim notifikasi baru beserta jumlah belum dibaca ke koneksi stream user
+func (u *notificationUseCase) publishCreated(ctx context.Context, notification *entity.Notification) {
+	if !u.hub.hasSubscribers(notification.UserID) {
+		return
+	}
+	unreadCount, err := u.repo.GetUnreadCount(ctx, notification.UserID)
+	if err != nil {
+		u.logger.Warn("Failed to get unread count for notification stream", zap.Uint("user_id", notification.UserID), zap.Error(err))
+		return
+	}
+	u.hub.publish(notification.UserID, toNotificationCreatedEvent(notification, unreadCount))
+}
+
+// publishUnreadCount mengirim jumlah notifikasi belum dibaca terbaru ke koneksi stream user
+func (u *notificationUseCase) publishUnreadCount(ctx context.Context, userID uint) {
+	if !u.hub.hasSubscribers(userID) {
+		return
+	}
+	unreadCount, err := u.repo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		u.logger.Warn("Failed to get unread count for notification stream", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+	u.hub.publish(userID, dto.NotificationStreamEvent{Type: dto.NotificationEventUnreadCount, UnreadCount: unreadCount})
+}
+
+func toNotificationCreatedEvent(n *entity.Notification, unreadCount int64) dto.NotificationStreamEvent {
+	return dto.NotificationStreamEvent{
+		ID:   n.ID,
+		Type: dto.NotificationEventCreated,
+		Notification: &dto.NotificationResponse{
+			ID:        n.ID,
+			UserID:    n.UserID,
+			Title:     n.Title,
+			Message:   n.Message,
+			Type:      n.Type,
+			Status:    n.Status,
+			ReadedAt:  n.ReadedAt,
+			Data:      n.Data,
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+		},
+		UnreadCount: unreadCount,
+	}
+}
@@ -2,12 +2,17 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"aplikasi-pos-team-boolean/internal/data/entity"
 	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/pkg/receipt"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type OrderUseCase interface {
@@ -15,19 +20,78 @@ type OrderUseCase interface {
 	CreateOrder(ctx context.Context, req dto.OrderCreateRequest) (*dto.OrderResponse, error)
 	UpdateOrder(ctx context.Context, id uint, req dto.OrderUpdateRequest) error
 	DeleteOrder(ctx context.Context, id uint) error
+	ChangeOrderStatus(ctx context.Context, id uint, req dto.OrderStatusUpdateRequest) error
+	GetOrderStatusHistory(ctx context.Context, id uint) ([]dto.OrderStatusHistoryResponse, error)
+	AddPayments(ctx context.Context, id uint, req dto.OrderPaymentRequest) (*dto.OrderSettlementResponse, error)
+	GetOrderPayments(ctx context.Context, id uint) ([]dto.OrderPaymentResponse, error)
+	SplitOrderItems(ctx context.Context, id uint, req dto.OrderSplitItemsRequest) ([]dto.OrderResponse, error)
+	SplitOrderEven(ctx context.Context, id uint, req dto.OrderSplitEvenRequest) ([]dto.OrderResponse, error)
+	MergeOrders(ctx context.Context, id uint, req dto.OrderMergeRequest) (*dto.OrderResponse, error)
+	GetOrderLinks(ctx context.Context, id uint) ([]dto.OrderLinkResponse, error)
+	VoidOrder(ctx context.Context, id uint, req dto.OrderVoidRequest) (*dto.OrderRefundResponse, error)
+	RefundOrder(ctx context.Context, id uint, req dto.OrderRefundRequest) (*dto.OrderRefundResponse, error)
+	GetOrderRefunds(ctx context.Context, id uint) ([]dto.OrderRefundResponse, error)
+	GetOrderReceipt(ctx context.Context, id uint, format string, isCopy bool) ([]byte, string, error)
 	GetAllTables(ctx context.Context) ([]dto.TableResponse, error)
 	GetAllPaymentMethods(ctx context.Context) ([]dto.PaymentMethodResponse, error)
 	GetAvailableChairs(ctx context.Context) ([]dto.TableResponse, error)
 }
 
+var (
+	// ErrInvalidStatusTransition dikembalikan jika perubahan status order tidak diizinkan
+	ErrInvalidStatusTransition = errors.New("perubahan status order tidak diizinkan")
+	// ErrManagerApprovalRequired dikembalikan jika void/refund tidak disetujui user dengan role manager
+	ErrManagerApprovalRequired = errors.New("membutuhkan persetujuan manager")
+	// ErrOrderNotDeletable dikembalikan jika order sudah punya jejak transaksi sehingga tidak boleh dihapus
+	ErrOrderNotDeletable = errors.New("order tidak dapat dihapus, gunakan void atau refund")
+)
+
+// orderStatusTransitions mendefinisikan status tujuan yang valid dari setiap status order.
+// Pembayaran boleh dilakukan di setiap tahap sebelum order ditutup. Pembatalan tidak lewat
+// perubahan status biasa, melainkan lewat VoidOrder agar tercatat di order_refunds.
+var orderStatusTransitions = map[string][]string{
+	entity.OrderStatusPending:   {entity.OrderStatusInKitchen, entity.OrderStatusPaid},
+	entity.OrderStatusInKitchen: {entity.OrderStatusServed, entity.OrderStatusPaid},
+	entity.OrderStatusServed:    {entity.OrderStatusPaid},
+	entity.OrderStatusPaid:      {entity.OrderStatusRefunded},
+}
+
+// orderVoidableStatuses adalah status order yang masih boleh di-void
+var orderVoidableStatuses = map[string]bool{
+	entity.OrderStatusPending:   true,
+	entity.OrderStatusInKitchen: true,
+	entity.OrderStatusServed:    true,
+}
+
+// canTransition mengecek apakah status order boleh berubah dari from ke to
+func canTransition(from, to string) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// managerRoles adalah role user yang boleh menyetujui override harga, void, dan refund
+var managerRoles = map[string]bool{"manager": true, "admin": true, "superadmin": true}
+
 type orderUseCase struct {
 	orderRepo repository.OrderRepository
+	authRepo  repository.AuthRepository
+	taxRepo   repository.TaxRepository
+	kitchen   KitchenUseCase
+	events    EventBus
 	logger    *zap.Logger
 }
 
-func NewOrderUseCase(orderRepo repository.OrderRepository, logger *zap.Logger) *orderUseCase {
+func NewOrderUseCase(orderRepo repository.OrderRepository, authRepo repository.AuthRepository, taxRepo repository.TaxRepository, kitchen KitchenUseCase, events EventBus, logger *zap.Logger) *orderUseCase {
 	return &orderUseCase{
 		orderRepo: orderRepo,
+		authRepo:  authRepo,
+		taxRepo:   taxRepo,
+		kitchen:   kitchen,
+		events:    events,
 		logger:    logger,
 	}
 }
@@ -62,6 +126,12 @@ func (uc *orderUseCase) CreateOrder(ctx context.Context, req dto.OrderCreateRequ
 		zap.String("customer_name", req.CustomerName),
 		zap.Uint("table_id", req.TableID))
 
+	approver, err := uc.priceApprover(ctx, req.PriceApprovedBy)
+	if err != nil {
+		return nil, err
+	}
+	req.PriceApprovedBy = approver
+
 	order, err := uc.orderRepo.Create(ctx, req)
 	if err != nil {
 		uc.logger.Error("Failed to create order",
@@ -80,6 +150,8 @@ func (uc *orderUseCase) CreateOrder(ctx context.Context, req dto.OrderCreateRequ
 	}
 
 	response := uc.toOrderResponse(*createdOrder)
+	uc.kitchen.PublishOrderTickets(ctx, order.ID)
+	uc.events.Publish(ctx, EventOrderCreated, toOrderEvent(createdOrder, ""))
 
 	uc.logger.Info("Successfully created order",
 		zap.Uint("id", order.ID),
@@ -92,7 +164,23 @@ func (uc *orderUseCase) UpdateOrder(ctx context.Context, id uint, req dto.OrderU
 		zap.Uint("id", id),
 		zap.String("customer_name", req.CustomerName))
 
-	err := uc.orderRepo.Update(ctx, id, req)
+	approver, err := uc.priceApprover(ctx, req.PriceApprovedBy)
+	if err != nil {
+		return err
+	}
+	req.PriceApprovedBy = approver
+
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	if !order.IsEditable() {
+		uc.logger.Warn("Order is not editable", zap.Uint("id", id), zap.String("status", order.Status))
+		return fmt.Errorf("%w: status %s", repository.ErrOrderNotEditable, order.Status)
+	}
+
+	err = uc.orderRepo.Update(ctx, id, req)
 	if err != nil {
 		uc.logger.Error("Failed to update order",
 			zap.Uint("id", id),
@@ -100,14 +188,28 @@ func (uc *orderUseCase) UpdateOrder(ctx context.Context, id uint, req dto.OrderU
 		return err
 	}
 
+	uc.kitchen.PublishOrderTickets(ctx, id)
+
 	uc.logger.Info("Successfully updated order", zap.Uint("id", id))
 	return nil
 }
 
+// DeleteOrder hanya untuk order pending yang belum menerima pembayaran (salah input).
+// Order lain harus di-void atau di-refund agar nominalnya tetap tercatat.
 func (uc *orderUseCase) DeleteOrder(ctx context.Context, id uint) error {
 	uc.logger.Info("Deleting order", zap.Uint("id", id))
 
-	err := uc.orderRepo.Delete(ctx, id)
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+	if order.Status != entity.OrderStatusPending || order.PaidAmount > 0 {
+		uc.logger.Warn("Order is not deletable", zap.Uint("id", id), zap.String("status", order.Status))
+		return fmt.Errorf("%w: status %s", ErrOrderNotDeletable, order.Status)
+	}
+
+	err = uc.orderRepo.Delete(ctx, id)
 	if err != nil {
 		uc.logger.Error("Failed to delete order",
 			zap.Uint("id", id),
@@ -115,10 +217,370 @@ func (uc *orderUseCase) DeleteOrder(ctx context.Context, id uint) error {
 		return err
 	}
 
+	uc.kitchen.PublishOrderTickets(ctx, id)
+
 	uc.logger.Info("Successfully deleted order", zap.Uint("id", id))
 	return nil
 }
 
+func (uc *orderUseCase) ChangeOrderStatus(ctx context.Context, id uint, req dto.OrderStatusUpdateRequest) error {
+	uc.logger.Info("Changing order status",
+		zap.Uint("id", id),
+		zap.String("status", req.Status))
+
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
+	if req.Status == entity.OrderStatusPaid {
+		uc.logger.Warn("Order must be paid through payments", zap.Uint("id", id))
+		return fmt.Errorf("%w: pelunasan order harus lewat pembayaran", ErrInvalidStatusTransition)
+	}
+	if !canTransition(order.Status, req.Status) {
+		uc.logger.Warn("Illegal order status transition",
+			zap.Uint("id", id),
+			zap.String("from_status", order.Status),
+			zap.String("to_status", req.Status))
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, order.Status, req.Status)
+	}
+
+	if err := uc.orderRepo.UpdateStatus(ctx, id, order.Status, req.Status, req.ChangedBy, req.Note); err != nil {
+		uc.logger.Error("Failed to change order status",
+			zap.Uint("id", id),
+			zap.Error(err))
+		return err
+	}
+
+	uc.logger.Info("Successfully changed order status",
+		zap.Uint("id", id),
+		zap.String("from_status", order.Status),
+		zap.String("to_status", req.Status))
+	return nil
+}
+
+func (uc *orderUseCase) GetOrderStatusHistory(ctx context.Context, id uint) ([]dto.OrderStatusHistoryResponse, error) {
+	uc.logger.Info("Getting order status history", zap.Uint("id", id))
+
+	if _, err := uc.orderRepo.FindByID(ctx, id); err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	histories, err := uc.orderRepo.FindStatusHistory(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to get order status history", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.OrderStatusHistoryResponse, 0, len(histories))
+	for _, h := range histories {
+		responses = append(responses, dto.OrderStatusHistoryResponse{
+			ID:         h.ID,
+			OrderID:    h.OrderID,
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			ChangedBy:  h.ChangedBy,
+			Note:       h.Note,
+			CreatedAt:  h.CreatedAt,
+		})
+	}
+
+	uc.logger.Info("Successfully retrieved order status history", zap.Int("count", len(responses)))
+	return responses, nil
+}
+
+// AddPayments mencatat tender pembayaran order; order otomatis menjadi paid jika sudah lunas
+func (uc *orderUseCase) AddPayments(ctx context.Context, id uint, req dto.OrderPaymentRequest) (*dto.OrderSettlementResponse, error) {
+	uc.logger.Info("Adding order payments",
+		zap.Uint("id", id),
+		zap.Int("tenders", len(req.Tenders)))
+
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	if !canTransition(order.Status, entity.OrderStatusPaid) {
+		uc.logger.Warn("Order cannot be paid", zap.Uint("id", id), zap.String("status", order.Status))
+		return nil, fmt.Errorf("%w: status %s", repository.ErrOrderNotEditable, order.Status)
+	}
+
+	payments, err := uc.orderRepo.AddPayments(ctx, id, order.Status, req.Tenders, req.ReceivedBy)
+	if err != nil {
+		uc.logger.Error("Failed to add order payments", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	settled, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	response := &dto.OrderSettlementResponse{
+		OrderID:         settled.ID,
+		Status:          settled.Status,
+		TotalAmount:     settled.TotalAmount,
+		PaidAmount:      settled.PaidAmount,
+		RemainingAmount: settled.RemainingAmount(),
+		Payments:        toOrderPaymentResponses(settled.Payments),
+	}
+	for _, payment := range payments {
+		response.ChangeDue += payment.Change
+	}
+	if settled.Status == entity.OrderStatusPaid {
+		uc.events.Publish(ctx, EventOrderPaid, toOrderEvent(settled, ""))
+	}
+
+	uc.logger.Info("Successfully added order payments",
+		zap.Uint("id", id),
+		zap.String("status", settled.Status),
+		zap.Float64("paid_amount", settled.PaidAmount),
+		zap.Float64("change_due", response.ChangeDue))
+	return response, nil
+}
+
+// GetOrderPayments mengambil semua pembayaran order
+func (uc *orderUseCase) GetOrderPayments(ctx context.Context, id uint) ([]dto.OrderPaymentResponse, error) {
+	uc.logger.Info("Getting order payments", zap.Uint("id", id))
+
+	if _, err := uc.orderRepo.FindByID(ctx, id); err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	payments, err := uc.orderRepo.FindPayments(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to get order payments", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return toOrderPaymentResponses(payments), nil
+}
+
+// SplitOrderItems memindah item terpilih ke order baru, mengembalikan order asal dan order baru
+func (uc *orderUseCase) SplitOrderItems(ctx context.Context, id uint, req dto.OrderSplitItemsRequest) ([]dto.OrderResponse, error) {
+	uc.logger.Info("Splitting order items", zap.Uint("id", id), zap.Int("items", len(req.Items)))
+
+	if _, err := uc.findRegroupOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
+	created, err := uc.orderRepo.SplitItems(ctx, id, req)
+	if err != nil {
+		uc.logger.Error("Failed to split order items", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return uc.findOrderResponses(ctx, []uint{id, created.ID})
+}
+
+// SplitOrderEven membagi order menjadi beberapa bagian dengan nominal sama, mengembalikan semua bagian
+func (uc *orderUseCase) SplitOrderEven(ctx context.Context, id uint, req dto.OrderSplitEvenRequest) ([]dto.OrderResponse, error) {
+	uc.logger.Info("Splitting order evenly", zap.Uint("id", id), zap.Int("parts", req.Parts))
+
+	if _, err := uc.findRegroupOrder(ctx, id); err != nil {
+		return nil, err
+	}
+
+	shares, err := uc.orderRepo.SplitEven(ctx, id, req.Parts, req.CreatedBy)
+	if err != nil {
+		uc.logger.Error("Failed to split order evenly", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(shares))
+	for _, share := range shares {
+		ids = append(ids, share.ID)
+	}
+	return uc.findOrderResponses(ctx, ids)
+}
+
+// MergeOrders menggabungkan order lain (boleh dari meja berbeda) ke order id
+func (uc *orderUseCase) MergeOrders(ctx context.Context, id uint, req dto.OrderMergeRequest) (*dto.OrderResponse, error) {
+	uc.logger.Info("Merging orders", zap.Uint("target_id", id), zap.Any("order_ids", req.OrderIDs))
+
+	for _, orderID := range append([]uint{id}, req.OrderIDs...) {
+		if _, err := uc.findRegroupOrder(ctx, orderID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := uc.orderRepo.Merge(ctx, id, req.OrderIDs, req.CreatedBy); err != nil {
+		uc.logger.Error("Failed to merge orders", zap.Uint("target_id", id), zap.Error(err))
+		return nil, err
+	}
+
+	responses, err := uc.findOrderResponses(ctx, []uint{id})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// GetOrderLinks mengambil jejak audit split/merge order
+func (uc *orderUseCase) GetOrderLinks(ctx context.Context, id uint) ([]dto.OrderLinkResponse, error) {
+	uc.logger.Info("Getting order links", zap.Uint("id", id))
+
+	if _, err := uc.orderRepo.FindByID(ctx, id); err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	links, err := uc.orderRepo.FindLinks(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to get order links", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.OrderLinkResponse, 0, len(links))
+	for _, link := range links {
+		responses = append(responses, dto.OrderLinkResponse{
+			ID:            link.ID,
+			SourceOrderID: link.SourceOrderID,
+			ResultOrderID: link.ResultOrderID,
+			LinkType:      link.LinkType,
+			CreatedBy:     link.CreatedBy,
+			CreatedAt:     link.CreatedAt,
+		})
+	}
+	return responses, nil
+}
+
+// VoidOrder membatalkan order yang belum dibayar dengan kode alasan dan persetujuan manager
+func (uc *orderUseCase) VoidOrder(ctx context.Context, id uint, req dto.OrderVoidRequest) (*dto.OrderRefundResponse, error) {
+	uc.logger.Info("Voiding order",
+		zap.Uint("id", id),
+		zap.String("reason_code", req.ReasonCode),
+		zap.Uint("approved_by", req.ApprovedBy))
+
+	if err := uc.requireManager(ctx, req.ApprovedBy); err != nil {
+		return nil, err
+	}
+
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	if !orderVoidableStatuses[order.Status] {
+		uc.logger.Warn("Order cannot be voided", zap.Uint("id", id), zap.String("status", order.Status))
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, order.Status, entity.OrderStatusCancelled)
+	}
+
+	record, err := uc.orderRepo.Void(ctx, id, order.Status, req)
+	if err != nil {
+		uc.logger.Error("Failed to void order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	uc.kitchen.PublishOrderTickets(ctx, id)
+
+	// Event dibentuk dari order yang dibaca ulang setelah void, bukan dari data sebelum transaksi
+	voided, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to reload voided order", zap.Uint("id", id), zap.Error(err))
+	} else {
+		uc.events.Publish(ctx, EventOrderCancelled, toOrderEvent(voided, req.ReasonCode))
+	}
+
+	response := toOrderRefundResponse(*record)
+	return &response, nil
+}
+
+// RefundOrder mengembalikan uang order yang sudah dibayar (penuh atau per item) dengan persetujuan manager
+func (uc *orderUseCase) RefundOrder(ctx context.Context, id uint, req dto.OrderRefundRequest) (*dto.OrderRefundResponse, error) {
+	uc.logger.Info("Refunding order",
+		zap.Uint("id", id),
+		zap.String("reason_code", req.ReasonCode),
+		zap.Uint("approved_by", req.ApprovedBy))
+
+	if err := uc.requireManager(ctx, req.ApprovedBy); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.orderRepo.FindByID(ctx, id); err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	record, err := uc.orderRepo.Refund(ctx, id, req)
+	if err != nil {
+		uc.logger.Error("Failed to refund order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	response := toOrderRefundResponse(*record)
+	return &response, nil
+}
+
+// GetOrderRefunds mengambil semua void/refund order
+func (uc *orderUseCase) GetOrderRefunds(ctx context.Context, id uint) ([]dto.OrderRefundResponse, error) {
+	uc.logger.Info("Getting order refunds", zap.Uint("id", id))
+
+	if _, err := uc.orderRepo.FindByID(ctx, id); err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	refunds, err := uc.orderRepo.FindRefunds(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to get order refunds", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	responses := make([]dto.OrderRefundResponse, 0, len(refunds))
+	for _, refund := range refunds {
+		responses = append(responses, toOrderRefundResponse(refund))
+	}
+	return responses, nil
+}
+
+// GetOrderReceipt membuat receipt order dalam format text, html, atau escpos memakai template outlet order.
+// isCopy menandai receipt cetak ulang dengan penanda COPY.
+func (uc *orderUseCase) GetOrderReceipt(ctx context.Context, id uint, format string, isCopy bool) ([]byte, string, error) {
+	uc.logger.Info("Rendering order receipt",
+		zap.Uint("id", id),
+		zap.String("format", format),
+		zap.Bool("copy", isCopy))
+
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, "", err
+	}
+
+	outlet, err := uc.taxRepo.FindOutletByID(ctx, order.OutletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", fmt.Errorf("%w: outlet_id %d", repository.ErrOutletNotFound, order.OutletID)
+		}
+		uc.logger.Error("Failed to find outlet", zap.Uint("outlet_id", order.OutletID), zap.Error(err))
+		return nil, "", err
+	}
+
+	productIDs := make([]uint, 0, len(order.Items))
+	for _, item := range order.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	productNames, err := uc.orderRepo.FindProductNames(ctx, productIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r := toReceipt(order, outlet, productNames)
+	r.IsCopy = isCopy
+	r.PrintedAt = time.Now()
+
+	body, contentType, err := receipt.Render(r, format)
+	if err != nil {
+		uc.logger.Warn("Failed to render receipt", zap.Uint("id", id), zap.String("format", format), zap.Error(err))
+		return nil, "", err
+	}
+	return body, contentType, nil
+}
+
 func (uc *orderUseCase) GetAllTables(ctx context.Context) ([]dto.TableResponse, error) {
 	uc.logger.Info("Getting all tables")
 
@@ -154,8 +616,9 @@ func (uc *orderUseCase) GetAllPaymentMethods(ctx context.Context) ([]dto.Payment
 	var responses []dto.PaymentMethodResponse
 	for _, pm := range paymentMethods {
 		responses = append(responses, dto.PaymentMethodResponse{
-			ID:   pm.ID,
-			Name: pm.Name,
+			ID:     pm.ID,
+			Name:   pm.Name,
+			IsCash: pm.IsCash,
 		})
 	}
 
@@ -186,30 +649,336 @@ func (uc *orderUseCase) GetAvailableChairs(ctx context.Context) ([]dto.TableResp
 	return responses, nil
 }
 
+// findRegroupOrder memastikan order boleh di-split/merge: masih bisa diubah dan belum dibayar
+func (uc *orderUseCase) findRegroupOrder(ctx context.Context, id uint) (*entity.Order, error) {
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+	if !order.IsEditable() {
+		uc.logger.Warn("Order cannot be split or merged", zap.Uint("id", id), zap.String("status", order.Status))
+		return nil, fmt.Errorf("%w: order %d berstatus %s", repository.ErrOrderNotEditable, id, order.Status)
+	}
+	if order.PaidAmount > 0 {
+		uc.logger.Warn("Order with payments cannot be split or merged", zap.Uint("id", id))
+		return nil, fmt.Errorf("%w: order %d sudah menerima pembayaran", repository.ErrOrderNotEditable, id)
+	}
+	return order, nil
+}
+
+// findOrderResponses mengambil beberapa order lengkap dengan relasinya sesuai urutan ids
+func (uc *orderUseCase) findOrderResponses(ctx context.Context, ids []uint) ([]dto.OrderResponse, error) {
+	responses := make([]dto.OrderResponse, 0, len(ids))
+	for _, id := range ids {
+		order, err := uc.orderRepo.FindByID(ctx, id)
+		if err != nil {
+			uc.logger.Error("Failed to find order", zap.Uint("id", id), zap.Error(err))
+			return nil, err
+		}
+		responses = append(responses, uc.toOrderResponse(*order))
+	}
+	return responses, nil
+}
+
+// priceApprover mengembalikan userID (user login) sebagai penyetuju override harga jika role-nya
+// manager, atau 0 sehingga override harga ditolak repository
+func (uc *orderUseCase) priceApprover(ctx context.Context, userID uint) (uint, error) {
+	if userID == 0 {
+		return 0, nil
+	}
+
+	isManager, err := uc.isManager(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !isManager {
+		return 0, nil
+	}
+	return userID, nil
+}
+
+// requireManager memastikan void/refund disetujui user login dengan role manager
+func (uc *orderUseCase) requireManager(ctx context.Context, approvedBy uint) error {
+	if approvedBy == 0 {
+		return fmt.Errorf("%w: user tidak terautentikasi", ErrManagerApprovalRequired)
+	}
+	isManager, err := uc.isManager(ctx, approvedBy)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		uc.logger.Warn("Approver is not a manager", zap.Uint("approved_by", approvedBy))
+		return fmt.Errorf("%w: user %d bukan manager", ErrManagerApprovalRequired, approvedBy)
+	}
+	return nil
+}
+
+// isManager mengecek apakah user memiliki role manager
+func (uc *orderUseCase) isManager(ctx context.Context, userID uint) (bool, error) {
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		uc.logger.Error("Failed to get approver", zap.Uint("approved_by", userID), zap.Error(err))
+		return false, err
+	}
+	return user != nil && managerRoles[user.Role], nil
+}
+
 func (uc *orderUseCase) toOrderResponse(order entity.Order) dto.OrderResponse {
 	var items []dto.OrderItemResponse
 	for _, item := range order.Items {
 		items = append(items, dto.OrderItemResponse{
-			ID:        item.ID,
-			OrderID:   item.OrderID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
-			Subtotal:  item.Subtotal,
+			ID:              item.ID,
+			OrderID:         item.OrderID,
+			ProductID:       item.ProductID,
+			VariantID:       item.VariantID,
+			VariantName:     item.VariantName,
+			Quantity:        item.Quantity,
+			Price:           item.Price,
+			ListPrice:       item.ListPrice,
+			IsPriceOverride: item.IsPriceOverride,
+			PriceApprovedBy: item.PriceApprovedBy,
+			Subtotal:        item.Subtotal,
+			DiscountAmount:  item.DiscountAmount,
+			PromotionID:     item.PromotionID,
+			ModifierAmount:  item.ModifierAmount,
+			Notes:           item.Notes,
+			Modifiers:       toOrderItemModifierResponses(item.Modifiers),
+			Components:      toOrderItemComponentResponses(item.Components),
+		})
+	}
+
+	taxes := make([]dto.OrderTaxResponse, 0, len(order.Taxes))
+	for _, tax := range order.Taxes {
+		taxes = append(taxes, dto.OrderTaxResponse{
+			Name:        tax.Name,
+			Type:        tax.Type,
+			Rate:        tax.Rate,
+			BaseAmount:  tax.BaseAmount,
+			Amount:      tax.Amount,
+			IsInclusive: tax.IsInclusive,
+		})
+	}
+
+	discounts := make([]dto.OrderDiscountResponse, 0, len(order.Discounts))
+	for _, discount := range order.Discounts {
+		discounts = append(discounts, dto.OrderDiscountResponse{
+			PromotionID: discount.PromotionID,
+			Code:        discount.Code,
+			Name:        discount.Name,
+			Type:        discount.Type,
+			Scope:       discount.Scope,
+			Amount:      discount.Amount,
 		})
 	}
 
 	return dto.OrderResponse{
-		ID:              order.ID,
-		UserID:          order.UserID,
-		TableID:         order.TableID,
-		PaymentMethodID: order.PaymentMethodID,
-		CustomerName:    order.CustomerName,
-		TotalAmount:     order.TotalAmount,
-		Tax:             order.Tax,
-		Status:          order.Status,
-		CreatedAt:       order.CreatedAt,
-		UpdatedAt:       order.UpdatedAt,
+		ID:               order.ID,
+		UserID:           order.UserID,
+		TableID:          order.TableID,
+		OrderType:        order.OrderType,
+		OutletID:         order.OutletID,
+		PaymentMethodID:  order.PaymentMethodID,
+		CustomerName:     order.CustomerName,
+		Subtotal:         order.Subtotal,
+		DiscountAmount:   order.DiscountAmount,
+		PromoCode:        order.PromoCode,
+		ServiceCharge:    order.ServiceCharge,
+		Tax:              order.Tax,
+		PricesIncludeTax: order.PricesIncludeTax,
+		TotalAmount:      order.TotalAmount,
+		PaidAmount:       order.PaidAmount,
+		RefundedAmount:   order.RefundedAmount,
+		ShareIndex:       order.ShareIndex,
+		ShareCount:       order.ShareCount,
+		Status:           order.Status,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+		Items:            items,
+		Taxes:            taxes,
+		Discounts:        discounts,
+		Payments:         toOrderPaymentResponses(order.Payments),
+	}
+}
+
+// toOrderPaymentResponses mengkonversi pembayaran order ke response DTO
+func toOrderPaymentResponses(payments []entity.OrderPayment) []dto.OrderPaymentResponse {
+	responses := make([]dto.OrderPaymentResponse, 0, len(payments))
+	for _, payment := range payments {
+		responses = append(responses, dto.OrderPaymentResponse{
+			ID:              payment.ID,
+			PaymentMethodID: payment.PaymentMethodID,
+			PaymentMethod:   payment.PaymentMethod.Name,
+			Amount:          payment.Amount,
+			Tendered:        payment.Tendered,
+			Change:          payment.Change,
+			Reference:       payment.Reference,
+			ReceivedBy:      payment.ReceivedBy,
+			CreatedAt:       payment.CreatedAt,
+		})
+	}
+	return responses
+}
+
+// toOrderEvent membentuk payload event order; reason diisi untuk pembatalan
+func toOrderEvent(order *entity.Order, reason string) OrderEvent {
+	return OrderEvent{
+		OrderID:      order.ID,
+		CustomerName: order.CustomerName,
+		TableNumber:  order.Table.Number,
+		Status:       order.Status,
+		TotalAmount:  order.TotalAmount,
+		PaidAmount:   order.PaidAmount,
+		Reason:       reason,
+	}
+}
+
+// toOrderRefundResponse mengkonversi void/refund order ke response DTO
+func toOrderRefundResponse(refund entity.OrderRefund) dto.OrderRefundResponse {
+	items := make([]dto.OrderRefundItemResponse, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		items = append(items, dto.OrderRefundItemResponse{
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+		})
+	}
+
+	return dto.OrderRefundResponse{
+		ID:              refund.ID,
+		OrderID:         refund.OrderID,
+		Type:            refund.Type,
+		ReasonCode:      refund.ReasonCode,
+		Note:            refund.Note,
+		Amount:          refund.Amount,
+		PaymentMethodID: refund.PaymentMethodID,
+		Restock:         refund.Restock,
+		ApprovedBy:      refund.ApprovedBy,
+		ProcessedBy:     refund.ProcessedBy,
+		CreatedAt:       refund.CreatedAt,
 		Items:           items,
 	}
 }
+
+// toOrderItemModifierResponses mengubah modifier item order menjadi response
+func toOrderItemModifierResponses(modifiers []entity.OrderItemModifier) []dto.OrderItemModifierResponse {
+	responses := make([]dto.OrderItemModifierResponse, 0, len(modifiers))
+	for _, m := range modifiers {
+		responses = append(responses, dto.OrderItemModifierResponse{
+			ModifierGroupID:  m.ModifierGroupID,
+			ModifierOptionID: m.ModifierOptionID,
+			GroupName:        m.GroupName,
+			OptionName:       m.OptionName,
+			PriceDelta:       m.PriceDelta,
+		})
+	}
+	return responses
+}
+
+func toOrderItemComponentResponses(components []entity.OrderItemComponent) []dto.OrderItemComponentResponse {
+	if len(components) == 0 {
+		return nil
+	}
+	responses := make([]dto.OrderItemComponentResponse, 0, len(components))
+	for _, c := range components {
+		responses = append(responses, dto.OrderItemComponentResponse{
+			BundleSlotID:    c.BundleSlotID,
+			SlotName:        c.SlotName,
+			ProductID:       c.ProductID,
+			VariantID:       c.VariantID,
+			ProductName:     c.ProductName,
+			Quantity:        c.Quantity,
+			AllocationRatio: c.AllocationRatio,
+		})
+	}
+	return responses
+}
+
+// toReceipt menyusun data receipt dari order dan pengaturan receipt outlet
+func toReceipt(order *entity.Order, outlet *entity.Outlet, productNames map[uint]string) receipt.Receipt {
+	r := receipt.Receipt{
+		OutletName:     outlet.Name,
+		OutletAddress:  outlet.Address,
+		OutletPhone:    outlet.Phone,
+		Header:         outlet.ReceiptHeader,
+		Footer:         outlet.ReceiptFooter,
+		PaperWidth:     outlet.ReceiptWidth,
+		OrderID:        order.ID,
+		OrderType:      order.OrderType,
+		TableNumber:    order.Table.Number,
+		CustomerName:   order.CustomerName,
+		Status:         order.Status,
+		CreatedAt:      order.CreatedAt,
+		Subtotal:       order.Subtotal,
+		DiscountAmount: order.DiscountAmount,
+		TotalAmount:    order.TotalAmount,
+		PaidAmount:     order.PaidAmount,
+		RefundedAmount: order.RefundedAmount,
+	}
+
+	for _, item := range order.Items {
+		name := productNames[item.ProductID]
+		if name == "" {
+			name = fmt.Sprintf("Produk #%d", item.ProductID)
+		}
+		if item.VariantName != "" {
+			name += " - " + item.VariantName
+		}
+		var modifiers []receipt.Modifier
+		for _, m := range item.Modifiers {
+			modifiers = append(modifiers, receipt.Modifier{Name: m.OptionName, PriceDelta: m.PriceDelta})
+		}
+		var components []string
+		for _, c := range item.Components {
+			components = append(components, fmt.Sprintf("%dx %s", c.Quantity, c.ProductName))
+		}
+		r.Items = append(r.Items, receipt.Item{
+			Name:           name,
+			Quantity:       item.Quantity,
+			Price:          item.Price,
+			Subtotal:       item.Subtotal,
+			DiscountAmount: item.DiscountAmount,
+			Modifiers:      modifiers,
+			Components:     components,
+			Notes:          item.Notes,
+		})
+	}
+	if order.ShareCount > 0 {
+		// Bagian split rata tidak punya item, cetak sebagai satu baris tagihan
+		r.Items = append(r.Items, receipt.Item{
+			Name:     fmt.Sprintf("Split bill bagian %d/%d", order.ShareIndex, order.ShareCount),
+			Quantity: 1,
+			Price:    order.Subtotal,
+			Subtotal: order.Subtotal,
+		})
+	}
+
+	for _, tax := range order.Taxes {
+		r.Taxes = append(r.Taxes, receipt.TaxLine{
+			Name:        tax.Name,
+			Rate:        tax.Rate,
+			Amount:      tax.Amount,
+			IsInclusive: tax.IsInclusive,
+		})
+	}
+
+	for _, payment := range order.Payments {
+		r.Payments = append(r.Payments, receipt.Payment{
+			Method:   payment.PaymentMethod.Name,
+			Amount:   payment.Amount,
+			Tendered: payment.Tendered,
+			Change:   payment.Change,
+		})
+		r.Change += payment.Change
+	}
+	if len(order.Payments) == 0 && order.PaymentMethod.Name != "" {
+		// Order lama tanpa catatan pembayaran: tampilkan metode pembayaran order
+		r.Payments = append(r.Payments, receipt.Payment{
+			Method: order.PaymentMethod.Name,
+			Amount: order.PaidAmount,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{entity.OrderStatusPending, entity.OrderStatusInKitchen, true},
+		{entity.OrderStatusPending, entity.OrderStatusPaid, true},
+		{entity.OrderStatusInKitchen, entity.OrderStatusServed, true},
+		{entity.OrderStatusInKitchen, entity.OrderStatusPaid, true},
+		{entity.OrderStatusServed, entity.OrderStatusPaid, true},
+		{entity.OrderStatusPaid, entity.OrderStatusRefunded, true},
+
+		{entity.OrderStatusPending, entity.OrderStatusServed, false},
+		{entity.OrderStatusPending, entity.OrderStatusRefunded, false},
+		{entity.OrderStatusPending, entity.OrderStatusPending, false},
+		{entity.OrderStatusInKitchen, entity.OrderStatusPending, false},
+		{entity.OrderStatusServed, entity.OrderStatusInKitchen, false},
+		{entity.OrderStatusPaid, entity.OrderStatusPending, false},
+		{entity.OrderStatusPaid, entity.OrderStatusServed, false},
+		{entity.OrderStatusRefunded, entity.OrderStatusPaid, false},
+		{entity.OrderStatusCancelled, entity.OrderStatusPending, false},
+		{entity.OrderStatusPending, entity.OrderStatusCancelled, false},
+		{"unknown", entity.OrderStatusPending, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("canTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+// statusOrderRepository adalah OrderRepository palsu yang hanya mendukung FindByID dan UpdateStatus
+type statusOrderRepository struct {
+	repository.OrderRepository
+	order   *entity.Order
+	updated []string
+}
+
+func (r *statusOrderRepository) FindByID(ctx context.Context, id uint) (*entity.Order, error) {
+	return r.order, nil
+}
+
+func (r *statusOrderRepository) UpdateStatus(ctx context.Context, id uint, fromStatus, toStatus string, changedBy uint, note string) error {
+	r.updated = append(r.updated, fromStatus+"->"+toStatus)
+	return nil
+}
+
+func TestChangeOrderStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "pending ke in_kitchen", from: entity.OrderStatusPending, to: entity.OrderStatusInKitchen},
+		{name: "in_kitchen ke served", from: entity.OrderStatusInKitchen, to: entity.OrderStatusServed},
+		{name: "pending langsung ke served", from: entity.OrderStatusPending, to: entity.OrderStatusServed, wantErr: true},
+		{name: "served kembali ke in_kitchen", from: entity.OrderStatusServed, to: entity.OrderStatusInKitchen, wantErr: true},
+		{name: "paid kembali ke pending", from: entity.OrderStatusPaid, to: entity.OrderStatusPending, wantErr: true},
+		{name: "cancelled tidak bisa dibuka lagi", from: entity.OrderStatusCancelled, to: entity.OrderStatusPending, wantErr: true},
+		{name: "paid hanya lewat pembayaran", from: entity.OrderStatusServed, to: entity.OrderStatusPaid, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &statusOrderRepository{order: &entity.Order{ID: 1, Status: tt.from}}
+			uc := NewOrderUseCase(repo, nil, nil, nil, nil, zap.NewNop())
+
+			err := uc.ChangeOrderStatus(context.Background(), 1, dto.OrderStatusUpdateRequest{Status: tt.to})
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStatusTransition) {
+					t.Fatalf("ChangeOrderStatus() error = %v, want %v", err, ErrInvalidStatusTransition)
+				}
+				if len(repo.updated) != 0 {
+					t.Errorf("UpdateStatus called %v, want no update", repo.updated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ChangeOrderStatus() unexpected error: %v", err)
+			}
+			if want := tt.from + "->" + tt.to; len(repo.updated) != 1 || repo.updated[0] != want {
+				t.Errorf("UpdateStatus calls = %v, want [%s]", repo.updated, want)
+			}
+		})
+	}
+}
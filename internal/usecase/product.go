@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"aplikasi-pos-team-boolean/internal/data/entity"
@@ -16,6 +17,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrInvalidBundle dikembalikan jika konfigurasi komponen bundle tidak valid
+var ErrInvalidBundle = errors.New("invalid bundle")
+
 type ProductUseCase interface {
 	GetListProduct(ctx context.Context, req dto.ProductFilterRequest) ([]dto.ProductListResponse, dto.Pagination, error)
 	GetProductByID(ctx context.Context, id uint) (*dto.ProductResponse, error)
@@ -136,10 +140,19 @@ func (s *productUseCase) CreateProduct(ctx context.Context, req dto.ProductCreat
 		ProductName:  req.ProductName,
 		ItemID:       itemID,
 		Stock:        req.Stock,
+		MinStock:     inventoryMinStock(req.MinStock, defaultMinStock),
 		CategoryID:   req.CategoryID,
 		Price:        req.Price,
 	}
 
+	// Varian menentukan harga dan stok produk
+	if err := s.applyVariants(ctx, product, req.Variants); err != nil {
+		return nil, err
+	}
+	if err := s.applyBundle(ctx, product, req.Type, req.BundleSlots); err != nil {
+		return nil, err
+	}
+
 	// Save to database
 	if err := s.productRepo.Create(ctx, product); err != nil {
 		s.logger.Error("Failed to create product",
@@ -189,15 +202,30 @@ func (s *productUseCase) UpdateProduct(ctx context.Context, id uint, req dto.Pro
 		product.Category = *category
 	}
 
+	// Stok yang dibaca sekarang; repository menyimpan stok request sebagai selisih terhadap nilai ini
+	stockBase := repository.ProductStockBase{Stock: product.Stock, Variants: make(map[uint]int, len(product.Variants))}
+	for _, v := range product.Variants {
+		stockBase.Variants[v.ID] = v.Stock
+	}
+
 	// Update product data
 	product.ProductImage = req.ProductImage
 	product.ProductName = req.ProductName
 	product.Stock = req.Stock
+	product.MinStock = inventoryMinStock(req.MinStock, product.MinStock)
 	product.CategoryID = req.CategoryID
 	product.Price = req.Price
 
+	// Varian menentukan harga dan stok produk
+	if err := s.applyVariants(ctx, product, req.Variants); err != nil {
+		return nil, err
+	}
+	if err := s.applyBundle(ctx, product, req.Type, req.BundleSlots); err != nil {
+		return nil, err
+	}
+
 	// Save to database
-	if err := s.productRepo.Update(ctx, product); err != nil {
+	if err := s.productRepo.Update(ctx, product, stockBase); err != nil {
 		s.logger.Error("Failed to update product",
 			zap.Uint("id", id),
 			zap.Error(err),
@@ -248,8 +276,218 @@ func (s *productUseCase) generateItemID() string {
 	return fmt.Sprintf("#%08d", rand.Intn(100000000))
 }
 
-// toProductListResponse converts entity to list response DTO
+// applyVariants mengganti varian produk dengan isi request lalu menyamakan harga dan stok produk.
+// Varian lama dicocokkan lewat ID; SKU kosong diisi otomatis dari item_id (contoh: #22314644-01).
+func (s *productUseCase) applyVariants(ctx context.Context, product *entity.Product, reqs []dto.ProductVariantRequest) error {
+	existing := make(map[uint]entity.ProductVariant, len(product.Variants))
+	for _, v := range product.Variants {
+		existing[v.ID] = v
+	}
+
+	variants := make([]entity.ProductVariant, 0, len(reqs))
+	usedSKU := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		variant := entity.ProductVariant{
+			ID:    req.ID,
+			SKU:   strings.TrimSpace(req.SKU),
+			Name:  strings.TrimSpace(req.Name),
+			Price: req.Price,
+			Stock: req.Stock,
+		}
+		if req.ID != 0 {
+			old, ok := existing[req.ID]
+			if !ok {
+				return errors.New("variant not found")
+			}
+			if variant.SKU == "" {
+				variant.SKU = old.SKU
+			}
+		}
+		if variant.SKU == "" {
+			continue
+		}
+		if usedSKU[variant.SKU] {
+			return errors.New("duplicate variant sku")
+		}
+		usedSKU[variant.SKU] = true
+		variants = append(variants, variant)
+	}
+
+	// Varian baru tanpa SKU mendapat nomor urut berikutnya yang belum dipakai
+	seq := 0
+	for _, req := range reqs {
+		if req.ID != 0 || strings.TrimSpace(req.SKU) != "" {
+			continue
+		}
+		variant := entity.ProductVariant{Name: strings.TrimSpace(req.Name), Price: req.Price, Stock: req.Stock}
+		for variant.SKU == "" || usedSKU[variant.SKU] {
+			seq++
+			variant.SKU = fmt.Sprintf("%s-%02d", product.ItemID, seq)
+		}
+		usedSKU[variant.SKU] = true
+		variants = append(variants, variant)
+	}
+
+	for _, variant := range variants {
+		exists, err := s.productRepo.VariantSKUExists(ctx, variant.SKU, variant.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("variant sku already exists")
+		}
+	}
+
+	if len(variants) == 0 && product.Price <= 0 {
+		return errors.New("price is required")
+	}
+
+	product.Variants = variants
+	product.ApplyVariantTotals()
+	return nil
+}
+
+// applyBundle mengatur tipe produk dan mengganti slot komponen bundle dengan isi request.
+// Tipe kosong berarti tetap memakai tipe produk sekarang (default single). Komponen harus produk
+// single yang ada; produk komponen yang punya varian wajib memilih variannya.
+func (s *productUseCase) applyBundle(ctx context.Context, product *entity.Product, productType string, reqs []dto.BundleSlotRequest) error {
+	if productType == "" {
+		productType = product.Type
+	}
+	if productType == "" {
+		productType = entity.ProductTypeSingle
+	}
+	product.Type = productType
+
+	if !product.IsBundle() {
+		if len(reqs) > 0 {
+			return fmt.Errorf("%w: bundle_slots only allowed for bundle products", ErrInvalidBundle)
+		}
+		product.BundleSlots = nil
+		return nil
+	}
+	if len(product.Variants) > 0 {
+		return fmt.Errorf("%w: bundle products cannot have variants", ErrInvalidBundle)
+	}
+	if len(reqs) == 0 {
+		return fmt.Errorf("%w: bundle requires at least one slot", ErrInvalidBundle)
+	}
+
+	components := make(map[uint]*entity.Product)
+	slots := make([]entity.BundleSlot, 0, len(reqs))
+	for _, req := range reqs {
+		slot := entity.BundleSlot{Name: strings.TrimSpace(req.Name), Quantity: req.Quantity}
+		if slot.Quantity < 1 {
+			slot.Quantity = 1
+		}
+
+		seen := make(map[string]bool, len(req.Options))
+		for _, optionReq := range req.Options {
+			component, ok := components[optionReq.ProductID]
+			if !ok {
+				found, err := s.productRepo.Detail(ctx, optionReq.ProductID)
+				if err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return fmt.Errorf("%w: component product %d not found", ErrInvalidBundle, optionReq.ProductID)
+					}
+					return err
+				}
+				component = found
+				components[optionReq.ProductID] = component
+			}
+			if component.ID == product.ID || component.IsBundle() {
+				return fmt.Errorf("%w: component %s must be a single product", ErrInvalidBundle, component.ProductName)
+			}
+
+			option := entity.BundleSlotOption{ProductID: component.ID, Product: *component}
+			if optionReq.VariantID != nil {
+				for i := range component.Variants {
+					if component.Variants[i].ID == *optionReq.VariantID {
+						option.VariantID = optionReq.VariantID
+						option.Variant = &component.Variants[i]
+					}
+				}
+				if option.Variant == nil {
+					return fmt.Errorf("%w: variant %d does not belong to %s", ErrInvalidBundle, *optionReq.VariantID, component.ProductName)
+				}
+			} else if len(component.Variants) > 0 {
+				return fmt.Errorf("%w: component %s requires variant_id", ErrInvalidBundle, component.ProductName)
+			}
+
+			key := fmt.Sprint(option.ProductID)
+			if option.VariantID != nil {
+				key += fmt.Sprintf("-%d", *option.VariantID)
+			}
+			if seen[key] {
+				return fmt.Errorf("%w: duplicate option %s in slot %s", ErrInvalidBundle, option.DisplayName(), slot.Name)
+			}
+			seen[key] = true
+			slot.Options = append(slot.Options, option)
+		}
+		slots = append(slots, slot)
+	}
+
+	product.BundleSlots = slots
+	product.Stock = 0
+	return nil
+}
+
+// toProductVariantResponses converts variant entities to response DTO (varian produk beresep
+// tidak mensyaratkan stock > 0)
+func (s *productUseCase) toProductVariantResponses(variants []entity.ProductVariant, hasRecipe bool) []dto.ProductVariantResponse {
+	responses := make([]dto.ProductVariantResponse, 0, len(variants))
+	for _, v := range variants {
+		availability := "out_of_stock"
+		if v.IsAvailable && (v.Stock > 0 || hasRecipe) {
+			availability = "in_stock"
+		}
+		responses = append(responses, dto.ProductVariantResponse{
+			ID:           v.ID,
+			SKU:          v.SKU,
+			Name:         v.Name,
+			Price:        v.Price,
+			Stock:        v.Stock,
+			IsAvailable:  v.IsAvailable,
+			Availability: availability,
+		})
+	}
+	return responses
+}
+
+// toBundleSlotResponses converts bundle slot entities to response DTO
+func (s *productUseCase) toBundleSlotResponses(slots []entity.BundleSlot) []dto.BundleSlotResponse {
+	responses := make([]dto.BundleSlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		options := make([]dto.BundleSlotOptionResponse, 0, len(slot.Options))
+		for _, option := range slot.Options {
+			price := option.Product.Price
+			if option.Variant != nil {
+				price = option.Variant.Price
+			}
+			options = append(options, dto.BundleSlotOptionResponse{
+				ID:          option.ID,
+				ProductID:   option.ProductID,
+				VariantID:   option.VariantID,
+				ProductName: option.DisplayName(),
+				Price:       price,
+				Stock:       option.AvailableStock(),
+			})
+		}
+		responses = append(responses, dto.BundleSlotResponse{
+			ID:       slot.ID,
+			Name:     slot.Name,
+			Quantity: slot.Quantity,
+			Options:  options,
+		})
+	}
+	return responses
+}
+
+// toProductListResponse converts entity to list response DTO (stok bundle dihitung dari komponennya,
+// ketersediaan juga memperhitungkan bahan resep)
 func (s *productUseCase) toProductListResponse(product *entity.Product) dto.ProductListResponse {
+	product.ApplyBundleStock()
+	product.ApplyRecipeAvailability()
 	return dto.ProductListResponse{
 		ID:           product.ID,
 		ProductImage: product.ProductImage,
@@ -260,22 +498,32 @@ func (s *productUseCase) toProductListResponse(product *entity.Product) dto.Prod
 		Price:        product.Price,
 		IsAvailable:  product.IsAvailable,
 		Availability: product.GetAvailabilityStatus(),
+		Variants:     s.toProductVariantResponses(product.Variants, product.HasRecipe()),
+		Type:         product.Type,
+		BundleSlots:  s.toBundleSlotResponses(product.BundleSlots),
 	}
 }
 
-// toProductResponse converts entity to response DTO
+// toProductResponse converts entity to response DTO (stok bundle dihitung dari komponennya,
+// ketersediaan juga memperhitungkan bahan resep)
 func (s *productUseCase) toProductResponse(product *entity.Product) dto.ProductResponse {
+	product.ApplyBundleStock()
+	product.ApplyRecipeAvailability()
 	return dto.ProductResponse{
 		ID:           product.ID,
 		ProductImage: product.ProductImage,
 		ProductName:  product.ProductName,
 		ItemID:       product.ItemID,
 		Stock:        product.Stock,
+		MinStock:     product.MinStock,
 		CategoryID:   product.CategoryID,
 		CategoryName: product.Category.CategoryName,
 		Price:        product.Price,
 		IsAvailable:  product.IsAvailable,
 		Availability: product.GetAvailabilityStatus(),
+		Variants:     s.toProductVariantResponses(product.Variants, product.HasRecipe()),
+		Type:         product.Type,
+		BundleSlots:  s.toBundleSlotResponses(product.BundleSlots),
 		CreatedAt:    product.CreatedAt.Format("2006-01-02 15:04:05"),
 		UpdatedAt:    product.UpdatedAt.Format("2006-01-02 15:04:05"),
 	}
@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPromotionNotFound dikembalikan jika promo tidak ada atau sudah dihapus
+	ErrPromotionNotFound = errors.New("promo tidak ditemukan")
+	// ErrPromoCodeExists dikembalikan jika kode promo sudah dipakai promo lain
+	ErrPromoCodeExists = errors.New("kode promo sudah digunakan")
+	// ErrInvalidPromotion dikembalikan jika kombinasi field promo tidak valid
+	ErrInvalidPromotion = errors.New("data promo tidak valid")
+	// ErrPromotionTargetNotFound dikembalikan jika produk/kategori tujuan promo tidak ada
+	ErrPromotionTargetNotFound = errors.New("produk atau kategori promo tidak ditemukan")
+)
+
+type PromotionUseCase interface {
+	GetAllPromotions(ctx context.Context, activeOnly bool) ([]dto.PromotionResponse, error)
+	GetPromotionByID(ctx context.Context, id uint) (*dto.PromotionResponse, error)
+	CreatePromotion(ctx context.Context, req dto.PromotionRequest) (*dto.PromotionResponse, error)
+	UpdatePromotion(ctx context.Context, id uint, req dto.PromotionRequest) (*dto.PromotionResponse, error)
+	DeletePromotion(ctx context.Context, id uint) error
+}
+
+type promotionUseCase struct {
+	promotionRepo repository.PromotionRepository
+	productRepo   repository.ProductRepository
+	categoryRepo  repository.CategoryRepository
+	logger        *zap.Logger
+}
+
+func NewPromotionUseCase(
+	promotionRepo repository.PromotionRepository,
+	productRepo repository.ProductRepository,
+	categoryRepo repository.CategoryRepository,
+	logger *zap.Logger,
+) PromotionUseCase {
+	return &promotionUseCase{
+		promotionRepo: promotionRepo,
+		productRepo:   productRepo,
+		categoryRepo:  categoryRepo,
+		logger:        logger,
+	}
+}
+
+func (uc *promotionUseCase) GetAllPromotions(ctx context.Context, activeOnly bool) ([]dto.PromotionResponse, error) {
+	promotions, err := uc.promotionRepo.FindAll(ctx, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]dto.PromotionResponse, 0, len(promotions))
+	for i := range promotions {
+		responses = append(responses, toPromotionResponse(&promotions[i], now))
+	}
+	return responses, nil
+}
+
+func (uc *promotionUseCase) GetPromotionByID(ctx context.Context, id uint) (*dto.PromotionResponse, error) {
+	promotion, err := uc.findPromotion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPromotionResponse(promotion, time.Now())
+	return &response, nil
+}
+
+func (uc *promotionUseCase) CreatePromotion(ctx context.Context, req dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	uc.logger.Info("Creating promotion",
+		zap.String("name", req.Name),
+		zap.String("type", req.Type),
+		zap.String("scope", req.Scope))
+
+	if err := uc.validatePromotion(ctx, 0, req); err != nil {
+		return nil, err
+	}
+
+	promotion := &entity.Promotion{}
+	applyPromotionRequest(promotion, req)
+	if err := uc.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	response := toPromotionResponse(promotion, time.Now())
+	return &response, nil
+}
+
+func (uc *promotionUseCase) UpdatePromotion(ctx context.Context, id uint, req dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	uc.logger.Info("Updating promotion", zap.Uint("id", id))
+
+	promotion, err := uc.findPromotion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.validatePromotion(ctx, id, req); err != nil {
+		return nil, err
+	}
+
+	applyPromotionRequest(promotion, req)
+	if err := uc.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	response := toPromotionResponse(promotion, time.Now())
+	return &response, nil
+}
+
+func (uc *promotionUseCase) DeletePromotion(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting promotion", zap.Uint("id", id))
+
+	if _, err := uc.findPromotion(ctx, id); err != nil {
+		return err
+	}
+	return uc.promotionRepo.Delete(ctx, id)
+}
+
+// validatePromotion memastikan kombinasi tipe/cakupan valid, produk/kategori tujuan ada,
+// jam happy hour berformat HH:MM, dan kode promo belum dipakai promo lain
+func (uc *promotionUseCase) validatePromotion(ctx context.Context, id uint, req dto.PromotionRequest) error {
+	switch req.Scope {
+	case entity.PromotionScopeProduct:
+		if req.ProductID == nil {
+			return fmt.Errorf("%w: product_id wajib diisi untuk scope product", ErrInvalidPromotion)
+		}
+		if _, err := uc.productRepo.Detail(ctx, *req.ProductID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: product_id %d", ErrPromotionTargetNotFound, *req.ProductID)
+			}
+			return err
+		}
+	case entity.PromotionScopeCategory:
+		if req.CategoryID == nil {
+			return fmt.Errorf("%w: category_id wajib diisi untuk scope category", ErrInvalidPromotion)
+		}
+		if _, err := uc.categoryRepo.Detail(ctx, *req.CategoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: category_id %d", ErrPromotionTargetNotFound, *req.CategoryID)
+			}
+			return err
+		}
+	}
+
+	switch req.Type {
+	case entity.PromotionTypePercentage:
+		if req.Value <= 0 || req.Value > 100 {
+			return fmt.Errorf("%w: value persentase harus di antara 0 dan 100", ErrInvalidPromotion)
+		}
+	case entity.PromotionTypeFixed:
+		if req.Value <= 0 {
+			return fmt.Errorf("%w: value potongan harus lebih dari 0", ErrInvalidPromotion)
+		}
+	case entity.PromotionTypeBuyXGetY:
+		if req.Scope == entity.PromotionScopeOrder {
+			return fmt.Errorf("%w: buy_x_get_y hanya untuk scope product atau category", ErrInvalidPromotion)
+		}
+		if req.BuyQuantity <= 0 || req.GetQuantity <= 0 {
+			return fmt.Errorf("%w: buy_quantity dan get_quantity wajib diisi", ErrInvalidPromotion)
+		}
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return fmt.Errorf("%w: ends_at harus setelah starts_at", ErrInvalidPromotion)
+	}
+	if (req.DailyStart == "") != (req.DailyEnd == "") {
+		return fmt.Errorf("%w: daily_start dan daily_end harus diisi bersamaan", ErrInvalidPromotion)
+	}
+	for _, clock := range []string{req.DailyStart, req.DailyEnd} {
+		if clock == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", clock); err != nil {
+			return fmt.Errorf("%w: format jam %q harus HH:MM", ErrInvalidPromotion, clock)
+		}
+	}
+	for _, day := range req.DaysOfWeek {
+		if day < 0 || day > 6 {
+			return fmt.Errorf("%w: days_of_week harus 0 (Minggu) sampai 6 (Sabtu)", ErrInvalidPromotion)
+		}
+	}
+
+	if code := strings.ToUpper(strings.TrimSpace(req.Code)); code != "" {
+		existing, err := uc.promotionRepo.FindByCode(ctx, code)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if existing != nil && existing.ID != id {
+			return fmt.Errorf("%w: %s", ErrPromoCodeExists, code)
+		}
+	}
+
+	return nil
+}
+
+// findPromotion mengambil promo dan mengubah record not found menjadi ErrPromotionNotFound
+func (uc *promotionUseCase) findPromotion(ctx context.Context, id uint) (*entity.Promotion, error) {
+	promotion, err := uc.promotionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: promotion_id %d", ErrPromotionNotFound, id)
+		}
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// applyPromotionRequest menyalin isi request ke entity promo
+func applyPromotionRequest(promotion *entity.Promotion, req dto.PromotionRequest) {
+	promotion.Name = req.Name
+	promotion.Code = nil
+	if code := strings.ToUpper(strings.TrimSpace(req.Code)); code != "" {
+		promotion.Code = &code
+	}
+	promotion.Type = req.Type
+	promotion.Scope = req.Scope
+	promotion.CategoryID = nil
+	promotion.ProductID = nil
+	switch req.Scope {
+	case entity.PromotionScopeCategory:
+		promotion.CategoryID = req.CategoryID
+	case entity.PromotionScopeProduct:
+		promotion.ProductID = req.ProductID
+	}
+	promotion.Value = req.Value
+	promotion.BuyQuantity = req.BuyQuantity
+	promotion.GetQuantity = req.GetQuantity
+	promotion.MinOrderAmount = req.MinOrderAmount
+	promotion.MaxDiscount = req.MaxDiscount
+	promotion.StartsAt = req.StartsAt
+	promotion.EndsAt = req.EndsAt
+	promotion.DailyStart = req.DailyStart
+	promotion.DailyEnd = req.DailyEnd
+	promotion.UsageLimit = req.UsageLimit
+	promotion.IsActive = req.IsActive == nil || *req.IsActive
+
+	days := append([]int(nil), req.DaysOfWeek...)
+	sort.Ints(days)
+	parts := make([]string, 0, len(days))
+	for i, day := range days {
+		if i > 0 && days[i-1] == day {
+			continue
+		}
+		parts = append(parts, strconv.Itoa(day))
+	}
+	promotion.DaysOfWeek = strings.Join(parts, ",")
+}
+
+// toPromotionResponse mengkonversi entity promo ke response DTO
+func toPromotionResponse(promotion *entity.Promotion, now time.Time) dto.PromotionResponse {
+	response := dto.PromotionResponse{
+		ID:             promotion.ID,
+		Name:           promotion.Name,
+		Type:           promotion.Type,
+		Scope:          promotion.Scope,
+		CategoryID:     promotion.CategoryID,
+		ProductID:      promotion.ProductID,
+		Value:          promotion.Value,
+		BuyQuantity:    promotion.BuyQuantity,
+		GetQuantity:    promotion.GetQuantity,
+		MinOrderAmount: promotion.MinOrderAmount,
+		MaxDiscount:    promotion.MaxDiscount,
+		StartsAt:       promotion.StartsAt,
+		EndsAt:         promotion.EndsAt,
+		DailyStart:     promotion.DailyStart,
+		DailyEnd:       promotion.DailyEnd,
+		UsageLimit:     promotion.UsageLimit,
+		UsageCount:     promotion.UsageCount,
+		IsActive:       promotion.IsActive,
+		IsRunningNow:   promotion.IsActiveAt(now),
+		CreatedAt:      promotion.CreatedAt,
+		UpdatedAt:      promotion.UpdatedAt,
+	}
+	if promotion.Code != nil {
+		response.Code = *promotion.Code
+	}
+	for _, d := range strings.Split(promotion.DaysOfWeek, ",") {
+		if day, err := strconv.Atoi(strings.TrimSpace(d)); err == nil {
+			response.DaysOfWeek = append(response.DaysOfWeek, day)
+		}
+	}
+	return response
+}
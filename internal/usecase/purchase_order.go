@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPurchaseOrderNotFound dikembalikan jika purchase order tidak ada
+	ErrPurchaseOrderNotFound = errors.New("purchase order tidak ditemukan")
+	// ErrInvalidPurchaseOrder dikembalikan jika supplier atau item purchase order tidak valid
+	ErrInvalidPurchaseOrder = errors.New("purchase order tidak valid")
+)
+
+// reorderTargetMultiplier menentukan target stok saat pemesanan ulang (kelipatan MinStock)
+const reorderTargetMultiplier = 2
+
+type PurchaseOrderUseCase interface {
+	GetAllPurchaseOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]dto.PurchaseOrderResponse, error)
+	GetPurchaseOrderByID(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error)
+	CreatePurchaseOrder(ctx context.Context, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error)
+	UpdatePurchaseOrder(ctx context.Context, id uint, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error)
+	DeletePurchaseOrder(ctx context.Context, id uint) error
+	SendPurchaseOrder(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error)
+	CancelPurchaseOrder(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, id uint, req dto.GoodsReceiptRequest) (*dto.PurchaseOrderResponse, error)
+	GetReorderSuggestions(ctx context.Context) ([]dto.ReorderSuggestionResponse, error)
+}
+
+type purchaseOrderUseCase struct {
+	purchaseOrderRepo repository.PurchaseOrderRepository
+	supplierRepo      repository.SupplierRepository
+	inventoriesRepo   repository.InventoriesRepository
+	logger            *zap.Logger
+}
+
+func NewPurchaseOrderUseCase(
+	purchaseOrderRepo repository.PurchaseOrderRepository,
+	supplierRepo repository.SupplierRepository,
+	inventoriesRepo repository.InventoriesRepository,
+	logger *zap.Logger,
+) PurchaseOrderUseCase {
+	return &purchaseOrderUseCase{
+		purchaseOrderRepo: purchaseOrderRepo,
+		supplierRepo:      supplierRepo,
+		inventoriesRepo:   inventoriesRepo,
+		logger:            logger,
+	}
+}
+
+func (uc *purchaseOrderUseCase) GetAllPurchaseOrders(ctx context.Context, filter dto.PurchaseOrderFilter) ([]dto.PurchaseOrderResponse, error) {
+	orders, err := uc.purchaseOrderRepo.FindAll(ctx, repository.PurchaseOrderFilter{
+		Status:     filter.Status,
+		SupplierID: filter.SupplierID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.PurchaseOrderResponse, 0, len(orders))
+	for i := range orders {
+		responses = append(responses, toPurchaseOrderResponse(&orders[i]))
+	}
+	return responses, nil
+}
+
+func (uc *purchaseOrderUseCase) GetPurchaseOrderByID(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error) {
+	po, err := uc.purchaseOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+
+	response := toPurchaseOrderResponse(po)
+	return &response, nil
+}
+
+func (uc *purchaseOrderUseCase) CreatePurchaseOrder(ctx context.Context, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error) {
+	uc.logger.Info("Creating purchase order", zap.Uint("supplier_id", req.SupplierID), zap.Int("items", len(req.Items)))
+
+	po := &entity.PurchaseOrder{Status: entity.PurchaseOrderStatusDraft}
+	if req.CreatedBy != 0 {
+		createdBy := req.CreatedBy
+		po.CreatedBy = &createdBy
+	}
+	if err := uc.applyPurchaseOrderRequest(ctx, po, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.Create(ctx, po); err != nil {
+		return nil, err
+	}
+	return uc.GetPurchaseOrderByID(ctx, po.ID)
+}
+
+// UpdatePurchaseOrder mengganti isi purchase order yang masih draft
+func (uc *purchaseOrderUseCase) UpdatePurchaseOrder(ctx context.Context, id uint, req dto.PurchaseOrderRequest) (*dto.PurchaseOrderResponse, error) {
+	uc.logger.Info("Updating purchase order", zap.Uint("id", id))
+
+	po := &entity.PurchaseOrder{ID: id}
+	if err := uc.applyPurchaseOrderRequest(ctx, po, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.UpdateDraft(ctx, po); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetPurchaseOrderByID(ctx, id)
+}
+
+func (uc *purchaseOrderUseCase) DeletePurchaseOrder(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting purchase order", zap.Uint("id", id))
+
+	if err := uc.purchaseOrderRepo.Delete(ctx, id); err != nil {
+		return uc.wrapNotFound(err, id)
+	}
+	return nil
+}
+
+// SendPurchaseOrder menandai purchase order draft sudah dikirim ke supplier
+func (uc *purchaseOrderUseCase) SendPurchaseOrder(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error) {
+	uc.logger.Info("Sending purchase order", zap.Uint("id", id))
+
+	if err := uc.purchaseOrderRepo.ChangeStatus(ctx, id,
+		[]string{entity.PurchaseOrderStatusDraft},
+		entity.PurchaseOrderStatusSent); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetPurchaseOrderByID(ctx, id)
+}
+
+// CancelPurchaseOrder membatalkan purchase order yang belum ada barang diterima
+func (uc *purchaseOrderUseCase) CancelPurchaseOrder(ctx context.Context, id uint) (*dto.PurchaseOrderResponse, error) {
+	uc.logger.Info("Cancelling purchase order", zap.Uint("id", id))
+
+	if err := uc.purchaseOrderRepo.ChangeStatus(ctx, id,
+		[]string{entity.PurchaseOrderStatusDraft, entity.PurchaseOrderStatusSent},
+		entity.PurchaseOrderStatusCancelled); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetPurchaseOrderByID(ctx, id)
+}
+
+// ReceivePurchaseOrder mencatat goods received note; quantity inventory bertambah lewat stock
+// movement purchase dengan harga satuan aktual (default harga pesanan)
+func (uc *purchaseOrderUseCase) ReceivePurchaseOrder(ctx context.Context, id uint, req dto.GoodsReceiptRequest) (*dto.PurchaseOrderResponse, error) {
+	uc.logger.Info("Receiving purchase order", zap.Uint("id", id), zap.Int("items", len(req.Items)))
+
+	po, err := uc.purchaseOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	orderedCost := make(map[uint]float64, len(po.Items))
+	for _, item := range po.Items {
+		orderedCost[item.ID] = item.UnitCost
+	}
+
+	receipt := &entity.GoodsReceipt{Note: req.Note}
+	if req.ReceivedBy != 0 {
+		receivedBy := req.ReceivedBy
+		receipt.ReceivedBy = &receivedBy
+	}
+	for _, itemReq := range req.Items {
+		unitCost, ok := orderedCost[itemReq.PurchaseOrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: item %d bukan bagian dari %s", repository.ErrInvalidGoodsReceipt, itemReq.PurchaseOrderItemID, po.PONumber)
+		}
+		if itemReq.UnitCost != nil {
+			unitCost = *itemReq.UnitCost
+		}
+		receipt.Items = append(receipt.Items, entity.GoodsReceiptItem{
+			PurchaseOrderItemID: itemReq.PurchaseOrderItemID,
+			Quantity:            itemReq.Quantity,
+			UnitCost:            unitCost,
+			BatchNumber:         itemReq.BatchNumber,
+			ExpiryDate:          itemReq.ExpiryDate,
+		})
+	}
+
+	if err := uc.purchaseOrderRepo.Receive(ctx, id, receipt); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetPurchaseOrderByID(ctx, id)
+}
+
+// GetReorderSuggestions menyarankan pemesanan ulang untuk inventory aktif di bawah MinStock.
+// Quantity saran mengisi stok sampai reorderTargetMultiplier x MinStock setelah dikurangi barang
+// yang masih dipesan; inventory yang sudah cukup dipesan tidak ditampilkan.
+func (uc *purchaseOrderUseCase) GetReorderSuggestions(ctx context.Context) ([]dto.ReorderSuggestionResponse, error) {
+	candidates, err := uc.purchaseOrderRepo.FindReorderCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]dto.ReorderSuggestionResponse, 0, len(candidates))
+	for _, c := range candidates {
+		target := float64(c.Inventory.MinStock * reorderTargetMultiplier)
+		suggested := math.Ceil(target - c.Inventory.Quantity - c.OnOrder)
+		if suggested <= 0 {
+			continue
+		}
+		suggestions = append(suggestions, dto.ReorderSuggestionResponse{
+			InventoryID:       c.Inventory.ID,
+			InventoryName:     c.Inventory.Name,
+			Unit:              c.Inventory.Unit,
+			Quantity:          c.Inventory.Quantity,
+			MinStock:          c.Inventory.MinStock,
+			OnOrder:           c.OnOrder,
+			SuggestedQuantity: suggested,
+			SupplierID:        c.LastSupplierID,
+			SupplierName:      c.LastSupplierName,
+			LastUnitCost:      c.LastUnitCost,
+			EstimatedCost:     math.Round(suggested*c.LastUnitCost*100) / 100,
+		})
+	}
+	return suggestions, nil
+}
+
+// applyPurchaseOrderRequest memvalidasi supplier dan inventory lalu menyalin request ke purchase order
+func (uc *purchaseOrderUseCase) applyPurchaseOrderRequest(ctx context.Context, po *entity.PurchaseOrder, req dto.PurchaseOrderRequest) error {
+	supplier, err := uc.supplierRepo.FindByID(ctx, req.SupplierID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: supplier_id %d", ErrSupplierNotFound, req.SupplierID)
+		}
+		return err
+	}
+	if !supplier.IsActive {
+		return fmt.Errorf("%w: supplier %s tidak aktif", ErrInvalidPurchaseOrder, supplier.Name)
+	}
+
+	po.SupplierID = supplier.ID
+	po.ExpectedDate = req.ExpectedDate
+	po.Note = req.Note
+	po.TotalAmount = 0
+	po.Items = make([]entity.PurchaseOrderItem, 0, len(req.Items))
+
+	seen := make(map[int64]bool, len(req.Items))
+	for _, itemReq := range req.Items {
+		if seen[itemReq.InventoryID] {
+			return fmt.Errorf("%w: inventory_id %d ditulis lebih dari sekali", ErrInvalidPurchaseOrder, itemReq.InventoryID)
+		}
+		seen[itemReq.InventoryID] = true
+
+		if _, err := uc.inventoriesRepo.FindByID(ctx, itemReq.InventoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: inventory_id %d tidak ditemukan", ErrInvalidPurchaseOrder, itemReq.InventoryID)
+			}
+			return err
+		}
+
+		po.Items = append(po.Items, entity.PurchaseOrderItem{
+			InventoryID: itemReq.InventoryID,
+			Quantity:    itemReq.Quantity,
+			UnitCost:    itemReq.UnitCost,
+		})
+		po.TotalAmount += itemReq.Quantity * itemReq.UnitCost
+	}
+	po.TotalAmount = math.Round(po.TotalAmount*100) / 100
+	return nil
+}
+
+// wrapNotFound mengubah record not found menjadi ErrPurchaseOrderNotFound
+func (uc *purchaseOrderUseCase) wrapNotFound(err error, id uint) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: purchase_order_id %d", ErrPurchaseOrderNotFound, id)
+	}
+	return err
+}
+
+// toPurchaseOrderResponse mengkonversi entity purchase order ke response DTO
+func toPurchaseOrderResponse(po *entity.PurchaseOrder) dto.PurchaseOrderResponse {
+	response := dto.PurchaseOrderResponse{
+		ID:           po.ID,
+		PONumber:     po.PONumber,
+		SupplierID:   po.SupplierID,
+		SupplierName: po.Supplier.Name,
+		Status:       po.Status,
+		ExpectedDate: po.ExpectedDate,
+		Note:         po.Note,
+		TotalAmount:  po.TotalAmount,
+		CreatedBy:    po.CreatedBy,
+		SentAt:       po.SentAt,
+		ReceivedAt:   po.ReceivedAt,
+		CreatedAt:    po.CreatedAt,
+		UpdatedAt:    po.UpdatedAt,
+		Items:        make([]dto.PurchaseOrderItemResponse, 0, len(po.Items)),
+	}
+	for _, item := range po.Items {
+		response.Items = append(response.Items, dto.PurchaseOrderItemResponse{
+			ID:                item.ID,
+			InventoryID:       item.InventoryID,
+			InventoryName:     item.Inventory.Name,
+			Unit:              item.Inventory.Unit,
+			Quantity:          item.Quantity,
+			ReceivedQuantity:  item.ReceivedQuantity,
+			RemainingQuantity: item.RemainingQuantity(),
+			UnitCost:          item.UnitCost,
+			Subtotal:          math.Round(item.Quantity*item.UnitCost*100) / 100,
+		})
+	}
+	for _, receipt := range po.Receipts {
+		receiptResponse := dto.GoodsReceiptResponse{
+			ID:              receipt.ID,
+			ReceiptNumber:   receipt.ReceiptNumber,
+			PurchaseOrderID: receipt.PurchaseOrderID,
+			ReceivedBy:      receipt.ReceivedBy,
+			Note:            receipt.Note,
+			TotalCost:       receipt.TotalCost,
+			CreatedAt:       receipt.CreatedAt,
+			Items:           make([]dto.GoodsReceiptItemResponse, 0, len(receipt.Items)),
+		}
+		for _, item := range receipt.Items {
+			receiptResponse.Items = append(receiptResponse.Items, dto.GoodsReceiptItemResponse{
+				ID:                  item.ID,
+				PurchaseOrderItemID: item.PurchaseOrderItemID,
+				InventoryID:         item.InventoryID,
+				Quantity:            item.Quantity,
+				UnitCost:            item.UnitCost,
+				StockMovementID:     item.StockMovementID,
+				BatchNumber:         item.BatchNumber,
+				ExpiryDate:          item.ExpiryDate,
+			})
+		}
+		response.Receipts = append(response.Receipts, receiptResponse)
+	}
+	return response
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/pkg/unit"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrRecipeProductNotFound dikembalikan jika produk resep tidak ada atau sudah dihapus
+	ErrRecipeProductNotFound = errors.New("produk resep tidak ditemukan")
+	// ErrInvalidRecipe dikembalikan jika bahan, varian, atau satuan resep tidak valid
+	ErrInvalidRecipe = errors.New("resep tidak valid")
+)
+
+type RecipeUseCase interface {
+	GetRecipe(ctx context.Context, productID uint) (*dto.RecipeResponse, error)
+	SaveRecipe(ctx context.Context, productID uint, req dto.RecipeRequest) (*dto.RecipeResponse, error)
+}
+
+type recipeUseCase struct {
+	recipeRepo      repository.RecipeRepository
+	productRepo     repository.ProductRepository
+	inventoriesRepo repository.InventoriesRepository
+	logger          *zap.Logger
+}
+
+func NewRecipeUseCase(
+	recipeRepo repository.RecipeRepository,
+	productRepo repository.ProductRepository,
+	inventoriesRepo repository.InventoriesRepository,
+	logger *zap.Logger,
+) RecipeUseCase {
+	return &recipeUseCase{
+		recipeRepo:      recipeRepo,
+		productRepo:     productRepo,
+		inventoriesRepo: inventoriesRepo,
+		logger:          logger,
+	}
+}
+
+func (uc *recipeUseCase) GetRecipe(ctx context.Context, productID uint) (*dto.RecipeResponse, error) {
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.recipeRepo.FindByProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return toRecipeResponse(product, items), nil
+}
+
+// SaveRecipe mengganti resep produk. Bundle tidak punya resep sendiri karena bahan diambil dari
+// resep komponennya.
+func (uc *recipeUseCase) SaveRecipe(ctx context.Context, productID uint, req dto.RecipeRequest) (*dto.RecipeResponse, error) {
+	uc.logger.Info("Saving recipe", zap.Uint("product_id", productID), zap.Int("items", len(req.Items)))
+
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsBundle() && len(req.Items) > 0 {
+		return nil, fmt.Errorf("%w: bundle memakai resep produk komponennya", ErrInvalidRecipe)
+	}
+
+	variants := make(map[uint]bool, len(product.Variants))
+	for _, v := range product.Variants {
+		variants[v.ID] = true
+	}
+
+	items := make([]entity.RecipeItem, 0, len(req.Items))
+	seen := make(map[string]bool, len(req.Items))
+	for _, itemReq := range req.Items {
+		if itemReq.VariantID != nil && !variants[*itemReq.VariantID] {
+			return nil, fmt.Errorf("%w: variant_id %d bukan milik %s", ErrInvalidRecipe, *itemReq.VariantID, product.ProductName)
+		}
+		key := fmt.Sprintf("%d", itemReq.InventoryID)
+		if itemReq.VariantID != nil {
+			key += fmt.Sprintf("-%d", *itemReq.VariantID)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: inventory_id %d ditulis lebih dari sekali", ErrInvalidRecipe, itemReq.InventoryID)
+		}
+		seen[key] = true
+
+		inventory, err := uc.inventoriesRepo.FindByID(ctx, itemReq.InventoryID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: inventory_id %d tidak ditemukan", ErrInvalidRecipe, itemReq.InventoryID)
+			}
+			return nil, err
+		}
+
+		itemUnit := unit.Normalize(itemReq.Unit)
+		if itemUnit == "" {
+			itemUnit = unit.Normalize(inventory.Unit)
+		}
+		if !inventory.AcceptsUnit(itemUnit) {
+			return nil, fmt.Errorf("%w: satuan %s tidak bisa dikonversi ke %s (%s)", ErrInvalidRecipe, itemUnit, inventory.Unit, inventory.Name)
+		}
+
+		items = append(items, entity.RecipeItem{
+			VariantID:   itemReq.VariantID,
+			InventoryID: inventory.ID,
+			Quantity:    itemReq.Quantity,
+			Unit:        itemUnit,
+		})
+	}
+
+	if err := uc.recipeRepo.Replace(ctx, productID, items); err != nil {
+		return nil, err
+	}
+	return uc.GetRecipe(ctx, productID)
+}
+
+// findProduct mengambil produk dan mengubah record not found menjadi ErrRecipeProductNotFound
+func (uc *recipeUseCase) findProduct(ctx context.Context, productID uint) (*entity.Product, error) {
+	product, err := uc.productRepo.Detail(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: product_id %d", ErrRecipeProductNotFound, productID)
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+func toRecipeResponse(product *entity.Product, items []entity.RecipeItem) *dto.RecipeResponse {
+	response := &dto.RecipeResponse{
+		ProductID:   product.ID,
+		ProductName: product.ProductName,
+		Items:       make([]dto.RecipeItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		perPortion, _ := item.InventoryQuantity()
+		response.Items = append(response.Items, dto.RecipeItemResponse{
+			ID:                item.ID,
+			VariantID:         item.VariantID,
+			InventoryID:       item.InventoryID,
+			InventoryName:     item.Inventory.Name,
+			Quantity:          item.Quantity,
+			Unit:              item.Unit,
+			InventoryUnit:     item.Inventory.Unit,
+			InventoryQuantity: perPortion,
+			InventoryStock:    item.Inventory.Quantity,
+		})
+	}
+	if len(product.Variants) == 0 {
+		if portions := entity.RecipePortions(items, nil); portions >= 0 {
+			response.AvailablePortions = &portions
+		}
+	}
+	return response
+}
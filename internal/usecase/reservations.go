@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"aplikasi-pos-team-boolean/internal/data/entity"
@@ -22,12 +23,14 @@ type ReservationsUseCase interface {
 
 type ReservationUseCase struct {
 	repo   repository.ReservationsRepository
+	events EventBus
 	logger *zap.Logger
 }
 
-func NewReservationUseCase(repo repository.ReservationsRepository, logger *zap.Logger) *ReservationUseCase {
+func NewReservationUseCase(repo repository.ReservationsRepository, events EventBus, logger *zap.Logger) *ReservationUseCase {
 	return &ReservationUseCase{
 		repo:   repo,
+		events: events,
 		logger: logger,
 	}
 }
@@ -112,6 +115,7 @@ func (uc *ReservationUseCase) CreateReservation(ctx context.Context, req dto.Res
 	if err != nil {
 		return nil, err
 	}
+	uc.events.Publish(ctx, EventReservationCreated, toReservationEvent(created, req.TableNumber))
 	return &dto.ReservationDetail{
 		ID:              created.ID,
 		CustomerName:    created.CustomerName,
@@ -205,14 +209,52 @@ func (uc *ReservationUseCase) UpdateReservation(ctx context.Context, id uint, re
 		return err
 	}
 
+	if isReservationCancelled(status) && !isReservationCancelled(existing.Status) {
+		uc.events.Publish(ctx, EventReservationCancelled, toReservationEvent(entityUpdate, req.TableNumber))
+	}
+
 	uc.logger.Info("Reservation updated successfully", zap.Uint("id", id))
 	return nil
 }
 
-// DeleteReservation soft deletes a reservation
+// DeleteReservation soft deletes a reservation; reservasi yang belum dibatalkan dianggap batal
 func (uc *ReservationUseCase) DeleteReservation(ctx context.Context, id uint) error {
+	existing, err := uc.repo.GetById(ctx, int64(id))
+	if err != nil {
+		uc.logger.Error("Failed to get existing reservation", zap.Uint("id", id), zap.Error(err))
+		return err
+	}
+
 	// Assuming soft delete by setting deleted_at
-	return uc.repo.Delete(ctx, int64(id))
+	if err := uc.repo.Delete(ctx, int64(id)); err != nil {
+		return err
+	}
+
+	if existing != nil && !isReservationCancelled(existing.Status) {
+		existing.Status = reservationStatusCancelled
+		uc.events.Publish(ctx, EventReservationCancelled, toReservationEvent(existing, ""))
+	}
+	return nil
+}
+
+// reservationStatusCancelled adalah status reservasi yang dibatalkan
+const reservationStatusCancelled = "cancelled"
+
+// isReservationCancelled mengembalikan true untuk status batal (cancelled/canceled, tanpa membedakan huruf)
+func isReservationCancelled(status string) bool {
+	return strings.EqualFold(status, reservationStatusCancelled) || strings.EqualFold(status, "canceled")
+}
+
+// toReservationEvent membentuk payload event reservasi
+func toReservationEvent(reservation *entity.Reservations, tableNumber string) ReservationEvent {
+	return ReservationEvent{
+		ReservationID:   uint(reservation.ID),
+		CustomerName:    reservation.CustomerName,
+		CustomerPhone:   reservation.CustomerPhone,
+		TableNumber:     tableNumber,
+		ReservationTime: reservation.ReservationTime,
+		Status:          reservation.Status,
+	}
 }
 
 // Helper untuk dereference *time.Time
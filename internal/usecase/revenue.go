@@ -17,14 +17,17 @@ type RevenueUseCase interface {
 }
 
 type revenueUseCase struct {
-	revenueRepo repository.RevenueRepository
-	logger      *zap.Logger
+	revenueRepo     repository.RevenueRepository
+	valuationMethod string
+	logger          *zap.Logger
 }
 
-func NewRevenueUseCase(revenueRepo repository.RevenueRepository, logger *zap.Logger) *revenueUseCase {
+// NewRevenueUseCase membuat revenue usecase; valuationMethod menentukan metode COGS untuk margin produk
+func NewRevenueUseCase(revenueRepo repository.RevenueRepository, valuationMethod string, logger *zap.Logger) *revenueUseCase {
 	return &revenueUseCase{
-		revenueRepo: revenueRepo,
-		logger:      logger,
+		revenueRepo:     revenueRepo,
+		valuationMethod: resolveValuationMethod(valuationMethod, logger),
+		logger:          logger,
 	}
 }
 
@@ -71,7 +74,7 @@ func (uc *revenueUseCase) GetRevenuePerMonth(ctx context.Context, year int, mont
 func (uc *revenueUseCase) GetProductRevenueList(ctx context.Context, productID int) (*dto.ProductRevenueListResponse, error) {
 	uc.logger.Info("Getting product revenue for product", zap.Int("product_id", productID))
 
-	response, err := uc.revenueRepo.GetProductRevenueList(ctx, productID)
+	response, err := uc.revenueRepo.GetProductRevenueList(ctx, productID, uc.valuationMethod)
 	if err != nil {
 		uc.logger.Error("Failed to get product revenue", zap.Error(err))
 		return nil, err
@@ -26,12 +26,14 @@ type StaffUseCase interface {
 
 type staffUseCase struct {
 	staffRepo repository.StaffRepository
+	events    EventBus
 	logger    *zap.Logger
 }
 
-func NewStaffUseCase(staffRepo repository.StaffRepository, logger *zap.Logger) *staffUseCase {
+func NewStaffUseCase(staffRepo repository.StaffRepository, events EventBus, logger *zap.Logger) *staffUseCase {
 	return &staffUseCase{
 		staffRepo: staffRepo,
+		events:    events,
 		logger:    logger,
 	}
 }
@@ -196,6 +198,13 @@ func (s *staffUseCase) CreateStaff(ctx context.Context, req dto.StaffCreateReque
 		zap.String("email", staff.Email),
 	)
 
+	s.events.Publish(ctx, EventStaffAdded, StaffEvent{
+		StaffID:  staff.ID,
+		FullName: staff.FullName,
+		Email:    staff.Email,
+		Role:     staff.Role,
+	})
+
 	response := s.toStaffResponse(staff)
 	return &response, nil
 }
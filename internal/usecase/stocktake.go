@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrStocktakeNotFound dikembalikan jika sesi stocktake tidak ada
+var ErrStocktakeNotFound = errors.New("stocktake tidak ditemukan")
+
+type StocktakeUseCase interface {
+	GetAllStocktakes(ctx context.Context, status string) ([]dto.StocktakeResponse, error)
+	GetStocktakeByID(ctx context.Context, id uint) (*dto.StocktakeResponse, error)
+	CreateStocktake(ctx context.Context, req dto.StocktakeRequest) (*dto.StocktakeResponse, error)
+	RecordCounts(ctx context.Context, id uint, req dto.StocktakeCountRequest) (*dto.StocktakeResponse, error)
+	ApproveStocktake(ctx context.Context, id uint, req dto.StocktakeApproveRequest) (*dto.StocktakeResponse, error)
+	CancelStocktake(ctx context.Context, id uint) (*dto.StocktakeResponse, error)
+	GetVarianceReport(ctx context.Context, id uint) (*dto.StocktakeResponse, error)
+	ExportVarianceCSV(ctx context.Context, id uint) ([]byte, string, error)
+}
+
+type stocktakeUseCase struct {
+	stocktakeRepo repository.StocktakeRepository
+	authRepo      repository.AuthRepository
+	logger        *zap.Logger
+}
+
+func NewStocktakeUseCase(stocktakeRepo repository.StocktakeRepository, authRepo repository.AuthRepository, logger *zap.Logger) StocktakeUseCase {
+	return &stocktakeUseCase{
+		stocktakeRepo: stocktakeRepo,
+		authRepo:      authRepo,
+		logger:        logger,
+	}
+}
+
+// GetAllStocktakes mengambil sesi stocktake beserta ringkasannya (tanpa detail baris)
+func (uc *stocktakeUseCase) GetAllStocktakes(ctx context.Context, status string) ([]dto.StocktakeResponse, error) {
+	stocktakes, err := uc.stocktakeRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.StocktakeResponse, 0, len(stocktakes))
+	for i := range stocktakes {
+		response := toStocktakeResponse(&stocktakes[i], false)
+		response.Lines = nil
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (uc *stocktakeUseCase) GetStocktakeByID(ctx context.Context, id uint) (*dto.StocktakeResponse, error) {
+	stocktake, err := uc.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStocktakeResponse(stocktake, false)
+	return &response, nil
+}
+
+// CreateStocktake membuka sesi stocktake dan men-snapshot quantity inventory saat ini
+func (uc *stocktakeUseCase) CreateStocktake(ctx context.Context, req dto.StocktakeRequest) (*dto.StocktakeResponse, error) {
+	uc.logger.Info("Creating stocktake", zap.String("category", req.Category), zap.Uint("created_by", req.CreatedBy))
+
+	stocktake := &entity.Stocktake{
+		Category: req.Category,
+		Note:     req.Note,
+	}
+	if req.CreatedBy != 0 {
+		createdBy := req.CreatedBy
+		stocktake.CreatedBy = &createdBy
+	}
+
+	if err := uc.stocktakeRepo.Create(ctx, stocktake); err != nil {
+		return nil, err
+	}
+	return uc.GetStocktakeByID(ctx, stocktake.ID)
+}
+
+// RecordCounts menyimpan hasil hitung satu counter
+func (uc *stocktakeUseCase) RecordCounts(ctx context.Context, id uint, req dto.StocktakeCountRequest) (*dto.StocktakeResponse, error) {
+	uc.logger.Info("Recording stocktake counts",
+		zap.Uint("id", id),
+		zap.Uint("counted_by", req.CountedBy),
+		zap.Int("items", len(req.Items)))
+
+	counts := make([]repository.StocktakeCountInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		counts = append(counts, repository.StocktakeCountInput{
+			InventoryID: item.InventoryID,
+			Quantity:    *item.Quantity,
+			Note:        item.Note,
+		})
+	}
+
+	if err := uc.stocktakeRepo.RecordCounts(ctx, id, req.CountedBy, counts); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetStocktakeByID(ctx, id)
+}
+
+// ApproveStocktake memposting selisih stocktake sebagai adjustment; hanya boleh oleh manager
+func (uc *stocktakeUseCase) ApproveStocktake(ctx context.Context, id uint, req dto.StocktakeApproveRequest) (*dto.StocktakeResponse, error) {
+	uc.logger.Info("Approving stocktake", zap.Uint("id", id), zap.Uint("approved_by", req.ApprovedBy))
+
+	if req.ApprovedBy == 0 {
+		return nil, fmt.Errorf("%w: user tidak terautentikasi", ErrManagerApprovalRequired)
+	}
+	user, err := uc.authRepo.GetUserByID(ctx, req.ApprovedBy)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !managerRoles[user.Role] {
+		uc.logger.Warn("Stocktake approver is not a manager", zap.Uint("approved_by", req.ApprovedBy))
+		return nil, fmt.Errorf("%w: user %d bukan manager", ErrManagerApprovalRequired, req.ApprovedBy)
+	}
+
+	if err := uc.stocktakeRepo.Approve(ctx, id, req.ApprovedBy); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetStocktakeByID(ctx, id)
+}
+
+func (uc *stocktakeUseCase) CancelStocktake(ctx context.Context, id uint) (*dto.StocktakeResponse, error) {
+	uc.logger.Info("Cancelling stocktake", zap.Uint("id", id))
+
+	if err := uc.stocktakeRepo.Cancel(ctx, id); err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return uc.GetStocktakeByID(ctx, id)
+}
+
+// GetVarianceReport mengambil laporan selisih: hanya baris yang sudah dihitung
+func (uc *stocktakeUseCase) GetVarianceReport(ctx context.Context, id uint) (*dto.StocktakeResponse, error) {
+	stocktake, err := uc.findStocktake(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toStocktakeResponse(stocktake, true)
+	return &response, nil
+}
+
+// ExportVarianceCSV membuat laporan selisih stocktake dalam format CSV
+func (uc *stocktakeUseCase) ExportVarianceCSV(ctx context.Context, id uint) ([]byte, string, error) {
+	report, err := uc.GetVarianceReport(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{{
+		"inventory_id", "inventory_name", "category", "unit",
+		"expected_quantity", "counted_quantity", "variance",
+		"retail_price", "variance_value_retail", "unit_cost", "variance_value_cost",
+	}}
+	for _, line := range report.Lines {
+		rows = append(rows, []string{
+			strconv.FormatInt(line.InventoryID, 10),
+			line.InventoryName,
+			line.Category,
+			line.Unit,
+			formatQuantity(line.ExpectedQuantity),
+			formatQuantity(*line.CountedQuantity),
+			formatQuantity(*line.Variance),
+			formatAmount(line.RetailPrice),
+			formatAmount(line.VarianceValueRetail),
+			formatAmount(line.UnitCost),
+			formatAmount(line.VarianceValueCost),
+		})
+	}
+	rows = append(rows, []string{
+		"", "TOTAL", "", "", "", "", "",
+		"", formatAmount(report.Summary.VarianceValueRetail),
+		"", formatAmount(report.Summary.VarianceValueCost),
+	})
+	if err := w.WriteAll(rows); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), fmt.Sprintf("stocktake-%s-variance.csv", report.Number), nil
+}
+
+// findStocktake mengambil stocktake dan mengubah record not found menjadi ErrStocktakeNotFound
+func (uc *stocktakeUseCase) findStocktake(ctx context.Context, id uint) (*entity.Stocktake, error) {
+	stocktake, err := uc.stocktakeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, uc.wrapNotFound(err, id)
+	}
+	return stocktake, nil
+}
+
+// wrapNotFound mengubah record not found menjadi ErrStocktakeNotFound
+func (uc *stocktakeUseCase) wrapNotFound(err error, id uint) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: stocktake_id %d", ErrStocktakeNotFound, id)
+	}
+	return err
+}
+
+// toStocktakeResponse mengkonversi entity stocktake ke response DTO. Jika countedOnly true,
+// hanya baris yang sudah dihitung yang disertakan (laporan selisih).
+func toStocktakeResponse(stocktake *entity.Stocktake, countedOnly bool) dto.StocktakeResponse {
+	response := dto.StocktakeResponse{
+		ID:         stocktake.ID,
+		Number:     stocktake.Number,
+		Status:     stocktake.Status,
+		Category:   stocktake.Category,
+		Note:       stocktake.Note,
+		CreatedBy:  stocktake.CreatedBy,
+		ApprovedBy: stocktake.ApprovedBy,
+		ApprovedAt: stocktake.ApprovedAt,
+		CreatedAt:  stocktake.CreatedAt,
+		UpdatedAt:  stocktake.UpdatedAt,
+		Lines:      make([]dto.StocktakeLineResponse, 0, len(stocktake.Lines)),
+	}
+	response.Summary.TotalLines = len(stocktake.Lines)
+
+	for _, line := range stocktake.Lines {
+		variance, counted := line.Variance()
+		if counted {
+			response.Summary.CountedLines++
+			if variance != 0 {
+				response.Summary.VarianceLines++
+			}
+		} else if countedOnly {
+			continue
+		}
+
+		lineResponse := dto.StocktakeLineResponse{
+			ID:                   line.ID,
+			InventoryID:          line.InventoryID,
+			InventoryName:        line.Inventory.Name,
+			Category:             line.Inventory.Category,
+			Unit:                 line.Inventory.Unit,
+			ExpectedQuantity:     line.ExpectedQuantity,
+			CountedQuantity:      line.CountedQuantity,
+			RetailPrice:          line.RetailPrice,
+			UnitCost:             line.UnitCost,
+			AdjustmentMovementID: line.AdjustmentMovementID,
+		}
+		if counted {
+			variance = math.Round(variance*1000) / 1000
+			lineResponse.Variance = &variance
+			lineResponse.VarianceValueRetail = math.Round(variance*line.RetailPrice*100) / 100
+			lineResponse.VarianceValueCost = math.Round(variance*line.UnitCost*100) / 100
+			response.Summary.VarianceValueRetail += lineResponse.VarianceValueRetail
+			response.Summary.VarianceValueCost += lineResponse.VarianceValueCost
+		}
+		for _, count := range line.Counts {
+			lineResponse.Counts = append(lineResponse.Counts, dto.StocktakeCountResponse{
+				CountedBy: count.CountedBy,
+				Quantity:  count.Quantity,
+				Note:      count.Note,
+				UpdatedAt: count.UpdatedAt,
+			})
+		}
+		response.Lines = append(response.Lines, lineResponse)
+	}
+
+	response.Summary.VarianceValueRetail = math.Round(response.Summary.VarianceValueRetail*100) / 100
+	response.Summary.VarianceValueCost = math.Round(response.Summary.VarianceValueCost*100) / 100
+	return response
+}
+
+// formatQuantity memformat quantity inventory untuk export (maksimal 3 desimal)
+func formatQuantity(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatAmount memformat nilai uang untuk export (2 desimal)
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
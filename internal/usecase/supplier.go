@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrSupplierNotFound dikembalikan jika supplier tidak ada atau sudah dihapus
+var ErrSupplierNotFound = errors.New("supplier tidak ditemukan")
+
+type SupplierUseCase interface {
+	GetAllSuppliers(ctx context.Context, activeOnly bool) ([]dto.SupplierResponse, error)
+	GetSupplierByID(ctx context.Context, id uint) (*dto.SupplierResponse, error)
+	CreateSupplier(ctx context.Context, req dto.SupplierRequest) (*dto.SupplierResponse, error)
+	UpdateSupplier(ctx context.Context, id uint, req dto.SupplierRequest) (*dto.SupplierResponse, error)
+	DeleteSupplier(ctx context.Context, id uint) error
+}
+
+type supplierUseCase struct {
+	supplierRepo repository.SupplierRepository
+	logger       *zap.Logger
+}
+
+func NewSupplierUseCase(supplierRepo repository.SupplierRepository, logger *zap.Logger) SupplierUseCase {
+	return &supplierUseCase{
+		supplierRepo: supplierRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *supplierUseCase) GetAllSuppliers(ctx context.Context, activeOnly bool) ([]dto.SupplierResponse, error) {
+	suppliers, err := uc.supplierRepo.FindAll(ctx, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.SupplierResponse, 0, len(suppliers))
+	for i := range suppliers {
+		responses = append(responses, toSupplierResponse(&suppliers[i]))
+	}
+	return responses, nil
+}
+
+func (uc *supplierUseCase) GetSupplierByID(ctx context.Context, id uint) (*dto.SupplierResponse, error) {
+	supplier, err := uc.findSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+func (uc *supplierUseCase) CreateSupplier(ctx context.Context, req dto.SupplierRequest) (*dto.SupplierResponse, error) {
+	uc.logger.Info("Creating supplier", zap.String("name", req.Name))
+
+	supplier := &entity.Supplier{}
+	applySupplierRequest(supplier, req)
+	if err := uc.supplierRepo.Create(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	response := toSupplierResponse(supplier)
+	return &response, nil
+}
+
+func (uc *supplierUseCase) UpdateSupplier(ctx context.Context, id uint, req dto.SupplierRequest) (*dto.SupplierResponse, error) {
+	uc.logger.Info("Updating supplier", zap.Uint("id", id))
+
+	supplier, err := uc.findSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applySupplierRequest(supplier, req)
+	if err := uc.supplierRepo.Update(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return uc.GetSupplierByID(ctx, id)
+}
+
+func (uc *supplierUseCase) DeleteSupplier(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting supplier", zap.Uint("id", id))
+
+	if err := uc.supplierRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: supplier_id %d", ErrSupplierNotFound, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// findSupplier mengambil supplier dan mengubah record not found menjadi ErrSupplierNotFound
+func (uc *supplierUseCase) findSupplier(ctx context.Context, id uint) (*entity.Supplier, error) {
+	supplier, err := uc.supplierRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: supplier_id %d", ErrSupplierNotFound, id)
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// applySupplierRequest menyalin isi request ke entity supplier
+func applySupplierRequest(supplier *entity.Supplier, req dto.SupplierRequest) {
+	supplier.Name = strings.TrimSpace(req.Name)
+	supplier.ContactName = strings.TrimSpace(req.ContactName)
+	supplier.Phone = strings.TrimSpace(req.Phone)
+	supplier.Email = strings.TrimSpace(req.Email)
+	supplier.Address = req.Address
+	supplier.Note = req.Note
+	supplier.IsActive = req.IsActive == nil || *req.IsActive
+}
+
+// toSupplierResponse mengkonversi entity supplier ke response DTO
+func toSupplierResponse(supplier *entity.Supplier) dto.SupplierResponse {
+	return dto.SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		Phone:       supplier.Phone,
+		Email:       supplier.Email,
+		Address:     supplier.Address,
+		Note:        supplier.Note,
+		IsActive:    supplier.IsActive,
+		CreatedAt:   supplier.CreatedAt,
+		UpdatedAt:   supplier.UpdatedAt,
+	}
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTableNumberExists dikembalikan jika nomor meja sudah dipakai meja lain
+	ErrTableNumberExists = errors.New("nomor meja sudah digunakan")
+	// ErrTableHasOpenOrders dikembalikan jika meja masih punya order yang berjalan
+	ErrTableHasOpenOrders = errors.New("meja masih memiliki order yang berjalan")
+)
+
+type TableUseCase interface {
+	GetAllTables(ctx context.Context, status string) ([]dto.TableDetailResponse, error)
+	GetTableByID(ctx context.Context, id uint) (*dto.TableDetailResponse, error)
+	CreateTable(ctx context.Context, req dto.TableCreateRequest) (*dto.TableDetailResponse, error)
+	UpdateTable(ctx context.Context, id uint, req dto.TableUpdateRequest) (*dto.TableDetailResponse, error)
+	DeleteTable(ctx context.Context, id uint) error
+	UpdateTableStatus(ctx context.Context, id uint, req dto.TableStatusUpdateRequest) (*dto.TableDetailResponse, error)
+	SyncReservationHolds(ctx context.Context) error
+	RunReservationSync(ctx context.Context, interval time.Duration)
+}
+
+type tableUseCase struct {
+	tableRepo   repository.TableRepository
+	holdWindow  time.Duration // Berapa lama sebelum reservation_time meja mulai ditahan
+	gracePeriod time.Duration // Berapa lama setelah reservation_time meja tetap ditahan jika tamu belum datang
+	logger      *zap.Logger
+}
+
+func NewTableUseCase(tableRepo repository.TableRepository, holdWindow, gracePeriod time.Duration, logger *zap.Logger) TableUseCase {
+	return &tableUseCase{
+		tableRepo:   tableRepo,
+		holdWindow:  holdWindow,
+		gracePeriod: gracePeriod,
+		logger:      logger,
+	}
+}
+
+func (uc *tableUseCase) GetAllTables(ctx context.Context, status string) ([]dto.TableDetailResponse, error) {
+	uc.logger.Info("Getting all tables", zap.String("status", status))
+
+	tables, err := uc.tableRepo.FindAll(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	tableIDs := make([]uint, 0, len(tables))
+	for _, table := range tables {
+		tableIDs = append(tableIDs, table.ID)
+	}
+	openOrders, err := uc.tableRepo.CountOpenOrdersByTables(ctx, tableIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TableDetailResponse, 0, len(tables))
+	for i := range tables {
+		responses = append(responses, toTableDetailResponse(&tables[i], openOrders[tables[i].ID]))
+	}
+
+	return responses, nil
+}
+
+func (uc *tableUseCase) GetTableByID(ctx context.Context, id uint) (*dto.TableDetailResponse, error) {
+	uc.logger.Info("Getting table by ID", zap.Uint("id", id))
+
+	table, err := uc.findTable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	openOrders, err := uc.tableRepo.CountOpenOrders(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTableDetailResponse(table, openOrders)
+	return &response, nil
+}
+
+func (uc *tableUseCase) CreateTable(ctx context.Context, req dto.TableCreateRequest) (*dto.TableDetailResponse, error) {
+	uc.logger.Info("Creating table", zap.String("number", req.Number))
+
+	if existing, err := uc.tableRepo.FindByNumber(ctx, req.Number); err == nil && existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTableNumberExists, req.Number)
+	}
+
+	table := &entity.Table{
+		Number:   req.Number,
+		Capacity: req.Capacity,
+		Status:   entity.TableStatusAvailable,
+	}
+	if err := uc.tableRepo.Create(ctx, table); err != nil {
+		uc.logger.Error("Failed to create table", zap.String("number", req.Number), zap.Error(err))
+		return nil, err
+	}
+
+	response := toTableDetailResponse(table, 0)
+	return &response, nil
+}
+
+func (uc *tableUseCase) UpdateTable(ctx context.Context, id uint, req dto.TableUpdateRequest) (*dto.TableDetailResponse, error) {
+	uc.logger.Info("Updating table", zap.Uint("id", id), zap.String("number", req.Number))
+
+	table, err := uc.findTable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Number != table.Number {
+		if existing, err := uc.tableRepo.FindByNumber(ctx, req.Number); err == nil && existing != nil && existing.ID != id {
+			return nil, fmt.Errorf("%w: %s", ErrTableNumberExists, req.Number)
+		}
+	}
+
+	table.Number = req.Number
+	table.Capacity = req.Capacity
+	if err := uc.tableRepo.Update(ctx, table); err != nil {
+		uc.logger.Error("Failed to update table", zap.Uint("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return uc.GetTableByID(ctx, id)
+}
+
+func (uc *tableUseCase) DeleteTable(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting table", zap.Uint("id", id))
+
+	if _, err := uc.findTable(ctx, id); err != nil {
+		return err
+	}
+
+	openOrders, err := uc.tableRepo.CountOpenOrders(ctx, id)
+	if err != nil {
+		return err
+	}
+	if openOrders > 0 {
+		return fmt.Errorf("%w: %d order", ErrTableHasOpenOrders, openOrders)
+	}
+
+	return uc.tableRepo.Delete(ctx, id)
+}
+
+// UpdateTableStatus meng-override status meja secara manual. Meja yang masih punya
+// order berjalan tidak boleh dikosongkan agar tidak tertimpa order baru.
+func (uc *tableUseCase) UpdateTableStatus(ctx context.Context, id uint, req dto.TableStatusUpdateRequest) (*dto.TableDetailResponse, error) {
+	uc.logger.Info("Overriding table status", zap.Uint("id", id), zap.String("status", req.Status))
+
+	if _, err := uc.findTable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if req.Status == entity.TableStatusAvailable {
+		openOrders, err := uc.tableRepo.CountOpenOrders(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if openOrders > 0 {
+			return nil, fmt.Errorf("%w: %d order", ErrTableHasOpenOrders, openOrders)
+		}
+	}
+
+	if err := uc.tableRepo.UpdateStatus(ctx, id, req.Status); err != nil {
+		return nil, err
+	}
+
+	return uc.GetTableByID(ctx, id)
+}
+
+// SyncReservationHolds menahan meja untuk reservasi yang reservation_time-nya kurang dari
+// holdWindow lagi, dan melepasnya kembali setelah lewat gracePeriod
+func (uc *tableUseCase) SyncReservationHolds(ctx context.Context) error {
+	now := time.Now()
+	reserved, released, err := uc.tableRepo.SyncReservations(ctx, now.Add(-uc.gracePeriod), now.Add(uc.holdWindow))
+	if err != nil {
+		return err
+	}
+
+	if reserved > 0 || released > 0 {
+		uc.logger.Info("Table reservation holds synced",
+			zap.Int64("reserved", reserved),
+			zap.Int64("released", released))
+	}
+	return nil
+}
+
+// RunReservationSync menjalankan SyncReservationHolds secara berkala sampai ctx dibatalkan
+func (uc *tableUseCase) RunReservationSync(ctx context.Context, interval time.Duration) {
+	uc.logger.Info("Reservation sync job started",
+		zap.Duration("interval", interval),
+		zap.Duration("hold_window", uc.holdWindow),
+		zap.Duration("grace_period", uc.gracePeriod))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.SyncReservationHolds(ctx); err != nil && ctx.Err() == nil {
+			uc.logger.Error("Failed to sync reservation holds", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.logger.Info("Reservation sync job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// findTable mengambil meja dan mengubah record not found menjadi ErrTableNotFound
+func (uc *tableUseCase) findTable(ctx context.Context, id uint) (*entity.Table, error) {
+	table, err := uc.tableRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: table_id %d", repository.ErrTableNotFound, id)
+		}
+		return nil, err
+	}
+	return table, nil
+}
+
+// toTableDetailResponse mengkonversi entity meja ke response DTO
+func toTableDetailResponse(table *entity.Table, openOrders int64) dto.TableDetailResponse {
+	return dto.TableDetailResponse{
+		ID:            table.ID,
+		Number:        table.Number,
+		Capacity:      table.Capacity,
+		Status:        table.Status,
+		ReservationID: table.ReservationID,
+		OpenOrders:    openOrders,
+		CreatedAt:     table.CreatedAt,
+		UpdatedAt:     table.UpdatedAt,
+	}
+}
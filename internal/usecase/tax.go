@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+	"aplikasi-pos-team-boolean/pkg/receipt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTaxRuleNotFound dikembalikan jika aturan pajak tidak ada atau sudah dihapus
+	ErrTaxRuleNotFound = errors.New("aturan pajak tidak ditemukan")
+	// ErrTaxCategoryNotFound dikembalikan jika kategori pada aturan pajak tidak ada
+	ErrTaxCategoryNotFound = errors.New("kategori aturan pajak tidak ditemukan")
+)
+
+type TaxUseCase interface {
+	GetAllOutlets(ctx context.Context) ([]dto.OutletResponse, error)
+	CreateOutlet(ctx context.Context, req dto.OutletRequest) (*dto.OutletResponse, error)
+	UpdateOutlet(ctx context.Context, id uint, req dto.OutletRequest) (*dto.OutletResponse, error)
+	GetTaxRules(ctx context.Context, outletID, categoryID uint) ([]dto.TaxRuleResponse, error)
+	CreateTaxRule(ctx context.Context, req dto.TaxRuleRequest) (*dto.TaxRuleResponse, error)
+	UpdateTaxRule(ctx context.Context, id uint, req dto.TaxRuleRequest) (*dto.TaxRuleResponse, error)
+	DeleteTaxRule(ctx context.Context, id uint) error
+}
+
+type taxUseCase struct {
+	taxRepo      repository.TaxRepository
+	categoryRepo repository.CategoryRepository
+	logger       *zap.Logger
+}
+
+func NewTaxUseCase(taxRepo repository.TaxRepository, categoryRepo repository.CategoryRepository, logger *zap.Logger) TaxUseCase {
+	return &taxUseCase{
+		taxRepo:      taxRepo,
+		categoryRepo: categoryRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *taxUseCase) GetAllOutlets(ctx context.Context) ([]dto.OutletResponse, error) {
+	outlets, err := uc.taxRepo.FindAllOutlets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.OutletResponse, 0, len(outlets))
+	for i := range outlets {
+		responses = append(responses, toOutletResponse(&outlets[i]))
+	}
+	return responses, nil
+}
+
+func (uc *taxUseCase) CreateOutlet(ctx context.Context, req dto.OutletRequest) (*dto.OutletResponse, error) {
+	uc.logger.Info("Creating outlet", zap.String("name", req.Name))
+
+	outlet := &entity.Outlet{}
+	applyOutletRequest(outlet, req)
+	if err := uc.taxRepo.CreateOutlet(ctx, outlet); err != nil {
+		return nil, err
+	}
+
+	response := toOutletResponse(outlet)
+	return &response, nil
+}
+
+func (uc *taxUseCase) UpdateOutlet(ctx context.Context, id uint, req dto.OutletRequest) (*dto.OutletResponse, error) {
+	uc.logger.Info("Updating outlet", zap.Uint("id", id))
+
+	outlet, err := uc.findOutlet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyOutletRequest(outlet, req)
+	if err := uc.taxRepo.UpdateOutlet(ctx, outlet); err != nil {
+		return nil, err
+	}
+
+	response := toOutletResponse(outlet)
+	return &response, nil
+}
+
+func (uc *taxUseCase) GetTaxRules(ctx context.Context, outletID, categoryID uint) ([]dto.TaxRuleResponse, error) {
+	rules, err := uc.taxRepo.FindTaxRules(ctx, outletID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TaxRuleResponse, 0, len(rules))
+	for i := range rules {
+		responses = append(responses, toTaxRuleResponse(&rules[i]))
+	}
+	return responses, nil
+}
+
+func (uc *taxUseCase) CreateTaxRule(ctx context.Context, req dto.TaxRuleRequest) (*dto.TaxRuleResponse, error) {
+	uc.logger.Info("Creating tax rule",
+		zap.String("name", req.Name),
+		zap.String("type", req.Type),
+		zap.Float64("rate", req.Rate))
+
+	if err := uc.validateTaxRuleScope(ctx, req); err != nil {
+		return nil, err
+	}
+
+	rule := &entity.TaxRule{}
+	applyTaxRuleRequest(rule, req)
+	if err := uc.taxRepo.CreateTaxRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	response := toTaxRuleResponse(rule)
+	return &response, nil
+}
+
+func (uc *taxUseCase) UpdateTaxRule(ctx context.Context, id uint, req dto.TaxRuleRequest) (*dto.TaxRuleResponse, error) {
+	uc.logger.Info("Updating tax rule", zap.Uint("id", id))
+
+	rule, err := uc.taxRepo.FindTaxRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: tax_rule_id %d", ErrTaxRuleNotFound, id)
+		}
+		return nil, err
+	}
+
+	if err := uc.validateTaxRuleScope(ctx, req); err != nil {
+		return nil, err
+	}
+
+	applyTaxRuleRequest(rule, req)
+	if err := uc.taxRepo.UpdateTaxRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	response := toTaxRuleResponse(rule)
+	return &response, nil
+}
+
+func (uc *taxUseCase) DeleteTaxRule(ctx context.Context, id uint) error {
+	uc.logger.Info("Deleting tax rule", zap.Uint("id", id))
+
+	if _, err := uc.taxRepo.FindTaxRuleByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: tax_rule_id %d", ErrTaxRuleNotFound, id)
+		}
+		return err
+	}
+
+	return uc.taxRepo.DeleteTaxRule(ctx, id)
+}
+
+// validateTaxRuleScope memastikan outlet dan kategori pada aturan pajak ada
+func (uc *taxUseCase) validateTaxRuleScope(ctx context.Context, req dto.TaxRuleRequest) error {
+	if req.OutletID != nil {
+		if _, err := uc.findOutlet(ctx, *req.OutletID); err != nil {
+			return err
+		}
+	}
+	if req.CategoryID != nil {
+		if _, err := uc.categoryRepo.Detail(ctx, *req.CategoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: category_id %d", ErrTaxCategoryNotFound, *req.CategoryID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// findOutlet mengambil outlet dan mengubah record not found menjadi ErrOutletNotFound
+func (uc *taxUseCase) findOutlet(ctx context.Context, id uint) (*entity.Outlet, error) {
+	outlet, err := uc.taxRepo.FindOutletByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: outlet_id %d", repository.ErrOutletNotFound, id)
+		}
+		return nil, err
+	}
+	return outlet, nil
+}
+
+// applyOutletRequest menyalin isi request ke entity outlet
+func applyOutletRequest(outlet *entity.Outlet, req dto.OutletRequest) {
+	outlet.Name = req.Name
+	outlet.Address = req.Address
+	outlet.Phone = req.Phone
+	outlet.PricesIncludeTax = req.PricesIncludeTax
+	outlet.ReceiptHeader = req.ReceiptHeader
+	outlet.ReceiptFooter = req.ReceiptFooter
+	outlet.ReceiptWidth = req.ReceiptWidth
+	if outlet.ReceiptWidth == 0 {
+		outlet.ReceiptWidth = receipt.PaperWidth58
+	}
+}
+
+// applyTaxRuleRequest menyalin isi request ke entity aturan pajak
+func applyTaxRuleRequest(rule *entity.TaxRule, req dto.TaxRuleRequest) {
+	rule.OutletID = req.OutletID
+	rule.CategoryID = req.CategoryID
+	rule.Name = req.Name
+	rule.Type = req.Type
+	rule.Rate = req.Rate
+	rule.Priority = req.Priority
+	rule.IsCompound = req.IsCompound
+	rule.IsActive = req.IsActive == nil || *req.IsActive
+}
+
+// toOutletResponse mengkonversi entity outlet ke response DTO
+func toOutletResponse(outlet *entity.Outlet) dto.OutletResponse {
+	return dto.OutletResponse{
+		ID:               outlet.ID,
+		Name:             outlet.Name,
+		Address:          outlet.Address,
+		Phone:            outlet.Phone,
+		PricesIncludeTax: outlet.PricesIncludeTax,
+		ReceiptHeader:    outlet.ReceiptHeader,
+		ReceiptFooter:    outlet.ReceiptFooter,
+		ReceiptWidth:     outlet.ReceiptWidth,
+		CreatedAt:        outlet.CreatedAt,
+		UpdatedAt:        outlet.UpdatedAt,
+	}
+}
+
+// toTaxRuleResponse mengkonversi entity aturan pajak ke response DTO
+func toTaxRuleResponse(rule *entity.TaxRule) dto.TaxRuleResponse {
+	return dto.TaxRuleResponse{
+		ID:         rule.ID,
+		OutletID:   rule.OutletID,
+		CategoryID: rule.CategoryID,
+		Name:       rule.Name,
+		Type:       rule.Type,
+		Rate:       rule.Rate,
+		Priority:   rule.Priority,
+		IsCompound: rule.IsCompound,
+		IsActive:   rule.IsActive,
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+}
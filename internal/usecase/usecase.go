@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"time"
+
 	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/pkg/utils"
 
@@ -23,10 +25,25 @@ type UseCase struct {
 	DashboardUseCase   DashboardUseCase
 	ReservationsUseCase ReservationsUseCase
 	RevenueUseCase      RevenueUseCase
+	TableUseCase        TableUseCase
+	TaxUseCase          TaxUseCase
+	PromotionUseCase    PromotionUseCase
+	KitchenUseCase      KitchenUseCase
+	ModifierUseCase     ModifierUseCase
+	RecipeUseCase       RecipeUseCase
+	SupplierUseCase     SupplierUseCase
+	PurchaseOrderUseCase PurchaseOrderUseCase
+	StocktakeUseCase     StocktakeUseCase
+	WasteUseCase         WasteUseCase
+	EventBus             EventBus
 }
 
 func NewUseCase(repo *repository.Repository, logger *zap.Logger, tx *gorm.DB) *UseCase {
 	emailService := utils.NewEmailService(logger, utils.Config.SMTP)
+	kitchenUseCase := NewKitchenUseCase(repo.KitchenRepo, repo.CategoryRepo, logger)
+	notificationUseCase := NewNotificationUseCase(repo.NotificationRepo, logger)
+	eventBus := NewEventBus(logger)
+	RegisterNotificationRules(eventBus, repo.AuthRepo, notificationUseCase, logger)
 
 	return &UseCase{
 		log:  logger,
@@ -34,14 +51,30 @@ func NewUseCase(repo *repository.Repository, logger *zap.Logger, tx *gorm.DB) *U
 
 		AuthUseCase:        NewAuthUseCase(repo.AuthRepo, logger, emailService),
 		AdminUseCase:       NewAdminUseCase(repo.AuthRepo, emailService, logger),
-		OrderUseCase:       NewOrderUseCase(repo.OrderRepo, logger),
-		InventoriesUsecase: NewInventoriesUsecase(repo.InventoriesRepo, logger),
-		StaffUseCase:       NewStaffUseCase(repo.StaffRepo, logger),
-		NotificationUseCase: NewNotificationUseCase(repo.NotificationRepo, logger),
+		OrderUseCase:       NewOrderUseCase(repo.OrderRepo, repo.AuthRepo, repo.TaxRepo, kitchenUseCase, eventBus, logger),
+		InventoriesUsecase: NewInventoriesUsecase(repo.InventoriesRepo, repo.StockMovementRepo, repo.ProductRepo, repo.AuthRepo, notificationUseCase, eventBus,
+			utils.Config.Inventory.ValuationMethod, utils.Config.Inventory.ExpiryAlertDays, logger),
+		StaffUseCase:       NewStaffUseCase(repo.StaffRepo, eventBus, logger),
+		NotificationUseCase: notificationUseCase,
 		CategoryUseCase:    NewCategoryUseCase(repo.CategoryRepo, logger),
 		ProductUseCase:     NewProductUseCase(repo.ProductRepo, repo.CategoryRepo, logger),
 		DashboardUseCase:   NewDashboardUseCase(repo.DashboardRepo, logger),
-		ReservationsUseCase: NewReservationUseCase(repo.ReservationRepo, logger),
-		RevenueUseCase:      NewRevenueUseCase(repo.RevenueRepo, logger),
+		ReservationsUseCase: NewReservationUseCase(repo.ReservationRepo, eventBus, logger),
+		RevenueUseCase:      NewRevenueUseCase(repo.RevenueRepo, utils.Config.Inventory.ValuationMethod, logger),
+		TableUseCase: NewTableUseCase(repo.TableRepo,
+			time.Duration(utils.Config.Reservation.HoldMinutes)*time.Minute,
+			time.Duration(utils.Config.Reservation.GraceMinutes)*time.Minute,
+			logger),
+		TaxUseCase: NewTaxUseCase(repo.TaxRepo, repo.CategoryRepo, logger),
+		PromotionUseCase: NewPromotionUseCase(repo.PromotionRepo, repo.ProductRepo, repo.CategoryRepo, logger),
+		KitchenUseCase:   kitchenUseCase,
+		ModifierUseCase:  NewModifierUseCase(repo.ModifierRepo, repo.ProductRepo, repo.CategoryRepo, logger),
+		RecipeUseCase:    NewRecipeUseCase(repo.RecipeRepo, repo.ProductRepo, repo.InventoriesRepo, logger),
+		SupplierUseCase:  NewSupplierUseCase(repo.SupplierRepo, logger),
+		PurchaseOrderUseCase: NewPurchaseOrderUseCase(repo.PurchaseOrderRepo, repo.SupplierRepo, repo.InventoriesRepo, logger),
+		StocktakeUseCase:     NewStocktakeUseCase(repo.StocktakeRepo, repo.AuthRepo, logger),
+		WasteUseCase: NewWasteUseCase(repo.WasteRepo, repo.InventoriesRepo, repo.ProductRepo, repo.StaffRepo,
+			utils.Config.Inventory.ValuationMethod, logger),
+		EventBus:     eventBus,
 	}
 }
@@ -0,0 +1,427 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"aplikasi-pos-team-boolean/internal/data/entity"
+	"aplikasi-pos-team-boolean/internal/data/repository"
+	"aplikasi-pos-team-boolean/internal/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrWasteNotFound dikembalikan jika catatan waste tidak ada
+	ErrWasteNotFound = errors.New("waste tidak ditemukan")
+	// ErrInvalidWaste dikembalikan jika data waste atau filter laporan tidak valid
+	ErrInvalidWaste = errors.New("data waste tidak valid")
+)
+
+// defaultWasteReportDays adalah rentang default laporan waste jika tanggal tidak diisi
+const defaultWasteReportDays = 30
+
+type WasteUseCase interface {
+	GetAllWaste(ctx context.Context, filter dto.WasteFilter) (*dto.WasteListResponse, error)
+	GetWasteByID(ctx context.Context, id uint) (*dto.WasteResponse, error)
+	CreateWaste(ctx context.Context, req dto.WasteRequest) (*dto.WasteResponse, error)
+	GetWasteReport(ctx context.Context, filter dto.WasteReportFilter) (*dto.WasteReportResponse, error)
+}
+
+type wasteUseCase struct {
+	wasteRepo       repository.WasteRepository
+	inventoriesRepo repository.InventoriesRepository
+	productRepo     repository.ProductRepository
+	staffRepo       repository.StaffRepository
+	valuationMethod string
+	logger          *zap.Logger
+}
+
+// NewWasteUseCase membuat WasteUseCase; valuationMethod adalah metode valuasi default biaya waste
+// (fifo atau weighted_average)
+func NewWasteUseCase(
+	wasteRepo repository.WasteRepository,
+	inventoriesRepo repository.InventoriesRepository,
+	productRepo repository.ProductRepository,
+	staffRepo repository.StaffRepository,
+	valuationMethod string,
+	logger *zap.Logger,
+) WasteUseCase {
+	return &wasteUseCase{
+		wasteRepo:       wasteRepo,
+		inventoriesRepo: inventoriesRepo,
+		productRepo:     productRepo,
+		staffRepo:       staffRepo,
+		valuationMethod: resolveValuationMethod(valuationMethod, logger),
+		logger:          logger,
+	}
+}
+
+// GetAllWaste mengambil daftar waste (filter tanggal YYYY-MM-DD, inklusif)
+func (uc *wasteUseCase) GetAllWaste(ctx context.Context, filter dto.WasteFilter) (*dto.WasteListResponse, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 {
+		filter.Limit = 20
+	}
+	repoFilter := repository.WasteFilter{
+		Reason:      filter.Reason,
+		InventoryID: filter.InventoryID,
+		ProductID:   filter.ProductID,
+		Page:        filter.Page,
+		Limit:       filter.Limit,
+	}
+	start, end, err := parseWasteDates(filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	repoFilter.StartDate, repoFilter.EndDate = start, end
+
+	wastes, total, err := uc.wasteRepo.FindAll(ctx, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.WasteResponse, 0, len(wastes))
+	for i := range wastes {
+		responses = append(responses, uc.toWasteResponse(&wastes[i]))
+	}
+
+	totalPages := int(total) / filter.Limit
+	if int(total)%filter.Limit != 0 {
+		totalPages++
+	}
+	return &dto.WasteListResponse{
+		Data: responses,
+		Pagination: dto.Pagination{
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: totalPages,
+			TotalItems: int(total),
+		},
+	}, nil
+}
+
+func (uc *wasteUseCase) GetWasteByID(ctx context.Context, id uint) (*dto.WasteResponse, error) {
+	waste, err := uc.wasteRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: waste_id %d", ErrWasteNotFound, id)
+		}
+		return nil, err
+	}
+
+	response := uc.toWasteResponse(waste)
+	return &response, nil
+}
+
+// CreateWaste mencatat waste inventory atau produk dan mengurangi stoknya
+func (uc *wasteUseCase) CreateWaste(ctx context.Context, req dto.WasteRequest) (*dto.WasteResponse, error) {
+	uc.logger.Info("Creating waste log",
+		zap.String("reason", req.Reason),
+		zap.Float64("quantity", req.Quantity),
+		zap.String("unit", req.Unit),
+		zap.Uint("recorded_by", req.RecordedBy))
+
+	if (req.InventoryID == nil) == (req.ProductID == nil) {
+		return nil, fmt.Errorf("%w: isi salah satu dari inventory_id atau product_id", ErrInvalidWaste)
+	}
+
+	waste := &entity.WasteLog{
+		Reason:     req.Reason,
+		StaffID:    req.StaffID,
+		PhotoURL:   req.PhotoURL,
+		Note:       req.Note,
+		RecordedBy: changedBy(req.RecordedBy),
+		WastedAt:   time.Now(),
+	}
+	if req.WastedAt != nil {
+		if req.WastedAt.After(waste.WastedAt) {
+			return nil, fmt.Errorf("%w: wasted_at tidak boleh di masa depan", ErrInvalidWaste)
+		}
+		waste.WastedAt = *req.WastedAt
+	}
+
+	if req.StaffID != nil {
+		if _, err := uc.staffRepo.Detail(ctx, *req.StaffID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: staff_id %d tidak ditemukan", ErrInvalidWaste, *req.StaffID)
+			}
+			return nil, err
+		}
+	}
+
+	var err error
+	if req.InventoryID != nil {
+		err = uc.applyInventoryWaste(ctx, waste, req)
+	} else {
+		err = uc.applyProductWaste(ctx, waste, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.wasteRepo.Create(ctx, waste); err != nil {
+		return nil, err
+	}
+	return uc.GetWasteByID(ctx, waste.ID)
+}
+
+// applyInventoryWaste mengisi inventory waste dan mengkonversi quantity ke satuan inventory
+func (uc *wasteUseCase) applyInventoryWaste(ctx context.Context, waste *entity.WasteLog, req dto.WasteRequest) error {
+	if req.VariantID != nil {
+		return fmt.Errorf("%w: variant_id hanya untuk waste produk", ErrInvalidWaste)
+	}
+
+	inventory, err := uc.inventoriesRepo.FindByID(ctx, *req.InventoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: inventory_id %d tidak ditemukan", ErrInvalidWaste, *req.InventoryID)
+		}
+		return err
+	}
+
+	quantity, err := inventory.ToStockUnit(req.Quantity, req.Unit)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWaste, err)
+	}
+	quantity = math.Round(quantity*1000) / 1000
+	if quantity <= 0 {
+		return fmt.Errorf("%w: quantity terlalu kecil untuk satuan %s", ErrInvalidWaste, inventory.Unit)
+	}
+
+	waste.InventoryID = &inventory.ID
+	waste.Quantity = quantity
+	waste.Unit = inventory.Unit
+	return nil
+}
+
+// applyProductWaste mengisi produk/varian waste; quantity adalah jumlah porsi
+func (uc *wasteUseCase) applyProductWaste(ctx context.Context, waste *entity.WasteLog, req dto.WasteRequest) error {
+	if req.Quantity != math.Trunc(req.Quantity) {
+		return fmt.Errorf("%w: quantity waste produk harus bilangan bulat", ErrInvalidWaste)
+	}
+	if req.Unit != "" && req.Unit != entity.WasteUnitPortion {
+		return fmt.Errorf("%w: satuan waste produk adalah %s", ErrInvalidWaste, entity.WasteUnitPortion)
+	}
+
+	product, err := uc.productRepo.Detail(ctx, *req.ProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: product_id %d tidak ditemukan", ErrInvalidWaste, *req.ProductID)
+		}
+		return err
+	}
+	if product.Type == entity.ProductTypeBundle {
+		return fmt.Errorf("%w: %s adalah bundle, catat waste per komponen", ErrInvalidWaste, product.ProductName)
+	}
+
+	hasVariants := false
+	variantFound := false
+	for _, v := range product.Variants {
+		if v.DeletedAt.Valid {
+			continue
+		}
+		hasVariants = true
+		if req.VariantID != nil && v.ID == *req.VariantID {
+			variantFound = true
+		}
+	}
+	switch {
+	case req.VariantID == nil && hasVariants:
+		return fmt.Errorf("%w: variant_id wajib untuk %s", ErrInvalidWaste, product.ProductName)
+	case req.VariantID != nil && !variantFound:
+		return fmt.Errorf("%w: variant_id %d bukan milik %s", ErrInvalidWaste, *req.VariantID, product.ProductName)
+	}
+
+	waste.ProductID = &product.ID
+	waste.VariantID = req.VariantID
+	waste.Quantity = req.Quantity
+	waste.Unit = entity.WasteUnitPortion
+	return nil
+}
+
+// GetWasteReport membuat laporan waste per periode, alasan, dan item yang dinilai dengan harga pokok,
+// beserta harga pokok penjualan pada periode yang sama sebagai pembanding
+func (uc *wasteUseCase) GetWasteReport(ctx context.Context, filter dto.WasteReportFilter) (*dto.WasteReportResponse, error) {
+	method := uc.valuationMethod
+	if filter.Method != "" {
+		method = filter.Method
+	}
+	period := filter.Period
+	if period == "" {
+		period = "day"
+	}
+
+	start, end, err := parseWasteDates(filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if end == nil {
+		tomorrow := startOfDay(time.Now()).AddDate(0, 0, 1)
+		end = &tomorrow
+	}
+	if start == nil {
+		from := end.AddDate(0, 0, -defaultWasteReportDays)
+		start = &from
+	}
+	if !start.Before(*end) {
+		return nil, fmt.Errorf("%w: start_date tidak boleh setelah end_date", ErrInvalidWaste)
+	}
+
+	repoFilter := repository.WasteReportFilter{StartDate: *start, EndDate: *end, Period: period, Reason: filter.Reason}
+	periods, err := uc.wasteRepo.GetTotalsByPeriod(ctx, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+	reasons, err := uc.wasteRepo.GetTotalsByReason(ctx, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+	items, err := uc.wasteRepo.GetTotalsByItem(ctx, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	fifo := method == entity.ValuationFIFO
+	pick := func(fifoCost, averageCost float64) float64 {
+		if fifo {
+			return fifoCost
+		}
+		return averageCost
+	}
+
+	response := &dto.WasteReportResponse{
+		Method:    method,
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.AddDate(0, 0, -1).Format("2006-01-02"),
+		Period:    period,
+		ByPeriod:  make([]dto.WastePeriodTotal, 0, len(periods)),
+		ByReason:  make([]dto.WasteReasonTotal, 0, len(reasons)),
+		ByItem:    make([]dto.WasteItemTotal, 0, len(items)),
+	}
+
+	for _, p := range periods {
+		cost := roundAmount(pick(p.FifoCost, p.AverageCost))
+		salesCost := roundAmount(pick(p.SalesFifoCost, p.SalesAverageCost))
+		response.TotalCount += p.Count
+		response.TotalCost += cost
+		response.SalesCost += salesCost
+		response.ByPeriod = append(response.ByPeriod, dto.WastePeriodTotal{
+			Period:          p.Period.Format("2006-01-02"),
+			Count:           p.Count,
+			Cost:            cost,
+			SalesCost:       salesCost,
+			WastePercentage: percentageOf(cost, cost+salesCost),
+		})
+	}
+	response.TotalCost = roundAmount(response.TotalCost)
+	response.SalesCost = roundAmount(response.SalesCost)
+	response.WastePercentage = percentageOf(response.TotalCost, response.TotalCost+response.SalesCost)
+
+	for _, r := range reasons {
+		cost := roundAmount(pick(r.FifoCost, r.AverageCost))
+		response.ByReason = append(response.ByReason, dto.WasteReasonTotal{
+			Reason:     r.Reason,
+			Count:      r.Count,
+			Cost:       cost,
+			Percentage: percentageOf(cost, response.TotalCost),
+		})
+	}
+
+	for _, item := range items {
+		response.ByItem = append(response.ByItem, dto.WasteItemTotal{
+			InventoryID: item.InventoryID,
+			ProductID:   item.ProductID,
+			Name:        item.Name,
+			Unit:        item.Unit,
+			Quantity:    item.Quantity,
+			Count:       item.Count,
+			Cost:        roundAmount(pick(item.FifoCost, item.AverageCost)),
+		})
+	}
+	sort.SliceStable(response.ByItem, func(i, j int) bool { return response.ByItem[i].Cost > response.ByItem[j].Cost })
+
+	return response, nil
+}
+
+// toWasteResponse mengkonversi entity waste ke response DTO
+func (uc *wasteUseCase) toWasteResponse(waste *entity.WasteLog) dto.WasteResponse {
+	response := dto.WasteResponse{
+		ID:          waste.ID,
+		InventoryID: waste.InventoryID,
+		ProductID:   waste.ProductID,
+		VariantID:   waste.VariantID,
+		Reason:      waste.Reason,
+		Quantity:    waste.Quantity,
+		Unit:        waste.Unit,
+		StaffID:     waste.StaffID,
+		PhotoURL:    waste.PhotoURL,
+		Note:        waste.Note,
+		RecordedBy:  waste.RecordedBy,
+		FifoCost:    waste.FifoCost,
+		AverageCost: waste.AverageCost,
+		WastedAt:    waste.WastedAt,
+		CreatedAt:   waste.CreatedAt,
+	}
+	response.Cost = waste.AverageCost
+	if uc.valuationMethod == entity.ValuationFIFO {
+		response.Cost = waste.FifoCost
+	}
+	switch {
+	case waste.Inventory != nil:
+		response.ItemName = waste.Inventory.Name
+	case waste.Product != nil:
+		response.ItemName = waste.Product.ProductName
+	}
+	if waste.Variant != nil {
+		response.VariantName = waste.Variant.Name
+	}
+	if waste.Staff != nil {
+		response.StaffName = waste.Staff.FullName
+	}
+	return response
+}
+
+// parseWasteDates mengubah filter tanggal YYYY-MM-DD (inklusif) menjadi rentang [start, end)
+func parseWasteDates(startDate, endDate string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if startDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: start_date harus berformat YYYY-MM-DD", ErrInvalidWaste)
+		}
+		start = &t
+	}
+	if endDate != "" {
+		t, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: end_date harus berformat YYYY-MM-DD", ErrInvalidWaste)
+		}
+		t = t.AddDate(0, 0, 1)
+		end = &t
+	}
+	if start != nil && end != nil && !start.Before(*end) {
+		return nil, nil, fmt.Errorf("%w: start_date tidak boleh setelah end_date", ErrInvalidWaste)
+	}
+	return start, end, nil
+}
+
+// roundAmount membulatkan nilai uang ke 2 desimal
+func roundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// percentageOf mengembalikan part / total x 100 (2 desimal), atau 0 jika total 0
+func percentageOf(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(part/total*10000) / 100
+}
@@ -1,6 +1,9 @@
 package wire
 
 import (
+	"context"
+	"time"
+
 	"aplikasi-pos-team-boolean/internal/adaptor"
 	"aplikasi-pos-team-boolean/internal/data/repository"
 	"aplikasi-pos-team-boolean/internal/usecase"
@@ -12,8 +15,11 @@ import (
 	"gorm.io/gorm"
 )
 
-// InitializeApp membuat dan mengkonfigurasi aplikasi dengan semua dependencies
-func InitializeApp(db *gorm.DB, logger *zap.Logger) *gin.Engine {
+// InitializeApp membuat dan mengkonfigurasi aplikasi dengan semua dependencies.
+// Background job (sinkronisasi reservasi meja) berjalan sampai ctx dibatalkan; stream notifikasi
+// ditutup saat ctx dibatalkan. Fungsi close yang dikembalikan dipanggil setelah server HTTP berhenti
+// untuk menunggu handler event domain yang masih berjalan.
+func InitializeApp(ctx context.Context, db *gorm.DB, logger *zap.Logger) (*gin.Engine, func(context.Context) error) {
 	// Setup Gin with default middleware
 	router := gin.Default()
 
@@ -26,17 +32,28 @@ func InitializeApp(db *gorm.DB, logger *zap.Logger) *gin.Engine {
 	// Setup use cases with UseCase struct (embedding)
 	uc := usecase.NewUseCase(&repo, logger, db)
 
+	// Jalankan background job
+	go uc.TableUseCase.RunReservationSync(ctx, time.Minute)
+	go uc.InventoriesUsecase.RunExpiryAlerts(ctx, 24*time.Hour)
+	go uc.InventoriesUsecase.RunLowStockAlerts(ctx, time.Duration(utils.Config.Inventory.LowStockCheckMinutes)*time.Minute)
+
+	// Tutup semua stream notifikasi saat shutdown agar koneksi SSE tidak menahan server
+	go func() {
+		<-ctx.Done()
+		uc.NotificationUseCase.CloseStreams()
+	}()
+
 	// Setup adaptor
 	adaptorInstance := adaptor.NewAdaptor(uc, logger)
 
 	// Setup routes
-	setupRoutes(router, adaptorInstance.AuthAdaptor, adaptorInstance.AdminAdaptor, adaptorInstance.InventoriesAdaptor, adaptorInstance.StaffAdaptor, adaptorInstance.OrderAdaptor, adaptorInstance.CategoryAdaptor, adaptorInstance.ProductAdaptor, adaptorInstance.RevenueAdaptor, adaptorInstance.ReservationsAdaptor, adaptorInstance.DashboardAdaptor, uc.DashboardUseCase, adaptorInstance.NotificationAdaptor, logger)
+	setupRoutes(router, adaptorInstance.AuthAdaptor, adaptorInstance.AdminAdaptor, adaptorInstance.InventoriesAdaptor, adaptorInstance.StaffAdaptor, adaptorInstance.OrderAdaptor, adaptorInstance.CategoryAdaptor, adaptorInstance.ProductAdaptor, adaptorInstance.RevenueAdaptor, adaptorInstance.ReservationsAdaptor, adaptorInstance.DashboardAdaptor, uc.DashboardUseCase, adaptorInstance.NotificationAdaptor, uc.NotificationUseCase, adaptorInstance.TableAdaptor, adaptorInstance.TaxAdaptor, adaptorInstance.PromotionAdaptor, adaptorInstance.KitchenAdaptor, uc.KitchenUseCase, adaptorInstance.ModifierAdaptor, adaptorInstance.RecipeAdaptor, adaptorInstance.SupplierAdaptor, adaptorInstance.PurchaseOrderAdaptor, adaptorInstance.StocktakeAdaptor, adaptorInstance.WasteAdaptor, logger)
 
-	return router
+	return router, uc.EventBus.Close
 }
 
 // setupRoutes mengatur semua routing untuk aplikasi
-func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHandler *adaptor.AdminAdaptor, inventoriesHandler *adaptor.InventoriesAdaptor, staffHandler *adaptor.StaffAdaptor, orderHandler *adaptor.OrderAdaptor, categoryHandler *adaptor.CategoryAdaptor, productHandler *adaptor.ProductAdaptor, revenueHandler *adaptor.RevenueAdaptor, reservationsHandler *adaptor.ReservationsAdaptor, dashboardHandler adaptor.DashboardHandler, dashboardUC usecase.DashboardUseCase, notificationHandler *adaptor.NotificationAdaptor, logger *zap.Logger) {
+func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHandler *adaptor.AdminAdaptor, inventoriesHandler *adaptor.InventoriesAdaptor, staffHandler *adaptor.StaffAdaptor, orderHandler *adaptor.OrderAdaptor, categoryHandler *adaptor.CategoryAdaptor, productHandler *adaptor.ProductAdaptor, revenueHandler *adaptor.RevenueAdaptor, reservationsHandler *adaptor.ReservationsAdaptor, dashboardHandler adaptor.DashboardHandler, dashboardUC usecase.DashboardUseCase, notificationHandler *adaptor.NotificationAdaptor, notificationUC usecase.NotificationUseCase, tableHandler *adaptor.TableAdaptor, taxHandler *adaptor.TaxAdaptor, promotionHandler *adaptor.PromotionAdaptor, kitchenHandler *adaptor.KitchenAdaptor, kitchenUC usecase.KitchenUseCase, modifierHandler *adaptor.ModifierAdaptor, recipeHandler *adaptor.RecipeAdaptor, supplierHandler *adaptor.SupplierAdaptor, purchaseOrderHandler *adaptor.PurchaseOrderAdaptor, stocktakeHandler *adaptor.StocktakeAdaptor, wasteHandler *adaptor.WasteAdaptor, logger *zap.Logger) {
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		utils.ResponseSuccess(c.Writer, 200, "Server is running", map[string]string{
@@ -46,6 +63,8 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 
 	// Inisialisasi websocket dashboard handler
 	dashboardWsHandler := adaptor.NewDashboardWebsocketHandler(dashboardUC, logger)
+	kitchenWsHandler := adaptor.NewKitchenWebsocketHandler(kitchenUC, logger)
+	notificationStreamHandler := adaptor.NewNotificationStreamHandler(notificationUC, logger)
 
 	// API v1 group
 	v1 := router.Group("/api/v1")
@@ -102,7 +121,7 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 			inventories.GET("", inventoriesHandler.GetAllInventories)
 
 			// 2. Get inventories dengan filter (semua filter masuk di query params)
-			// Filter: status, category, stock, unit, min_qty, max_qty, min_price, max_price
+			// Filter: status, category, stock, unit, min_qty, max_qty, min_price, max_price, expiring_within
 			inventories.GET("/filter", inventoriesHandler.GetInventoryByFilter)
 
 			// 3. Create inventory
@@ -113,6 +132,18 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 
 			// 5. Delete inventory
 			inventories.DELETE("/:id", inventoriesHandler.DeleteInventory)
+
+			// 6. Get riwayat pergerakan stok (query param: type, start_date, end_date, page, limit)
+			inventories.GET("/:id/movements", inventoriesHandler.GetStockMovements)
+
+			// 7. Get laporan nilai persediaan (query param: method=fifo|weighted_average, category)
+			inventories.GET("/valuation", inventoriesHandler.GetValuationReport)
+
+			// 8. Get batch/lot inventory urut FEFO (query param: include_empty)
+			inventories.GET("/:id/batches", inventoriesHandler.GetBatches)
+
+			// 9. Get katalog satuan dan faktor konversi (kemasan per item ada di pack_sizes inventory)
+			inventories.GET("/units", inventoriesHandler.GetUnitCatalog)
 		}
 
 		// Staff routes
@@ -137,8 +168,9 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 			staff.DELETE("/:id", staffHandler.Delete)
 		}
 
-		// Order routes
+		// Order routes (protected, persetujuan manager diambil dari user login)
 		order := v1.Group("/orders")
+		order.Use(middleware.AuthMiddleware(logger))
 		{
 			// 1. GET all orders
 			order.GET("", orderHandler.GetAllOrders)
@@ -152,6 +184,45 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 			// 4. DELETE order
 			order.DELETE("/:id", orderHandler.DeleteOrder)
 
+			// 4a. POST Cancel order, sama dengan void (butuh kode alasan dan persetujuan manager)
+			order.POST("/:id/cancel", orderHandler.VoidOrder)
+
+			// 4b. PATCH Ubah status order (pending -> in_kitchen -> served -> paid); batal lewat 4a/4j, refund lewat 4k
+			order.PATCH("/:id/status", orderHandler.ChangeOrderStatus)
+
+			// 4c. GET Riwayat status order
+			order.GET("/:id/status-history", orderHandler.GetOrderStatusHistory)
+
+			// 4d. POST Bayar order, bisa beberapa tender sekaligus (tunai + QRIS, dst)
+			order.POST("/:id/payments", orderHandler.AddPayments)
+
+			// 4e. GET Daftar pembayaran order
+			order.GET("/:id/payments", orderHandler.GetOrderPayments)
+
+			// 4f. POST Split bill: pindahkan item terpilih ke order baru
+			order.POST("/:id/split", orderHandler.SplitOrderItems)
+
+			// 4g. POST Split bill rata menjadi beberapa bagian
+			order.POST("/:id/split-even", orderHandler.SplitOrderEven)
+
+			// 4h. POST Merge bill: gabungkan order lain (bisa beda meja) ke order ini
+			order.POST("/:id/merge", orderHandler.MergeOrders)
+
+			// 4i. GET Jejak audit split/merge order
+			order.GET("/:id/links", orderHandler.GetOrderLinks)
+
+			// 4j. POST Void order yang belum dibayar (kode alasan + persetujuan manager)
+			order.POST("/:id/void", orderHandler.VoidOrder)
+
+			// 4k. POST Refund order yang sudah dibayar, penuh atau per item (persetujuan manager)
+			order.POST("/:id/refunds", orderHandler.RefundOrder)
+
+			// 4l. GET Riwayat void/refund order
+			order.GET("/:id/refunds", orderHandler.GetOrderRefunds)
+
+			// 4m. GET Receipt order (?format=text|html|escpos, ?copy=true untuk cetak ulang)
+			order.GET("/:id/receipt", orderHandler.GetOrderReceipt)
+
 			// 5. GET all tables
 			order.GET("/tables", orderHandler.GetAllTables)
 
@@ -173,6 +244,9 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 
 			// 3. DELETE notification
 			notifications.DELETE("/:id", notificationHandler.DeleteNotification)
+
+			// 4. GET stream notifikasi real-time (websocket atau SSE)
+			notifications.GET("/stream", middleware.StreamAuthMiddleware(logger), notificationStreamHandler.ServeStream)
 		}
 
 		// Category routes (Menu)
@@ -214,6 +288,12 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 
 			// 6. DELETE product
 			products.DELETE("/:id", productHandler.Delete)
+
+			// 7. GET resep (bahan inventory) produk
+			products.GET("/:id/recipe", recipeHandler.GetRecipe)
+
+			// 8. PUT Ganti resep produk
+			products.PUT("/:id/recipe", recipeHandler.SaveRecipe)
 		}
 
 		// Dashboard routes
@@ -266,6 +346,216 @@ func setupRoutes(router *gin.Engine, authHandler *adaptor.AuthAdaptor, adminHand
 			// 5. DELETE reservation
 			reservations.DELETE("/:id", reservationsHandler.DeleteReservation)
 		}
+
+		// Table routes
+		// Status occupied/available diatur otomatis oleh order dine-in, reserved oleh reservasi
+		tables := v1.Group("/tables")
+		{
+			// 1. GET all tables (optional query param: status)
+			tables.GET("", tableHandler.GetAllTables)
+
+			// 2. GET table by ID
+			tables.GET("/:id", tableHandler.GetTableByID)
+
+			// 3. POST Create table
+			tables.POST("", tableHandler.CreateTable)
+
+			// 4. PUT Update table (number, capacity)
+			tables.PUT("/:id", tableHandler.UpdateTable)
+
+			// 5. DELETE table
+			tables.DELETE("/:id", tableHandler.DeleteTable)
+
+			// 6. PATCH Override status meja secara manual (available, occupied, reserved, out_of_service)
+			tables.PATCH("/:id/status", tableHandler.UpdateTableStatus)
+		}
+
+		// Outlet routes
+		outlets := v1.Group("/outlets")
+		{
+			// 1. GET all outlets
+			outlets.GET("", taxHandler.GetAllOutlets)
+
+			// 2. POST Create outlet
+			outlets.POST("", taxHandler.CreateOutlet)
+
+			// 3. PUT Update outlet (prices_include_tax mengatur harga inclusive/exclusive)
+			outlets.PUT("/:id", taxHandler.UpdateOutlet)
+		}
+
+		// Tax rule routes (PB1, PPN, service charge per outlet/kategori)
+		taxRules := v1.Group("/tax-rules")
+		{
+			// 1. GET tax rules (optional query param: outlet_id, category_id)
+			taxRules.GET("", taxHandler.GetTaxRules)
+
+			// 2. POST Create tax rule
+			taxRules.POST("", taxHandler.CreateTaxRule)
+
+			// 3. PUT Update tax rule
+			taxRules.PUT("/:id", taxHandler.UpdateTaxRule)
+
+			// 4. DELETE tax rule
+			taxRules.DELETE("/:id", taxHandler.DeleteTaxRule)
+		}
+
+		// Promotion routes (diskon otomatis, kode promo, happy hour)
+		promotions := v1.Group("/promotions")
+		{
+			// 1. GET all promotions (optional query param: active=true)
+			promotions.GET("", promotionHandler.GetAllPromotions)
+
+			// 2. GET promotion by ID
+			promotions.GET("/:id", promotionHandler.GetPromotionByID)
+
+			// 3. POST Create promotion
+			promotions.POST("", promotionHandler.CreatePromotion)
+
+			// 4. PUT Update promotion
+			promotions.PUT("/:id", promotionHandler.UpdatePromotion)
+
+			// 5. DELETE promotion
+			promotions.DELETE("/:id", promotionHandler.DeletePromotion)
+		}
+
+		// Kitchen display routes (station dapur & tiket per order)
+		kitchen := v1.Group("/kitchen")
+		{
+			// 1. GET all stations
+			kitchen.GET("/stations", kitchenHandler.GetAllStations)
+
+			// 2. POST Create station (beserta kategori yang diarahkan)
+			kitchen.POST("/stations", kitchenHandler.CreateStation)
+
+			// 3. PUT Update station
+			kitchen.PUT("/stations/:id", kitchenHandler.UpdateStation)
+
+			// 4. DELETE station
+			kitchen.DELETE("/stations/:id", kitchenHandler.DeleteStation)
+
+			// 5. GET tiket dapur (optional query param: station_id, status)
+			kitchen.GET("/tickets", kitchenHandler.GetTickets)
+
+			// 6. POST tandai semua item tiket ready
+			kitchen.POST("/tickets/:id/ready", kitchenHandler.ReadyTicket)
+
+			// 7. POST bump item (queued -> cooking -> ready)
+			kitchen.POST("/items/:id/bump", kitchenHandler.BumpItem)
+
+			// 8. Websocket realtime tiket dapur (optional query param: station_id)
+			kitchen.GET("/ws", kitchenWsHandler.ServeWs)
+		}
+
+		// Modifier group routes (add-on, ukuran, tanpa bahan untuk item order)
+		modifierGroups := v1.Group("/modifier-groups")
+		{
+			// 1. GET all modifier groups (optional query param: product_id untuk group yang berlaku di produk)
+			modifierGroups.GET("", modifierHandler.GetAllModifierGroups)
+
+			// 2. GET modifier group by ID
+			modifierGroups.GET("/:id", modifierHandler.GetModifierGroupByID)
+
+			// 3. POST Create modifier group
+			modifierGroups.POST("", modifierHandler.CreateModifierGroup)
+
+			// 4. PUT Update modifier group
+			modifierGroups.PUT("/:id", modifierHandler.UpdateModifierGroup)
+
+			// 5. DELETE modifier group
+			modifierGroups.DELETE("/:id", modifierHandler.DeleteModifierGroup)
+		}
+
+		// Supplier routes (pemasok barang inventory)
+		suppliers := v1.Group("/suppliers")
+		{
+			// 1. GET all suppliers (optional query param: active=true)
+			suppliers.GET("", supplierHandler.GetAllSuppliers)
+
+			// 2. GET supplier by ID
+			suppliers.GET("/:id", supplierHandler.GetSupplierByID)
+
+			// 3. POST Create supplier
+			suppliers.POST("", supplierHandler.CreateSupplier)
+
+			// 4. PUT Update supplier
+			suppliers.PUT("/:id", supplierHandler.UpdateSupplier)
+
+			// 5. DELETE supplier
+			suppliers.DELETE("/:id", supplierHandler.DeleteSupplier)
+		}
+
+		// Purchase order routes (draft -> sent -> partially_received -> received)
+		purchaseOrders := v1.Group("/purchase-orders")
+		{
+			// 1. GET all purchase orders (optional query param: status, supplier_id)
+			purchaseOrders.GET("", purchaseOrderHandler.GetAllPurchaseOrders)
+
+			// 2. GET saran pemesanan ulang untuk inventory di bawah min stock
+			purchaseOrders.GET("/reorder-suggestions", purchaseOrderHandler.GetReorderSuggestions)
+
+			// 3. GET purchase order by ID (termasuk goods received note)
+			purchaseOrders.GET("/:id", purchaseOrderHandler.GetPurchaseOrderByID)
+
+			// 4. POST Create purchase order (draft)
+			purchaseOrders.POST("", purchaseOrderHandler.CreatePurchaseOrder)
+
+			// 5. PUT Update purchase order draft
+			purchaseOrders.PUT("/:id", purchaseOrderHandler.UpdatePurchaseOrder)
+
+			// 6. DELETE purchase order draft
+			purchaseOrders.DELETE("/:id", purchaseOrderHandler.DeletePurchaseOrder)
+
+			// 7. POST Kirim purchase order ke supplier
+			purchaseOrders.POST("/:id/send", purchaseOrderHandler.SendPurchaseOrder)
+
+			// 8. POST Batalkan purchase order
+			purchaseOrders.POST("/:id/cancel", purchaseOrderHandler.CancelPurchaseOrder)
+
+			// 9. POST Catat penerimaan barang (menambah stok inventory)
+			purchaseOrders.POST("/:id/receive", purchaseOrderHandler.ReceivePurchaseOrder)
+		}
+
+		// Stocktake routes (protected; counting -> approved / cancelled)
+		stocktakes := v1.Group("/stocktakes")
+		stocktakes.Use(middleware.AuthMiddleware(logger))
+		{
+			// 1. GET all stocktakes (optional query param: status)
+			stocktakes.GET("", stocktakeHandler.GetAllStocktakes)
+
+			// 2. GET stocktake by ID (termasuk hasil hitung setiap counter)
+			stocktakes.GET("/:id", stocktakeHandler.GetStocktakeByID)
+
+			// 3. GET laporan selisih (optional query param: format=csv)
+			stocktakes.GET("/:id/variance", stocktakeHandler.GetVarianceReport)
+
+			// 4. POST Buka sesi stocktake (snapshot quantity inventory)
+			stocktakes.POST("", stocktakeHandler.CreateStocktake)
+
+			// 5. POST Catat hasil hitung satu counter
+			stocktakes.POST("/:id/counts", stocktakeHandler.RecordCounts)
+
+			// 6. POST Setujui stocktake (manager) dan posting adjustment
+			stocktakes.POST("/:id/approve", stocktakeHandler.ApproveStocktake)
+
+			// 7. POST Batalkan stocktake
+			stocktakes.POST("/:id/cancel", stocktakeHandler.CancelStocktake)
+		}
+
+		// Waste routes (barang terbuang inventory / produk, mengurangi stok)
+		waste := v1.Group("/waste")
+		{
+			// 1. GET all waste (optional query params: reason, inventory_id, product_id, start_date, end_date, page, limit)
+			waste.GET("", wasteHandler.GetAllWaste)
+
+			// 2. GET laporan waste dinilai harga pokok (optional query params: start_date, end_date, period, reason, method)
+			waste.GET("/report", wasteHandler.GetWasteReport)
+
+			// 3. GET waste by ID
+			waste.GET("/:id", wasteHandler.GetWasteByID)
+
+			// 4. POST Catat waste inventory atau produk
+			waste.POST("", wasteHandler.CreateWaste)
+		}
 	}
 
 	logger.Info("Routes registered successfully")
@@ -66,8 +66,12 @@ func main() {
 		}
 	}
 
+	// Context aplikasi untuk background job, dibatalkan saat shutdown
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+
 	// Initialize app dengan dependency injection
-	router := wire.InitializeApp(db, logger)
+	router, closeApp := wire.InitializeApp(appCtx, db, logger)
 
 	// Setup HTTP Server
 	port := config.Port
@@ -102,6 +106,9 @@ func main() {
 	logger.Info("Shutting down server...")
 	fmt.Println("\nGraceful shutdown initiated...")
 
+	// Hentikan background job
+	stopApp()
+
 	// Timeout 5 detik untuk graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -111,6 +118,11 @@ func main() {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Tunggu handler event (notifikasi) yang masih berjalan
+	if err := closeApp(ctx); err != nil {
+		logger.Warn("Event handlers did not finish before shutdown", zap.Error(err))
+	}
+
 	logger.Info("Server exited successfully")
 	fmt.Println("Server stopped gracefully")
 }
@@ -30,14 +30,52 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.OTP{},
 		&entity.Staff{},
 		&entity.Inventories{},
+		&entity.InventoryPackSize{},
+		&entity.StockMovement{},
+		&entity.CostLayer{},
+		&entity.InventoryBatch{},
+		&entity.Supplier{},
+		&entity.PurchaseOrder{},
+		&entity.PurchaseOrderItem{},
+		&entity.GoodsReceipt{},
+		&entity.GoodsReceiptItem{},
+		&entity.Stocktake{},
+		&entity.StocktakeLine{},
+		&entity.StocktakeCount{},
 		&entity.Table{},
 		&entity.PaymentMethod{},
 		&entity.Reservations{},
 		&entity.Order{},
 		&entity.OrderItem{},
+		&entity.OrderItemModifier{},
+		&entity.OrderItemComponent{},
+		&entity.OrderStatusHistory{},
+		&entity.OrderTax{},
+		&entity.OrderPayment{},
+		&entity.OrderLink{},
+		&entity.OrderRefund{},
+		&entity.OrderRefundItem{},
+		&entity.SaleCost{},
+		&entity.Outlet{},
+		&entity.TaxRule{},
+		&entity.Promotion{},
+		&entity.OrderDiscount{},
 		&entity.Notification{},
 		&entity.Category{},
 		&entity.Product{},
+		&entity.ProductVariant{},
+		&entity.BundleSlot{},
+		&entity.BundleSlotOption{},
+		&entity.RecipeItem{},
+		&entity.WasteLog{},
+		&entity.KitchenStation{},
+		&entity.KitchenStationCategory{},
+		&entity.KitchenTicket{},
+		&entity.KitchenTicketItem{},
+		&entity.ModifierGroup{},
+		&entity.ModifierOption{},
+		&entity.ProductModifierGroup{},
+		&entity.CategoryModifierGroup{},
 		// Tambahkan entity lain jika ada
 	}
 
@@ -45,10 +83,208 @@ func AutoMigrate(db *gorm.DB) error {
 		return fmt.Errorf("failed to auto migrate: %w", err)
 	}
 
+	// Order tanpa outlet memakai outlet default, jadi outlet tersebut harus selalu ada
+	if err := ensureDefaultOutlet(db); err != nil {
+		return fmt.Errorf("failed to create default outlet: %w", err)
+	}
+
+	// Metode pembayaran tunai yang sudah ada sebelum kolom is_cash ditambahkan
+	if err := db.Model(&entity.PaymentMethod{}).
+		Where("LOWER(name) IN ? AND is_cash = ?", []string{"cash", "tunai"}, false).
+		Update("is_cash", true).Error; err != nil {
+		return fmt.Errorf("failed to mark cash payment methods: %w", err)
+	}
+
+	if err := backfillOpeningStockMovements(db); err != nil {
+		return fmt.Errorf("failed to backfill stock movements: %w", err)
+	}
+	if err := backfillInventoryBatches(db); err != nil {
+		return fmt.Errorf("failed to backfill inventory batches: %w", err)
+	}
+	if err := backfillOpeningCosts(db); err != nil {
+		return fmt.Errorf("failed to backfill opening costs: %w", err)
+	}
+	if err := backfillOrderSubtotals(db); err != nil {
+		return fmt.Errorf("failed to backfill order subtotals: %w", err)
+	}
+	if err := backfillOrderPayments(db); err != nil {
+		return fmt.Errorf("failed to backfill order payments: %w", err)
+	}
+
 	log.Println("Database auto migration completed successfully!")
 	return nil
 }
 
+// backfillOpeningStockMovements mencatat quantity inventory yang belum punya riwayat pergerakan
+// stok sebagai adjustment stok awal, sehingga saldo ledger selalu sama dengan quantity inventory
+func backfillOpeningStockMovements(db *gorm.DB) error {
+	result := db.Exec(`
+		INSERT INTO stock_movements (inventory_id, type, delta, balance, reference_type, note, created_at)
+		SELECT i.id, ?, i.quantity, i.quantity, 'inventory', 'Stok awal', i.created_at
+		FROM inventories i
+		WHERE i.quantity <> 0
+		AND NOT EXISTS (SELECT 1 FROM stock_movements sm WHERE sm.inventory_id = i.id)`,
+		entity.StockMovementAdjustment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("   Backfilled %d opening stock movements", result.RowsAffected)
+	}
+	return nil
+}
+
+// backfillInventoryBatches membuat satu batch tanpa tanggal kedaluwarsa untuk stok inventory yang
+// belum punya batch (stok lama sebelum batch dicatat) agar konsumsi FEFO tetap seimbang
+func backfillInventoryBatches(db *gorm.DB) error {
+	result := db.Exec(`
+		INSERT INTO inventory_batches (inventory_id, quantity, remaining_quantity, created_at)
+		SELECT i.id, i.quantity, i.quantity, i.created_at
+		FROM inventories i
+		WHERE i.quantity > 0
+		AND NOT EXISTS (SELECT 1 FROM inventory_batches b WHERE b.inventory_id = i.id)`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("   Backfilled %d inventory batches", result.RowsAffected)
+	}
+	return nil
+}
+
+// backfillOpeningCosts memberi harga pokok pada stok inventory lama (ada sebelum harga pokok dicatat)
+// agar penjualannya tidak tercatat dengan COGS nol. AverageCost yang masih nol diisi dari harga
+// pembelian terakhir (harga penerimaan barang, atau harga purchase order jika belum pernah diterima),
+// lalu stok yang belum punya cost layer mendapat satu layer pembuka senilai AverageCost tersebut yang
+// ditautkan ke pergerakan stok pertamanya. Inventory tanpa riwayat pembelian tetap bernilai nol.
+func backfillOpeningCosts(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			UPDATE inventories i SET average_cost = lp.unit_cost
+			FROM (
+				SELECT DISTINCT ON (inventory_id) inventory_id, unit_cost
+				FROM (
+					SELECT gri.inventory_id, gri.unit_cost, gri.created_at, 1 AS source
+					FROM goods_receipt_items gri WHERE gri.unit_cost > 0
+					UNION ALL
+					SELECT poi.inventory_id, poi.unit_cost, poi.created_at, 2 AS source
+					FROM purchase_order_items poi WHERE poi.unit_cost > 0
+				) prices
+				ORDER BY inventory_id, source, created_at DESC
+			) lp
+			WHERE lp.inventory_id = i.id AND i.average_cost = 0 AND i.quantity > 0
+			AND NOT EXISTS (SELECT 1 FROM cost_layers cl WHERE cl.inventory_id = i.id)`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("   Backfilled average cost of %d inventories", result.RowsAffected)
+		}
+
+		result = tx.Exec(`
+			INSERT INTO cost_layers (inventory_id, stock_movement_id, unit_cost, quantity, remaining_quantity, created_at)
+			SELECT i.id, sm.id, i.average_cost, i.quantity, i.quantity, sm.created_at
+			FROM inventories i
+			JOIN LATERAL (
+				SELECT m.id, m.created_at FROM stock_movements m
+				WHERE m.inventory_id = i.id
+				ORDER BY m.created_at, m.id
+				LIMIT 1
+			) sm ON true
+			WHERE i.quantity > 0 AND i.average_cost > 0
+			AND NOT EXISTS (SELECT 1 FROM cost_layers cl WHERE cl.inventory_id = i.id)`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("   Backfilled %d opening cost layers", result.RowsAffected)
+		}
+
+		// Pergerakan stok awal dari backfillOpeningStockMovements yang menjadi dasar layer pembuka ikut
+		// diberi nilai persediaan
+		return tx.Exec(`
+			UPDATE stock_movements sm
+			SET unit_cost = cl.unit_cost,
+				fifo_value = ROUND(sm.delta * cl.unit_cost, 2),
+				average_value = ROUND(sm.delta * cl.unit_cost, 2)
+			FROM cost_layers cl
+			WHERE cl.stock_movement_id = sm.id
+			AND sm.type = ? AND sm.reference_type = 'inventory' AND sm.note = 'Stok awal'
+			AND sm.unit_cost IS NULL AND sm.fifo_value = 0`,
+			entity.StockMovementAdjustment).Error
+	})
+}
+
+// backfillOrderSubtotals mengisi subtotal order lama (dibuat sebelum kolom subtotal ada) dari jumlah
+// subtotal item-nya, agar laporan pendapatan kotor dan diskon tidak menghitungnya sebagai nol
+func backfillOrderSubtotals(db *gorm.DB) error {
+	result := db.Exec(`
+		UPDATE orders o SET subtotal = items.subtotal
+		FROM (
+			SELECT oi.order_id, SUM(oi.subtotal) AS subtotal
+			FROM order_items oi
+			WHERE oi.deleted_at IS NULL
+			GROUP BY oi.order_id
+		) items
+		WHERE items.order_id = o.id AND o.subtotal = 0 AND items.subtotal <> 0`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("   Backfilled %d order subtotals", result.RowsAffected)
+	}
+	return nil
+}
+
+// backfillOrderPayments mencatat sisa tagihan order paid/refunded lama (ditandai paid sebelum
+// pembayaran dicatat per tender) sebagai satu pembayaran dengan metode pembayaran order, lalu
+// menyamakan paid_amount dengan total order
+func backfillOrderPayments(db *gorm.DB) error {
+	statuses := []string{entity.OrderStatusPaid, entity.OrderStatusRefunded}
+	result := db.Exec(`
+		INSERT INTO order_payments (order_id, payment_method_id, amount, tendered, change, reference, created_at)
+		SELECT o.id, o.payment_method_id, o.total_amount - o.paid_amount, o.total_amount - o.paid_amount, 0, 'Backfill', o.updated_at
+		FROM orders o
+		WHERE o.status IN ?
+		AND o.paid_amount < o.total_amount`,
+		statuses)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("   Backfilled %d order payments", result.RowsAffected)
+	}
+
+	return db.Exec(`
+		UPDATE orders SET paid_amount = total_amount
+		WHERE status IN ? AND paid_amount < total_amount`,
+		statuses).Error
+}
+
+// ensureDefaultOutlet membuat outlet default (ID 1) jika belum ada
+func ensureDefaultOutlet(db *gorm.DB) error {
+	var count int64
+	if err := db.Unscoped().Model(&entity.Outlet{}).Where("id = ?", entity.DefaultOutletID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	log.Println("   Creating default outlet...")
+	if err := db.Create(&entity.Outlet{
+		ID:            entity.DefaultOutletID,
+		Name:          "Outlet Utama",
+		ReceiptFooter: "Terima kasih atas kunjungan Anda",
+		ReceiptWidth:  58,
+	}).Error; err != nil {
+		return err
+	}
+
+	// Sinkronkan sequence karena ID diisi manual
+	return db.Exec("SELECT setval(pg_get_serial_sequence('outlets', 'id'), (SELECT MAX(id) FROM outlets))").Error
+}
+
 // fixNotificationsUserID handles the migration fix for user_id column in notifications table
 func fixNotificationsUserID(db *gorm.DB) error {
 	log.Println("   Checking notifications table for user_id issues...")
@@ -204,7 +440,7 @@ func SeedData(db *gorm.DB) error {
 	if count == 0 {
 		log.Println("   Seeding payment methods data...")
 		seedPaymentMethods := []entity.PaymentMethod{
-			{Name: "Cash"},
+			{Name: "Cash", IsCash: true},
 			{Name: "Credit Card"},
 			{Name: "Debit Card"},
 			{Name: "E-Wallet"},
@@ -225,7 +461,7 @@ func SeedData(db *gorm.DB) error {
 	if count == 0 {
 		log.Println("   Seeding payment_methods data...")
 		paymentMethods := []entity.PaymentMethod{
-			{Name: "Cash"},
+			{Name: "Cash", IsCash: true},
 			{Name: "QRIS"},
 			{Name: "Debit"},
 		}
@@ -278,6 +514,25 @@ func SeedData(db *gorm.DB) error {
 		if err := db.Create(&inventories).Error; err != nil {
 			return fmt.Errorf("failed to seed inventories: %w", err)
 		}
+		if err := backfillOpeningStockMovements(db); err != nil {
+			return fmt.Errorf("failed to seed stock movements: %w", err)
+		}
+		if err := backfillInventoryBatches(db); err != nil {
+			return fmt.Errorf("failed to seed inventory batches: %w", err)
+		}
+	}
+
+	// Seed Suppliers
+	db.Model(&entity.Supplier{}).Count(&count)
+	if count == 0 {
+		log.Println("   Seeding suppliers data...")
+		suppliers := []entity.Supplier{
+			{Name: "PT Minuman Segar", ContactName: "Budi", Phone: "081234567890", Email: "sales@minumansegar.co.id", IsActive: true},
+			{Name: "CV Sumber Pangan", ContactName: "Sari", Phone: "081298765432", Email: "order@sumberpangan.co.id", IsActive: true},
+		}
+		if err := db.Create(&suppliers).Error; err != nil {
+			return fmt.Errorf("failed to seed suppliers: %w", err)
+		}
 	}
 
 	// Seed Reservations
@@ -304,6 +559,7 @@ func SeedData(db *gorm.DB) error {
 				TableID:         1,
 				PaymentMethodID: 1,
 				CustomerName:    "John Doe",
+				Subtotal:        80000,
 				TotalAmount:     85500,
 				Tax:             5500,
 				Status:          "pending",
@@ -313,7 +569,9 @@ func SeedData(db *gorm.DB) error {
 				TableID:         2,
 				PaymentMethodID: 2,
 				CustomerName:    "Jane Smith",
+				Subtotal:        27000,
 				TotalAmount:     30000,
+				PaidAmount:      30000,
 				Tax:             3000,
 				Status:          "paid",
 			},
@@ -347,6 +605,37 @@ func SeedData(db *gorm.DB) error {
 		}
 	}
 
+	db.Model(&entity.OrderPayment{}).Count(&count)
+	if count == 0 {
+		log.Println("   Seeding order_payments data...")
+		orderPayments := []entity.OrderPayment{
+			{
+				OrderID:         2,
+				PaymentMethodID: 2,
+				Amount:          30000,
+				Tendered:        30000,
+			},
+		}
+		if err := db.Create(&orderPayments).Error; err != nil {
+			return fmt.Errorf("failed to seed order_payments: %w", err)
+		}
+	}
+
+	// Seed aturan pajak jika masih kosong: service charge opsional (nonaktif),
+	// PB1 10% dihitung setelah service charge, PPN 11% nonaktif (untuk outlet non-restoran)
+	db.Model(&entity.TaxRule{}).Count(&count)
+	if count == 0 {
+		log.Println("   Seeding tax_rules data...")
+		taxRules := []entity.TaxRule{
+			{Name: "Service Charge", Type: entity.TaxTypeServiceCharge, Rate: 5, Priority: 1, IsActive: false},
+			{Name: "PB1", Type: entity.TaxTypeTax, Rate: 10, Priority: 2, IsCompound: true, IsActive: true},
+			{Name: "PPN", Type: entity.TaxTypeTax, Rate: 11, Priority: 2, IsCompound: true, IsActive: false},
+		}
+		if err := db.Create(&taxRules).Error; err != nil {
+			return fmt.Errorf("failed to seed tax_rules: %w", err)
+		}
+	}
+
 	// Seed categories jika masih kosong
 	db.Model(&entity.Category{}).Count(&count)
 	if count == 0 {
@@ -474,6 +763,153 @@ func SeedData(db *gorm.DB) error {
 		log.Printf("   Seeded %d products", len(seedProducts))
 	}
 
+	// Seed product variants jika masih kosong (total stok varian = stok produk)
+	db.Model(&entity.ProductVariant{}).Count(&count)
+	if count == 0 {
+		var cola entity.Product
+		if err := db.Where("item_id = ?", "#22314649").First(&cola).Error; err == nil {
+			log.Println("   Seeding product variants data...")
+
+			seedVariants := []entity.ProductVariant{
+				{ProductID: cola.ID, SKU: "#22314649-01", Name: "Regular", Price: 5.00, Stock: 120},
+				{ProductID: cola.ID, SKU: "#22314649-02", Name: "Large", Price: 7.00, Stock: 80},
+			}
+			if err := db.Create(&seedVariants).Error; err != nil {
+				return fmt.Errorf("failed to seed product_variants: %w", err)
+			}
+			log.Printf("   Seeded %d product variants", len(seedVariants))
+		}
+	}
+
+	// Seed bundle jika belum ada (Burger Combo: Classic Burger + pilihan minuman)
+	db.Model(&entity.BundleSlot{}).Count(&count)
+	if count == 0 {
+		var burger, orangeJuice entity.Product
+		var colaRegular entity.ProductVariant
+		db.Where("item_id = ?", "#22314647").First(&burger)
+		db.Where("item_id = ?", "#22314650").First(&orangeJuice)
+		db.Where("sku = ?", "#22314649-01").First(&colaRegular)
+		if burger.ID != 0 && orangeJuice.ID != 0 && colaRegular.ID != 0 {
+			log.Println("   Seeding bundle products data...")
+
+			combo := entity.Product{
+				ProductImage: "/images/burger-combo.jpg",
+				ProductName:  "Burger Combo",
+				ItemID:       "#22314651",
+				Type:         entity.ProductTypeBundle,
+				CategoryID:   burger.CategoryID,
+				Price:        38.00,
+				IsAvailable:  true,
+				BundleSlots: []entity.BundleSlot{
+					{Name: "Burger", Quantity: 1, Options: []entity.BundleSlotOption{{ProductID: burger.ID}}},
+					{Name: "Drink", Quantity: 1, Options: []entity.BundleSlotOption{
+						{ProductID: colaRegular.ProductID, VariantID: &colaRegular.ID},
+						{ProductID: orangeJuice.ID},
+					}},
+				},
+			}
+			if err := db.Create(&combo).Error; err != nil {
+				return fmt.Errorf("failed to seed bundle products: %w", err)
+			}
+			log.Printf("   Seeded bundle %s with %d slots", combo.ProductName, len(combo.BundleSlots))
+		}
+	}
+
+	// Seed resep jika masih kosong (Cola memakai stok Coca Cola 1L sesuai ukuran varian)
+	db.Model(&entity.RecipeItem{}).Count(&count)
+	if count == 0 {
+		var cocaCola entity.Inventories
+		var colaRegular, colaLarge entity.ProductVariant
+		db.Where("name = ?", "Coca Cola 1L").First(&cocaCola)
+		db.Where("sku = ?", "#22314649-01").First(&colaRegular)
+		db.Where("sku = ?", "#22314649-02").First(&colaLarge)
+		if cocaCola.ID != 0 && colaRegular.ID != 0 && colaLarge.ID != 0 {
+			log.Println("   Seeding recipes data...")
+
+			seedRecipe := []entity.RecipeItem{
+				{ProductID: colaRegular.ProductID, VariantID: &colaRegular.ID, InventoryID: cocaCola.ID, Quantity: 330, Unit: "ml"},
+				{ProductID: colaLarge.ProductID, VariantID: &colaLarge.ID, InventoryID: cocaCola.ID, Quantity: 500, Unit: "ml"},
+			}
+			if err := db.Omit("Inventory").Create(&seedRecipe).Error; err != nil {
+				return fmt.Errorf("failed to seed recipe_items: %w", err)
+			}
+			log.Printf("   Seeded %d recipe items", len(seedRecipe))
+		}
+	}
+
+	// Seed kitchen stations jika masih kosong
+	db.Model(&entity.KitchenStation{}).Count(&count)
+	if count == 0 {
+		log.Println("   Seeding kitchen stations data...")
+
+		stationCategories := map[string][]string{
+			"Grill":  {"Pizza", "Burger", "Chicken", "Seafood"},
+			"Bar":    {"Beverage"},
+			"Bakery": {"Bakery"},
+		}
+		seedStations := []entity.KitchenStation{
+			{Name: "Grill", IsDefault: true},
+			{Name: "Bar"},
+			{Name: "Bakery"},
+		}
+		for i := range seedStations {
+			var categories []entity.Category
+			db.Where("category_name IN ?", stationCategories[seedStations[i].Name]).Find(&categories)
+			for _, category := range categories {
+				seedStations[i].Categories = append(seedStations[i].Categories, entity.KitchenStationCategory{CategoryID: category.ID})
+			}
+		}
+
+		if err := db.Create(&seedStations).Error; err != nil {
+			return fmt.Errorf("failed to seed kitchen_stations: %w", err)
+		}
+		log.Printf("   Seeded %d kitchen stations", len(seedStations))
+	}
+
+	// Seed modifier groups jika masih kosong
+	db.Model(&entity.ModifierGroup{}).Count(&count)
+	if count == 0 {
+		log.Println("   Seeding modifier groups data...")
+
+		var pizzaCategory, burgerCategory entity.Category
+		db.Where("category_name = ?", "Pizza").First(&pizzaCategory)
+		db.Where("category_name = ?", "Burger").First(&burgerCategory)
+
+		seedGroups := []entity.ModifierGroup{
+			{
+				Name:      "Ukuran",
+				MinSelect: 1,
+				MaxSelect: 1,
+				Options: []entity.ModifierOption{
+					{Name: "Regular", IsAvailable: true},
+					{Name: "Large", PriceDelta: 5, IsAvailable: true},
+				},
+				Categories: []entity.CategoryModifierGroup{{CategoryID: pizzaCategory.ID}},
+			},
+			{
+				Name: "Topping",
+				Options: []entity.ModifierOption{
+					{Name: "Extra Cheese", PriceDelta: 3, IsAvailable: true},
+					{Name: "Extra Patty", PriceDelta: 8, IsAvailable: true},
+				},
+				Categories: []entity.CategoryModifierGroup{{CategoryID: burgerCategory.ID}},
+			},
+			{
+				Name: "Tanpa Bahan",
+				Options: []entity.ModifierOption{
+					{Name: "No Onions", IsAvailable: true},
+					{Name: "No Pickles", IsAvailable: true},
+				},
+				Categories: []entity.CategoryModifierGroup{{CategoryID: burgerCategory.ID}},
+			},
+		}
+
+		if err := db.Create(&seedGroups).Error; err != nil {
+			return fmt.Errorf("failed to seed modifier_groups: %w", err)
+		}
+		log.Printf("   Seeded %d modifier groups", len(seedGroups))
+	}
+
 	return nil
 }
 
@@ -482,13 +918,51 @@ func DropAllTables(db *gorm.DB) error {
 	log.Println("WARNING: Dropping all tables...")
 
 	entities := []interface{}{
+		&entity.KitchenTicketItem{},
+		&entity.KitchenTicket{},
+		&entity.KitchenStationCategory{},
+		&entity.KitchenStation{},
+		&entity.CategoryModifierGroup{},
+		&entity.ProductModifierGroup{},
+		&entity.ModifierOption{},
+		&entity.ModifierGroup{},
+		&entity.BundleSlotOption{},
+		&entity.BundleSlot{},
+		&entity.WasteLog{},
+		&entity.RecipeItem{},
+		&entity.ProductVariant{},
 		&entity.Product{},
 		&entity.Category{},
+		&entity.SaleCost{},
+		&entity.OrderStatusHistory{},
+		&entity.OrderTax{},
+		&entity.OrderPayment{},
+		&entity.OrderLink{},
+		&entity.OrderRefundItem{},
+		&entity.OrderRefund{},
+		&entity.OrderDiscount{},
+		&entity.Promotion{},
+		&entity.TaxRule{},
+		&entity.Outlet{},
+		&entity.OrderItemModifier{},
+		&entity.OrderItemComponent{},
 		&entity.OrderItem{},
 		&entity.Order{},
 		&entity.PaymentMethod{},
 		&entity.Table{},
 		&entity.Reservations{},
+		&entity.StocktakeCount{},
+		&entity.StocktakeLine{},
+		&entity.Stocktake{},
+		&entity.GoodsReceiptItem{},
+		&entity.GoodsReceipt{},
+		&entity.PurchaseOrderItem{},
+		&entity.PurchaseOrder{},
+		&entity.Supplier{},
+		&entity.InventoryBatch{},
+		&entity.CostLayer{},
+		&entity.StockMovement{},
+		&entity.InventoryPackSize{},
 		&entity.Inventories{},
 		&entity.Staff{},
 		&entity.Notification{},
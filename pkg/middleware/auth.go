@@ -97,6 +97,48 @@ func AuthMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	}
 }
 
+const (
+	// StreamTokenQueryParam is the query parameter carrying the JWT on stream routes
+	StreamTokenQueryParam = "access_token"
+	// WebSocketTokenProtocol marks the JWT in Sec-WebSocket-Protocol: the client offers
+	// "bearer, <token>" and the server answers with "bearer"
+	WebSocketTokenProtocol = "bearer"
+)
+
+// StreamAuthMiddleware works like AuthMiddleware, but also accepts the token from the access_token
+// query parameter or Sec-WebSocket-Protocol, because browser EventSource and WebSocket clients cannot
+// send an Authorization header. Only use it on stream routes.
+func StreamAuthMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	auth := AuthMiddleware(logger)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := streamToken(c.Request); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		auth(c)
+	}
+}
+
+// streamToken extracts the token from the query string or the Sec-WebSocket-Protocol header
+func streamToken(r *http.Request) string {
+	if token := r.URL.Query().Get(StreamTokenQueryParam); token != "" {
+		return token
+	}
+	var protocols []string
+	for _, value := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(value, ",") {
+			protocols = append(protocols, strings.TrimSpace(protocol))
+		}
+	}
+	for i := 0; i+1 < len(protocols); i++ {
+		if protocols[i] == WebSocketTokenProtocol {
+			return protocols[i+1]
+		}
+	}
+	return ""
+}
+
 // RoleMiddleware checks if user has required role
 func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
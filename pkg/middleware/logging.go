@@ -3,6 +3,8 @@ package middleware
 import (
 	"bytes"
 	"io"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +15,7 @@ import (
 func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
+		query := redactQuery(c.Request.URL.RawQuery)
 
 		// Read request body for logging (if present)
 		var requestBody []byte
@@ -28,7 +31,7 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		logger.Info("incoming request",
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
-			zap.String("query", c.Request.URL.RawQuery),
+			zap.String("query", query),
 			zap.String("remote_addr", c.ClientIP()),
 			zap.String("user_agent", c.Request.UserAgent()),
 			zap.String("referer", c.Request.Referer()),
@@ -50,7 +53,7 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 			logger.Error("request completed with server error",
 				zap.String("method", c.Request.Method),
 				zap.String("path", c.Request.URL.Path),
-				zap.String("query", c.Request.URL.RawQuery),
+				zap.String("query", query),
 				zap.Int("status", statusCode),
 				zap.Duration("duration", duration),
 				zap.Int64("response_size", responseSize),
@@ -61,7 +64,7 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 			logger.Warn("request completed with client error",
 				zap.String("method", c.Request.Method),
 				zap.String("path", c.Request.URL.Path),
-				zap.String("query", c.Request.URL.RawQuery),
+				zap.String("query", query),
 				zap.Int("status", statusCode),
 				zap.Duration("duration", duration),
 				zap.Int64("response_size", responseSize),
@@ -72,7 +75,7 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 			logger.Info("request completed",
 				zap.String("method", c.Request.Method),
 				zap.String("path", c.Request.URL.Path),
-				zap.String("query", c.Request.URL.RawQuery),
+				zap.String("query", query),
 				zap.Int("status", statusCode),
 				zap.Duration("duration", duration),
 				zap.Int64("response_size", responseSize),
@@ -80,3 +83,16 @@ func LoggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		}
 	}
 }
+
+// redactQuery hides the stream token (see StreamAuthMiddleware) before the query is logged
+func redactQuery(rawQuery string) string {
+	if !strings.Contains(rawQuery, StreamTokenQueryParam) {
+		return rawQuery
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || !values.Has(StreamTokenQueryParam) {
+		return rawQuery
+	}
+	values.Set(StreamTokenQueryParam, "REDACTED")
+	return values.Encode()
+}
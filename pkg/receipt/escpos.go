@@ -0,0 +1,68 @@
+package receipt
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Perintah ESC/POS yang dipakai, didukung umumnya printer thermal 58mm/80mm
+var (
+	escInit         = []byte{0x1B, 0x40}             // ESC @ reset printer
+	escAlignLeft    = []byte{0x1B, 0x61, 0x00}       // ESC a 0
+	escAlignCenter  = []byte{0x1B, 0x61, 0x01}       // ESC a 1
+	escBoldOn       = []byte{0x1B, 0x45, 0x01}       // ESC E 1
+	escBoldOff      = []byte{0x1B, 0x45, 0x00}       // ESC E 0
+	escDoubleHeight = []byte{0x1D, 0x21, 0x01}       // GS ! 1, tinggi dua kali tanpa mengubah lebar
+	escNormalSize   = []byte{0x1D, 0x21, 0x00}       // GS ! 0
+	escFeedLines    = []byte{0x1B, 0x64, 0x04}       // ESC d 4, majukan kertas sebelum dipotong
+	escPartialCut   = []byte{0x1D, 0x56, 0x42, 0x00} // GS V 66 0, potong kertas sebagian
+)
+
+// RenderESCPOS membuat byte stream ESC/POS yang bisa dikirim langsung ke printer thermal.
+// Teks dibatasi ke ASCII karena code page printer berbeda-beda.
+func RenderESCPOS(r Receipt) []byte {
+	var b bytes.Buffer
+	b.Write(escInit)
+
+	for _, l := range layout(r) {
+		if l.align == alignCenter {
+			b.Write(escAlignCenter)
+		} else {
+			b.Write(escAlignLeft)
+		}
+		if l.bold {
+			b.Write(escBoldOn)
+		}
+		if l.large {
+			b.Write(escDoubleHeight)
+		}
+
+		b.WriteString(toASCII(strings.TrimRight(l.text, " ")))
+		b.WriteByte('\n')
+
+		if l.large {
+			b.Write(escNormalSize)
+		}
+		if l.bold {
+			b.Write(escBoldOff)
+		}
+	}
+
+	b.Write(escAlignLeft)
+	b.Write(escFeedLines)
+	b.Write(escPartialCut)
+	return b.Bytes()
+}
+
+// toASCII mengganti karakter non-ASCII dan karakter kontrol dengan '?'
+func toASCII(text string) string {
+	var b strings.Builder
+	for _, ch := range text {
+		if ch < 0x20 || ch > 0x7E {
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// htmlTemplate adalah template receipt HTML, lebar halaman mengikuti lebar kertas printer
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"amount": FormatAmount,
+	"lines":  splitLines,
+	"marker": statusMarker,
+	"copyMarker": func() string {
+		return CopyMarker
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Receipt Order #{{.OrderID}}</title>
+<style>
+  body { width: {{.PaperWidth}}mm; margin: 0 auto; font-family: monospace; font-size: 12px; }
+  .center { text-align: center; }
+  .bold { font-weight: bold; }
+  .outlet { font-size: 16px; font-weight: bold; }
+  .copy { text-align: center; font-weight: bold; letter-spacing: 2px; }
+  hr { border: none; border-top: 1px dashed #000; }
+  table { width: 100%; border-collapse: collapse; }
+  td.amount { text-align: right; white-space: nowrap; }
+  td.indent { padding-left: 8px; }
+  @media print { @page { size: {{.PaperWidth}}mm auto; margin: 0; } }
+</style>
+</head>
+<body>
+{{- if .IsCopy}}
+<div class="copy">{{copyMarker}}</div>
+{{- end}}
+<div class="center">
+  <div class="outlet">{{.OutletName}}</div>
+  {{- if .OutletAddress}}<div>{{.OutletAddress}}</div>{{end}}
+  {{- if .OutletPhone}}<div>Telp. {{.OutletPhone}}</div>{{end}}
+  {{- range lines .Header}}<div>{{.}}</div>{{end}}
+</div>
+<hr>
+<table>
+  <tr><td>Order #{{.OrderID}}</td><td class="amount">{{.CreatedAt.Format "02/01/2006 15:04"}}</td></tr>
+  {{- with .TableLabel}}<tr><td colspan="2">{{.}}</td></tr>{{end}}
+  {{- if .CustomerName}}<tr><td colspan="2">Pelanggan: {{.CustomerName}}</td></tr>{{end}}
+</table>
+{{- with marker .Status}}
+<div class="copy">{{.}}</div>
+{{- end}}
+<hr>
+<table>
+  {{- range .Items}}
+  <tr><td colspan="2">{{.Name}}</td></tr>
+  {{- range .Components}}
+  <tr><td class="indent" colspan="2">- {{.}}</td></tr>
+  {{- end}}
+  {{- range .Modifiers}}
+  <tr><td class="indent" colspan="2">{{.Label}}</td></tr>
+  {{- end}}
+  {{- if .Notes}}
+  <tr><td class="indent" colspan="2">Catatan: {{.Notes}}</td></tr>
+  {{- end}}
+  <tr><td class="indent">{{.Quantity}} x {{amount .Price}}</td><td class="amount">{{amount .Subtotal}}</td></tr>
+  {{- if gt .DiscountAmount 0.0}}
+  <tr><td class="indent">Diskon</td><td class="amount">-{{amount .DiscountAmount}}</td></tr>
+  {{- end}}
+  {{- end}}
+</table>
+<hr>
+<table>
+  <tr><td>Subtotal</td><td class="amount">{{amount .Subtotal}}</td></tr>
+  {{- if gt .DiscountAmount 0.0}}
+  <tr><td>Diskon</td><td class="amount">-{{amount .DiscountAmount}}</td></tr>
+  {{- end}}
+  {{- range .Taxes}}
+  <tr><td>{{.Label}}</td><td class="amount">{{amount .Amount}}</td></tr>
+  {{- end}}
+  <tr class="bold"><td>TOTAL</td><td class="amount">{{amount .TotalAmount}}</td></tr>
+</table>
+<hr>
+<table>
+  {{- range .Payments}}
+  <tr><td>{{.Method}}</td><td class="amount">{{if gt .Tendered .Amount}}{{amount .Tendered}}{{else}}{{amount .Amount}}{{end}}</td></tr>
+  {{- end}}
+  {{- if gt .Change 0.0}}
+  <tr><td>Kembalian</td><td class="amount">{{amount .Change}}</td></tr>
+  {{- end}}
+  {{- with .RemainingAmount}}{{if gt . 0.0}}
+  <tr class="bold"><td>Sisa Tagihan</td><td class="amount">{{amount .}}</td></tr>
+  {{- end}}{{end}}
+  {{- if gt .RefundedAmount 0.0}}
+  <tr><td>Refund</td><td class="amount">-{{amount .RefundedAmount}}</td></tr>
+  {{- end}}
+</table>
+<div class="center">
+  {{- range lines .Footer}}<div>{{.}}</div>{{end}}
+  <div>Dicetak {{.PrintedAt.Format "02/01/2006 15:04"}}</div>
+</div>
+{{- if .IsCopy}}
+<div class="copy">{{copyMarker}}</div>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML membuat receipt HTML yang siap dicetak dari browser
+func RenderHTML(r Receipt) ([]byte, error) {
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, r); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
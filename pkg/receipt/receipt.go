@@ -0,0 +1,191 @@
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Format receipt yang didukung
+const (
+	FormatText   = "text"
+	FormatHTML   = "html"
+	FormatESCPOS = "escpos"
+)
+
+// Lebar kertas printer thermal yang didukung (mm)
+const (
+	PaperWidth58 = 58
+	PaperWidth80 = 80
+)
+
+// CopyMarker dicetak di atas dan bawah receipt cetak ulang
+const CopyMarker = "*** COPY ***"
+
+// ErrInvalidFormat dikembalikan jika format receipt tidak dikenal
+var ErrInvalidFormat = errors.New("format receipt tidak valid, gunakan text, html, atau escpos")
+
+// Receipt berisi data yang dicetak pada struk order
+type Receipt struct {
+	OutletName     string
+	OutletAddress  string
+	OutletPhone    string
+	Header         string // Teks tambahan di bawah nama outlet, bisa beberapa baris
+	Footer         string // Teks penutup, bisa beberapa baris
+	PaperWidth     int    // 58 atau 80 (mm)
+	OrderID        uint
+	OrderType      string
+	TableNumber    string
+	CustomerName   string
+	Status         string
+	CreatedAt      time.Time
+	PrintedAt      time.Time
+	Items          []Item
+	Subtotal       float64
+	DiscountAmount float64
+	Taxes          []TaxLine
+	TotalAmount    float64
+	Payments       []Payment
+	PaidAmount     float64
+	Change         float64
+	RefundedAmount float64
+	IsCopy         bool
+}
+
+// Item adalah satu baris item pada receipt
+type Item struct {
+	Name           string
+	Quantity       int
+	Price          float64
+	Subtotal       float64
+	DiscountAmount float64
+	Modifiers      []Modifier // Modifier yang dipilih, harganya sudah termasuk di Price
+	Components     []string   // Isi paket bundle, contoh "1x Cola - Large"
+	Notes          string
+}
+
+// Modifier adalah modifier yang dipilih pada item receipt
+type Modifier struct {
+	Name       string
+	PriceDelta float64
+}
+
+// Label mengembalikan teks modifier, contoh "+ Extra Cheese (5.000)"
+func (m Modifier) Label() string {
+	if m.PriceDelta == 0 {
+		return "+ " + m.Name
+	}
+	return fmt.Sprintf("+ %s (%s)", m.Name, FormatAmount(m.PriceDelta))
+}
+
+// TaxLine adalah rincian pajak/service charge pada receipt
+type TaxLine struct {
+	Name        string
+	Rate        float64
+	Amount      float64
+	IsInclusive bool
+}
+
+// Payment adalah satu tender pembayaran pada receipt
+type Payment struct {
+	Method   string
+	Amount   float64
+	Tendered float64
+	Change   float64
+}
+
+// RemainingAmount menghitung sisa tagihan yang belum dibayar
+func (r Receipt) RemainingAmount() float64 {
+	remaining := math.Round((r.TotalAmount-r.PaidAmount)*100) / 100
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// Render membuat receipt dalam format yang diminta beserta content type-nya
+func Render(r Receipt, format string) ([]byte, string, error) {
+	switch format {
+	case FormatText, "":
+		return []byte(RenderText(r)), "text/plain; charset=utf-8", nil
+	case FormatHTML:
+		body, err := RenderHTML(r)
+		if err != nil {
+			return nil, "", err
+		}
+		return body, "text/html; charset=utf-8", nil
+	case FormatESCPOS:
+		return RenderESCPOS(r), "application/octet-stream", nil
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrInvalidFormat, format)
+	}
+}
+
+// Columns mengembalikan jumlah karakter per baris untuk lebar kertas (font A printer thermal)
+func Columns(paperWidth int) int {
+	if paperWidth == PaperWidth80 {
+		return 48
+	}
+	return 32
+}
+
+// FormatAmount memformat nominal rupiah dengan pemisah ribuan titik, contoh 15000 -> "15.000"
+func FormatAmount(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var b strings.Builder
+	for i, ch := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(ch)
+	}
+	if frac := cents % 100; frac != 0 {
+		fmt.Fprintf(&b, ",%02d", frac)
+	}
+	return sign + b.String()
+}
+
+// FormatRate memformat persentase tanpa nol di belakang koma, contoh 10 -> "10%", 2.5 -> "2.5%"
+func FormatRate(rate float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", rate), "0"), ".") + "%"
+}
+
+// statusMarker mengembalikan penanda status order yang perlu dicetak, kosong untuk order normal
+func statusMarker(status string) string {
+	switch status {
+	case "cancelled":
+		return "*** VOID ***"
+	case "refunded":
+		return "*** REFUND ***"
+	}
+	return ""
+}
+
+// TableLabel mengembalikan label meja, atau "Take Away" untuk order take away
+func (r Receipt) TableLabel() string {
+	if r.OrderType == "take_away" {
+		return "Take Away"
+	}
+	if r.TableNumber == "" {
+		return ""
+	}
+	return "Meja " + r.TableNumber
+}
+
+// Label mengembalikan label rincian pajak, contoh "PPN 11%" atau "PPN 11% (incl.)"
+func (t TaxLine) Label() string {
+	label := t.Name + " " + FormatRate(t.Rate)
+	if t.IsInclusive {
+		label += " (incl.)"
+	}
+	return label
+}
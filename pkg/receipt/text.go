@@ -0,0 +1,202 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Perataan baris receipt
+const (
+	alignLeft = iota
+	alignCenter
+)
+
+// line adalah satu baris receipt yang sudah dipotong sesuai lebar kertas
+type line struct {
+	text  string
+	align int
+	bold  bool
+	large bool
+}
+
+// RenderText membuat receipt teks polos dengan lebar sesuai kertas printer
+func RenderText(r Receipt) string {
+	width := Columns(r.PaperWidth)
+
+	var b strings.Builder
+	for _, l := range layout(r) {
+		text := l.text
+		if l.align == alignCenter {
+			if pad := (width - utf8.RuneCountInString(text)) / 2; pad > 0 {
+				text = strings.Repeat(" ", pad) + text
+			}
+		}
+		b.WriteString(strings.TrimRight(text, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// layout menyusun baris-baris receipt; dipakai bersama oleh format teks dan ESC/POS
+func layout(r Receipt) []line {
+	width := Columns(r.PaperWidth)
+	separator := line{text: strings.Repeat("-", width)}
+
+	var lines []line
+	center := func(text string, bold, large bool) {
+		for _, chunk := range wrap(text, width) {
+			lines = append(lines, line{text: chunk, align: alignCenter, bold: bold, large: large})
+		}
+	}
+	left := func(text string) {
+		for _, chunk := range wrap(text, width) {
+			lines = append(lines, line{text: chunk})
+		}
+	}
+	row := func(label, value string, bold bool) {
+		lines = append(lines, line{text: columns(label, value, width), bold: bold})
+	}
+
+	if r.IsCopy {
+		center(CopyMarker, true, false)
+	}
+	center(r.OutletName, true, true)
+	if r.OutletAddress != "" {
+		center(r.OutletAddress, false, false)
+	}
+	if r.OutletPhone != "" {
+		center("Telp. "+r.OutletPhone, false, false)
+	}
+	for _, text := range splitLines(r.Header) {
+		center(text, false, false)
+	}
+	lines = append(lines, separator)
+
+	row(fmt.Sprintf("Order #%d", r.OrderID), r.CreatedAt.Format("02/01/2006 15:04"), false)
+	if label := r.TableLabel(); label != "" {
+		left(label)
+	}
+	if r.CustomerName != "" {
+		left("Pelanggan: " + r.CustomerName)
+	}
+	if marker := statusMarker(r.Status); marker != "" {
+		center(marker, true, false)
+	}
+	lines = append(lines, separator)
+
+	for _, item := range r.Items {
+		left(item.Name)
+		for _, c := range item.Components {
+			left("  - " + c)
+		}
+		for _, m := range item.Modifiers {
+			left("  " + m.Label())
+		}
+		if item.Notes != "" {
+			left("  Catatan: " + item.Notes)
+		}
+		row(fmt.Sprintf("  %d x %s", item.Quantity, FormatAmount(item.Price)), FormatAmount(item.Subtotal), false)
+		if item.DiscountAmount > 0 {
+			row("  Diskon", "-"+FormatAmount(item.DiscountAmount), false)
+		}
+	}
+	lines = append(lines, separator)
+
+	row("Subtotal", FormatAmount(r.Subtotal), false)
+	if r.DiscountAmount > 0 {
+		row("Diskon", "-"+FormatAmount(r.DiscountAmount), false)
+	}
+	for _, tax := range r.Taxes {
+		row(tax.Label(), FormatAmount(tax.Amount), false)
+	}
+	row("TOTAL", FormatAmount(r.TotalAmount), true)
+	lines = append(lines, separator)
+
+	for _, payment := range r.Payments {
+		if payment.Tendered > payment.Amount {
+			row(payment.Method, FormatAmount(payment.Tendered), false)
+		} else {
+			row(payment.Method, FormatAmount(payment.Amount), false)
+		}
+	}
+	if r.Change > 0 {
+		row("Kembalian", FormatAmount(r.Change), false)
+	}
+	if remaining := r.RemainingAmount(); remaining > 0 {
+		row("Sisa Tagihan", FormatAmount(remaining), true)
+	}
+	if r.RefundedAmount > 0 {
+		row("Refund", "-"+FormatAmount(r.RefundedAmount), false)
+	}
+	if len(r.Payments) > 0 || r.Change > 0 || r.RefundedAmount > 0 || r.RemainingAmount() > 0 {
+		lines = append(lines, separator)
+	}
+
+	for _, text := range splitLines(r.Footer) {
+		center(text, false, false)
+	}
+	center("Dicetak "+r.PrintedAt.Format("02/01/2006 15:04"), false, false)
+	if r.IsCopy {
+		center(CopyMarker, true, false)
+	}
+
+	return lines
+}
+
+// columns menyusun label di kiri dan nilai di kanan dalam satu baris, label dipotong jika tidak muat
+func columns(label, value string, width int) string {
+	space := width - utf8.RuneCountInString(value) - 1
+	if space < 0 {
+		space = 0
+	}
+	label = truncate(label, space)
+	gap := width - utf8.RuneCountInString(label) - utf8.RuneCountInString(value)
+	if gap < 1 {
+		gap = 1
+	}
+	return label + strings.Repeat(" ", gap) + value
+}
+
+// wrap memecah teks menjadi beberapa baris dengan panjang maksimal width karakter
+func wrap(text string, width int) []string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		chunks = append(chunks, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
+
+// truncate memotong teks menjadi maksimal width karakter
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width])
+}
+
+// splitLines memecah teks header/footer per baris dan membuang baris kosong di awal/akhir
+func splitLines(text string) []string {
+	text = strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,141 @@
+// Package unit menyediakan katalog satuan dan konversi sederhana untuk resep dan inventory
+// (massa, volume, dan jumlah). Satuan yang tidak dikenal hanya bisa dikonversi ke satuan yang sama.
+// Kemasan per item (contoh: crate, karton) tidak ada di katalog ini karena isinya berbeda
+// per inventory; lihat entity.InventoryPackSize.
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrIncompatible dikembalikan jika dua satuan tidak bisa dikonversi (contoh: gram ke litre)
+var ErrIncompatible = errors.New("satuan tidak bisa dikonversi")
+
+type dimension string
+
+const (
+	mass   dimension = "mass"
+	volume dimension = "volume"
+	count  dimension = "count"
+)
+
+type definition struct {
+	dimension dimension
+	factor    float64 // Kelipatan terhadap satuan dasar dimensinya (gram, ml, pcs)
+}
+
+var definitions = map[string]definition{
+	"ton":   {mass, 1000000},
+	"kg":    {mass, 1000},
+	"g":     {mass, 1},
+	"gr":    {mass, 1},
+	"gram":  {mass, 1},
+	"mg":    {mass, 0.001},
+	"l":     {volume, 1000},
+	"litre": {volume, 1000},
+	"liter": {volume, 1000},
+	"dl":    {volume, 100},
+	"cl":    {volume, 10},
+	"ml":    {volume, 1},
+	"pcs":   {count, 1},
+	"pc":    {count, 1},
+	"piece": {count, 1},
+	"lusin": {count, 12},
+	"dozen": {count, 12},
+}
+
+// baseUnits adalah satuan dasar tiap dimensi (factor 1)
+var baseUnits = map[dimension]string{
+	mass:   "g",
+	volume: "ml",
+	count:  "pcs",
+}
+
+// Definition menjelaskan satu satuan di katalog
+type Definition struct {
+	Unit   string  `json:"unit"`
+	Family string  `json:"family"`
+	Base   string  `json:"base"`   // Satuan dasar family
+	Factor float64 `json:"factor"` // Kelipatan terhadap satuan dasar
+}
+
+// Catalog mengembalikan semua satuan yang dikenal, urut per family lalu factor
+func Catalog() []Definition {
+	catalog := make([]Definition, 0, len(definitions))
+	for u, d := range definitions {
+		catalog = append(catalog, Definition{
+			Unit:   u,
+			Family: string(d.dimension),
+			Base:   baseUnits[d.dimension],
+			Factor: d.factor,
+		})
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		if catalog[i].Family != catalog[j].Family {
+			return catalog[i].Family < catalog[j].Family
+		}
+		if catalog[i].Factor != catalog[j].Factor {
+			return catalog[i].Factor < catalog[j].Factor
+		}
+		return catalog[i].Unit < catalog[j].Unit
+	})
+	return catalog
+}
+
+// Family mengembalikan family satuan (mass, volume, count), atau string kosong jika tidak dikenal
+func Family(u string) string {
+	if d, ok := definitions[Normalize(u)]; ok {
+		return string(d.dimension)
+	}
+	return ""
+}
+
+// FamilyUnits mengembalikan semua satuan yang sefamily dengan u. u boleh berupa satuan
+// (contoh: "litre" menghasilkan l, litre, ml, ...) atau nama family ("volume").
+// Satuan yang tidak dikenal hanya mengembalikan dirinya sendiri.
+func FamilyUnits(u string) []string {
+	u = Normalize(u)
+	family := dimension(u)
+	if d, ok := definitions[u]; ok {
+		family = d.dimension
+	} else if _, ok := baseUnits[family]; !ok {
+		return []string{u}
+	}
+
+	units := make([]string, 0)
+	for name, d := range definitions {
+		if d.dimension == family {
+			units = append(units, name)
+		}
+	}
+	sort.Strings(units)
+	return units
+}
+
+// Normalize menyeragamkan penulisan satuan (huruf kecil, tanpa spasi)
+func Normalize(u string) string {
+	return strings.ToLower(strings.TrimSpace(u))
+}
+
+// Compatible mengembalikan true jika from bisa dikonversi ke to
+func Compatible(from, to string) bool {
+	_, err := Convert(1, from, to)
+	return err == nil
+}
+
+// Convert mengubah quantity dari satuan from ke satuan to, contoh Convert(330, "ml", "litre") = 0.33
+func Convert(quantity float64, from, to string) (float64, error) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return quantity, nil
+	}
+	f, okFrom := definitions[from]
+	t, okTo := definitions[to]
+	if !okFrom || !okTo || f.dimension != t.dimension {
+		return 0, fmt.Errorf("%w: %s ke %s", ErrIncompatible, from, to)
+	}
+	return quantity * f.factor / t.factor, nil
+}
@@ -15,6 +15,8 @@ type Configuration struct {
 	Limit       int
 	PathLogging string
 	JWTSecret   string
+	Reservation ReservationConfig
+	Inventory   InventoryConfig
 	DB          DatabaseCofig
 	SMTP        SMTPConfig
 }
@@ -28,6 +30,19 @@ type DatabaseCofig struct {
 	MaxConn  int32
 }
 
+// ReservationConfig mengatur kapan meja ditahan untuk reservasi
+type ReservationConfig struct {
+	HoldMinutes  int // Meja menjadi reserved sejak sekian menit sebelum reservation_time
+	GraceMinutes int // Meja tetap reserved sekian menit setelah reservation_time jika tamu belum datang
+}
+
+// InventoryConfig mengatur perhitungan harga pokok inventory
+type InventoryConfig struct {
+	ValuationMethod      string // fifo atau weighted_average; dipakai untuk COGS, margin, dan laporan nilai persediaan
+	ExpiryAlertDays      int    // Batch yang kedaluwarsa dalam sekian hari dikirim notifikasinya
+	LowStockCheckMinutes int    // Interval pengecekan stok menipis (inventory dan produk); 0 menonaktifkan
+}
+
 type SMTPConfig struct {
 	Host     string
 	Port     string
@@ -49,6 +64,12 @@ func ReadConfiguration() (Configuration, error) {
 	// get config from os variable
 	viper.AutomaticEnv()
 
+	viper.SetDefault("RESERVATION_HOLD_MINUTES", 60)
+	viper.SetDefault("RESERVATION_GRACE_MINUTES", 30)
+	viper.SetDefault("INVENTORY_VALUATION_METHOD", "fifo")
+	viper.SetDefault("INVENTORY_EXPIRY_ALERT_DAYS", 3)
+	viper.SetDefault("INVENTORY_LOW_STOCK_CHECK_MINUTES", 5)
+
 	// get config from flag
 	pflag.Int("port-app", 0, "port for app golang")
 	viper.BindPFlags(pflag.CommandLine)
@@ -61,6 +82,15 @@ func ReadConfiguration() (Configuration, error) {
 		Limit:       viper.GetInt("LIMIT"),
 		PathLogging: viper.GetString("PATH_LOGGING"),
 		JWTSecret:   viper.GetString("JWT_SECRET"),
+		Reservation: ReservationConfig{
+			HoldMinutes:  viper.GetInt("RESERVATION_HOLD_MINUTES"),
+			GraceMinutes: viper.GetInt("RESERVATION_GRACE_MINUTES"),
+		},
+		Inventory: InventoryConfig{
+			ValuationMethod:      viper.GetString("INVENTORY_VALUATION_METHOD"),
+			ExpiryAlertDays:      viper.GetInt("INVENTORY_EXPIRY_ALERT_DAYS"),
+			LowStockCheckMinutes: viper.GetInt("INVENTORY_LOW_STOCK_CHECK_MINUTES"),
+		},
 		DB: DatabaseCofig{
 			Name:     viper.GetString("DATABASE_NAME"),
 			Username: viper.GetString("DATABASE_USERNAME"),